@@ -18,19 +18,32 @@ import (
 	"google.golang.org/grpc"
 
 	apiDataPkg "gitlab.ozon.dev/iTukaev/homework/internal/api/data"
+	consumerPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/consumer"
 	dataPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/data"
+	outboxPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/outbox"
 	configPkg "gitlab.ozon.dev/iTukaev/homework/internal/config"
 	yamlPkg "gitlab.ozon.dev/iTukaev/homework/internal/config/yaml"
 	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
 	"gitlab.ozon.dev/iTukaev/homework/internal/counter"
+	sessionPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session"
+	tokenPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/token"
 	userPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
+	passwordPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/password"
 	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	embeddedPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/embedded"
 	localCachePkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/local"
 	postgresPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres"
+	migratePkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres/migrate"
 	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	kafkaPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/kafka"
+	cacheConnectPkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache/connect"
 	jaegerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/jaeger"
 	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
-	redisPkg "gitlab.ozon.dev/iTukaev/homework/pkg/redis"
+)
+
+const (
+	defaultRetention     = 30 * 24 * time.Hour
+	defaultPurgeInterval = time.Hour
 )
 
 func main() {
@@ -44,6 +57,18 @@ func main() {
 	}
 	logger.Infoln("Start main")
 
+	// data migrate up|down|status|redo
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		command := migratePkg.Up
+		if len(os.Args) > 2 {
+			command = os.Args[2]
+		}
+		if err = migratePkg.Run(context.Background(), config.PGConfig(), command, logger); err != nil {
+			logger.Fatalln("Migrate", err)
+		}
+		return
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer func() {
 		logger.Infoln("Shutting down...")
@@ -53,40 +78,42 @@ func main() {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 
+	done := make(chan struct{})
 	go func() {
 		if err = start(ctx, config, logger); err != nil {
 			logger.Errorln("gRPC", err)
 		}
+		close(done)
 		c <- os.Interrupt
 	}()
 
 	<-c
+	// the storage is closed by start, the durable local storage flushes its journal
+	cancel()
+	<-done
 }
 
 func start(ctx context.Context, config configPkg.Interface, logger *zap.SugaredLogger) (retErr error) {
-	var data repoPkg.Interface
-	if config.Local() {
-		workers := config.WorkersCount()
-		if workers == 0 {
-			workers = 10
-		}
-		data = localCachePkg.New(workers, logger)
-	} else {
-		pg := config.PGConfig()
-		pool, err := postgresPkg.NewPostgres(ctx, pg.Host, pg.Port, pg.User, pg.Password, pg.DBName, logger)
-		if err != nil {
-			logger.Errorln("New Postgres", err)
-			return err
-		}
-		data = postgresPkg.New(pool, logger)
+	data, err := newRepo(ctx, config, logger)
+	if err != nil {
+		return errors.Wrap(err, "new storage")
+	}
+	defer data.Close()
+
+	cache, err := cacheConnectPkg.New(ctx, config.Cache(), config.RedisConfig())
+	if err != nil {
+		return errors.Wrap(err, "new cache")
 	}
+	defer func() {
+		_ = cache.Close()
+	}()
 
-	client, err := redisPkg.New(ctx, config.RedisConfig())
+	hasher, err := passwordPkg.New(config.HasherConfig())
 	if err != nil {
-		return errors.Wrap(err, "new redis client")
+		return errors.Wrap(err, "new password hasher")
 	}
 
-	user := userPkg.New(data, logger, client)
+	user := userPkg.New(data, logger, cache, hasher)
 
 	tracer, closer, err := jaegerPkg.New(config.JService(), config.JHost())
 	if err != nil {
@@ -98,7 +125,13 @@ func start(ctx context.Context, config configPkg.Interface, logger *zap.SugaredL
 	}()
 	opentracing.SetGlobalTracer(tracer)
 
-	server := apiDataPkg.New(user, logger)
+	signer, err := tokenPkg.New(config.AuthConfig().Token)
+	if err != nil {
+		return errors.Wrap(err, "new token signer")
+	}
+	session := sessionPkg.New(user, signer, cache, config.AuthConfig(), logger)
+
+	server := apiDataPkg.New(user, session, logger)
 
 	stopCh := make(chan struct{}, 0)
 	go func() {
@@ -114,11 +147,12 @@ func start(ctx context.Context, config configPkg.Interface, logger *zap.SugaredL
 		close(stopCh)
 	}()
 	go func() {
-		if err = runService(ctx, config.Brokers(), logger, user); err != nil {
+		if err = runService(ctx, config.Brokers(), logger, user, session, config.ConsumerConfig(), data, config.OutboxConfig()); err != nil {
 			retErr = errors.Wrap(err, "consumer service")
 		}
 		close(stopCh)
 	}()
+	go runPurger(ctx, user, config.PurgeConfig(), logger)
 
 	select {
 	case <-ctx.Done():
@@ -158,7 +192,8 @@ func runGRPCServer(ctx context.Context, server pb.UserServer, grpcSrv string, lo
 	return
 }
 
-func runService(ctx context.Context, brokers []string, logger *zap.SugaredLogger, user userPkg.Interface) error {
+func runService(ctx context.Context, brokers []string, logger *zap.SugaredLogger, user userPkg.Interface,
+	session sessionPkg.Interface, consumerCfg consumerPkg.Config, store outboxPkg.Store, outboxCfg outboxPkg.Config) error {
 	cfg := sarama.NewConfig()
 	cfg.Producer.Return.Successes = true
 	cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
@@ -173,19 +208,42 @@ func runService(ctx context.Context, brokers []string, logger *zap.SugaredLogger
 		return errors.Wrap(err, "new ConsumerGroup")
 	}
 
-	handler := dataPkg.NewHandler(user, logger, producer)
+	publisher := kafkaPkg.NewPublisher(producer)
+	handler := dataPkg.NewHandler(user, session, logger, publisher)
+	// the changes are published from the outbox they are written to with the change
+	go outboxPkg.New(store, publisher, outboxCfg, logger).Run(ctx)
 
-	go func() {
-		for {
-			if err = income.Consume(ctx, []string{consts.TopicData}, handler); err != nil {
-				logger.Errorf("on consume: <%v>", err)
-				time.Sleep(time.Second * 5)
+	return consumerPkg.New(kafkaPkg.NewSubscriber(income), publisher, []string{consts.TopicData}, handler.Handle,
+		consumerCfg, logger).Run(ctx)
+}
+
+func runPurger(ctx context.Context, user userPkg.Interface, cfg userPkg.PurgeConfig, logger *zap.SugaredLogger) {
+	if cfg.Retention == 0 {
+		cfg.Retention = defaultRetention
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultPurgeInterval
+	}
+	logger.Infoln("Start purger, retention", cfg.Retention)
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infoln("Purger stopped")
+			return
+		case <-ticker.C:
+			purged, err := user.Purge(ctx, cfg.Retention)
+			if err != nil {
+				logger.Errorf("purge deleted users: %v", err)
+				continue
+			}
+			if purged > 0 {
+				logger.Infof("%d deleted users purged", purged)
 			}
 		}
-	}()
-
-	<-ctx.Done()
-	return income.Close()
+	}
 }
 
 func runHTTPServer(ctx context.Context, httpSrv string, logger *zap.SugaredLogger) (retErr error) {
@@ -193,6 +251,7 @@ func runHTTPServer(ctx context.Context, httpSrv string, logger *zap.SugaredLogge
 	mux.Handle("/counters", expvar.Handler())
 	expvar.Publish("Hit cache", counter.Hit)
 	expvar.Publish("Miss cache", counter.Miss)
+	expvar.Publish("Coalesced cache", counter.Coalesced)
 
 	srv := http.Server{
 		Addr:    httpSrv,
@@ -220,3 +279,33 @@ func runHTTPServer(ctx context.Context, httpSrv string, logger *zap.SugaredLogge
 	logger.Infoln("HTTP gateway stopped")
 	return nil
 }
+
+func newRepo(ctx context.Context, config configPkg.Interface, logger *zap.SugaredLogger) (repoPkg.Interface, error) {
+	switch config.Storage() {
+	case repoPkg.Memory:
+		workers := config.WorkersCount()
+		if workers == 0 {
+			workers = 10
+		}
+		if localCfg := config.LocalConfig(); localCfg.Dir != "" {
+			return localCachePkg.NewDurable(workers, localCfg, logger)
+		}
+		return localCachePkg.New(workers, logger), nil
+	case repoPkg.Embedded:
+		return embeddedPkg.New(config.EmbeddedConfig(), logger)
+	case "", repoPkg.Postgres:
+		pg := config.PGConfig()
+		if pg.AutoMigrate {
+			if err := migratePkg.Run(ctx, pg, migratePkg.Up, logger); err != nil {
+				return nil, errors.Wrap(err, "auto migrate")
+			}
+		}
+		pool, err := postgresPkg.NewPostgres(ctx, pg.Host, pg.Port, pg.User, pg.Password, pg.DBName, logger)
+		if err != nil {
+			return nil, errors.Wrap(err, "new postgres")
+		}
+		return postgresPkg.New(pool, logger), nil
+	default:
+		return nil, errors.Errorf("unknown storage [%s]", config.Storage())
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+
+	"github.com/Shopify/sarama"
+
+	consumerPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/consumer"
+	yamlPkg "gitlab.ozon.dev/iTukaev/homework/internal/config/yaml"
+	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
+)
+
+const usage = "usage: dlq list|redrive <topic>_dlq"
+
+// dlq list <topic>_dlq prints the dead-lettered messages with their failure headers,
+// dlq redrive <topic>_dlq sends the messages not re-driven yet back to the original topic.
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatalln(usage)
+	}
+	command, topic := os.Args[1], os.Args[2]
+
+	config, err := yamlPkg.New()
+	if err != nil {
+		log.Fatalln("Config init error:", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	client, err := sarama.NewClient(config.Brokers(), cfg)
+	if err != nil {
+		log.Fatalln("New client:", err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		log.Fatalln("New SyncProducer:", err)
+	}
+	defer func() {
+		_ = producer.Close()
+	}()
+
+	dlq := consumerPkg.NewDLQ(client, producer, consts.GroupDLQ)
+
+	switch command {
+	case "list":
+		if err = dlq.List(ctx, topic, printMessage); err != nil {
+			log.Fatalln("List:", err)
+		}
+	case "redrive":
+		sent, err := dlq.Redrive(ctx, topic)
+		log.Printf("%d messages re-driven\n", sent)
+		if err != nil {
+			log.Fatalln("Redrive:", err)
+		}
+	default:
+		log.Fatalln(usage)
+	}
+}
+
+func printMessage(msg *sarama.ConsumerMessage) error {
+	fmt.Printf("partition: %d, offset: %d, key: %s, time: %v\n", msg.Partition, msg.Offset, msg.Key, msg.Timestamp)
+	headers := make([]string, 0, len(msg.Headers))
+	for _, header := range msg.Headers {
+		headers = append(headers, fmt.Sprintf("  %s: %s", header.Key, header.Value))
+	}
+	sort.Strings(headers)
+	for _, header := range headers {
+		fmt.Println(header)
+	}
+	fmt.Printf("  value: %s\n", msg.Value)
+	return nil
+}
@@ -5,20 +5,22 @@ import (
 	"log"
 	"os"
 	"os/signal"
-	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	consumerPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/consumer"
 	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/mailing"
 	configPkg "gitlab.ozon.dev/iTukaev/homework/internal/config"
 	yamlPkg "gitlab.ozon.dev/iTukaev/homework/internal/config/yaml"
 	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
+	kafkaPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/kafka"
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+	cacheConnectPkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache/connect"
 	jaegerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/jaeger"
 	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
-	redisPkg "gitlab.ozon.dev/iTukaev/homework/pkg/redis"
 )
 
 func main() {
@@ -62,6 +64,11 @@ func main() {
 }
 
 func runService(ctx context.Context, config configPkg.Interface, logger *zap.SugaredLogger) error {
+	// the results are read by the data service from the cache shared with it
+	if config.Cache() == cachePkg.Memory {
+		return errors.New("memory cache is not shared with the data service, mailing needs redis")
+	}
+
 	cfg := sarama.NewConfig()
 	cfg.Producer.Return.Successes = true
 	cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
@@ -76,22 +83,16 @@ func runService(ctx context.Context, config configPkg.Interface, logger *zap.Sug
 		return errors.Wrap(err, "new ConsumerGroup")
 	}
 
-	client, err := redisPkg.New(ctx, config.RedisConfig())
+	cache, err := cacheConnectPkg.New(ctx, config.Cache(), config.RedisConfig())
 	if err != nil {
-		return errors.Wrap(err, "new redis client")
+		return errors.Wrap(err, "new cache")
 	}
-
-	handler := mailing.NewHandler(logger, producer, client)
-
-	go func() {
-		for {
-			if err = income.Consume(ctx, []string{consts.TopicError, consts.TopicMailing}, handler); err != nil {
-				logger.Errorf("on consume: <%v>", err)
-				time.Sleep(time.Second * 5)
-			}
-		}
+	defer func() {
+		_ = cache.Close()
 	}()
 
-	<-ctx.Done()
-	return income.Close()
+	handler := mailing.NewHandler(logger, cache)
+
+	return consumerPkg.New(kafkaPkg.NewSubscriber(income), kafkaPkg.NewPublisher(producer),
+		[]string{consts.TopicError, consts.TopicMailing}, handler.Handle, config.ConsumerConfig(), logger).Run(ctx)
 }
@@ -30,8 +30,10 @@ import (
 	cmdGetPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/bot/command/get"
 	cmdHelpPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/bot/command/help"
 	cmdListPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/bot/command/list"
+	cmdRestorePkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/bot/command/restore"
 	cmdUpdatePkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/bot/command/update"
 	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	kafkaPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/kafka"
 	grpcPkg "gitlab.ozon.dev/iTukaev/homework/pkg/grpc"
 	jaegerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/jaeger"
 	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
@@ -99,7 +101,7 @@ func start(ctx context.Context, config configPkg.Interface, logger *zap.SugaredL
 		return errors.Wrap(err, "new SyncProducer")
 	}
 
-	server := apiReceiverPkg.New(client, logger, producer)
+	server := apiReceiverPkg.New(client, logger, kafkaPkg.NewPublisher(producer))
 
 	stopCh := make(chan struct{}, 0)
 	go func() {
@@ -143,6 +145,9 @@ func runBot(ctx context.Context, client pb.UserClient, apiKey string, logger *za
 	commandDelete := cmdDeletePkg.New(client, logger)
 	bot.RegisterCommand(commandDelete)
 
+	commandRestore := cmdRestorePkg.New(client, logger)
+	bot.RegisterCommand(commandRestore)
+
 	commandGet := cmdGetPkg.New(client, logger)
 	bot.RegisterCommand(commandGet)
 
@@ -150,11 +155,12 @@ func runBot(ctx context.Context, client pb.UserClient, apiKey string, logger *za
 	bot.RegisterCommand(commandList)
 
 	commandHelp := cmdHelpPkg.New(map[string]string{
-		commandAdd.Name():    commandAdd.Description(),
-		commandUpdate.Name(): commandUpdate.Description(),
-		commandDelete.Name(): commandDelete.Description(),
-		commandGet.Name():    commandGet.Description(),
-		commandList.Name():   commandList.Description(),
+		commandAdd.Name():     commandAdd.Description(),
+		commandUpdate.Name():  commandUpdate.Description(),
+		commandDelete.Name():  commandDelete.Description(),
+		commandRestore.Name(): commandRestore.Description(),
+		commandGet.Name():     commandGet.Description(),
+		commandList.Name():    commandList.Description(),
 	})
 	bot.RegisterCommand(commandHelp)
 
@@ -217,6 +223,8 @@ func runHTTPServer(ctx context.Context, server pb.UserServer, httpSrv string, lo
 				DiscardUnknown: true,
 			},
 		}),
+		runtime.WithIncomingHeaderMatcher(grpcPkg.IncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(grpcPkg.OutgoingHeaderMatcher),
 	)
 
 	mux := http.NewServeMux()
@@ -5,17 +5,18 @@ import (
 	"log"
 	"os"
 	"os/signal"
-	"time"
 
 	"github.com/Shopify/sarama"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	consumerPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/consumer"
 	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/validator"
 	configPkg "gitlab.ozon.dev/iTukaev/homework/internal/config"
 	yamlPkg "gitlab.ozon.dev/iTukaev/homework/internal/config/yaml"
 	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
+	kafkaPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/kafka"
 	jaegerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/jaeger"
 	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
 )
@@ -75,17 +76,9 @@ func runService(ctx context.Context, config configPkg.Interface, logger *zap.Sug
 		return errors.Wrap(err, "new ConsumerGroup")
 	}
 
-	handler := validator.NewHandler(logger, producer)
+	publisher := kafkaPkg.NewPublisher(producer)
+	handler := validator.NewHandler(logger, publisher)
 
-	go func() {
-		for {
-			if err = income.Consume(ctx, []string{consts.TopicValidate}, handler); err != nil {
-				logger.Errorf("on consume: %v", err)
-				time.Sleep(time.Second * 5)
-			}
-		}
-	}()
-
-	<-ctx.Done()
-	return income.Close()
+	return consumerPkg.New(kafkaPkg.NewSubscriber(income), publisher, []string{consts.TopicValidate}, handler.Handle,
+		config.ConsumerConfig(), logger).Run(ctx)
 }
@@ -3,63 +3,131 @@ package data
 import (
 	"context"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	sessionPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session"
 	userPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
 	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
 	grpcPkg "gitlab.ozon.dev/iTukaev/homework/pkg/grpc"
 )
 
-func New(user userPkg.Interface, logger *zap.SugaredLogger) pb.UserServer {
+func New(user userPkg.Interface, session sessionPkg.Interface, logger *zap.SugaredLogger) pb.UserServer {
 	return &core{
-		user:   user,
-		logger: logger,
+		user:    user,
+		session: session,
+		logger:  logger,
 	}
 }
 
 type core struct {
-	user   userPkg.Interface
-	logger *zap.SugaredLogger
+	user    userPkg.Interface
+	session sessionPkg.Interface
+	logger  *zap.SugaredLogger
 	pb.UnimplementedUserServer
 }
 
 func (c *core) UserAllList(in *pb.UserAllListRequest, stream pb.User_UserAllListServer) error {
 	meta := grpcPkg.GetMetaFromContext(stream.Context())
-	c.logger.Debugln(meta, "all users list", in.GetOrder(), in.GetLimit())
+	c.logger.Debugln(meta, "all users list", in.GetOrder(), in.GetLimit(), in.GetPageToken())
 
-	offset := uint64(0)
+	sorting, err := models.ParseOrderBy(in.GetOrderBy())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	params := models.NewUserListParams().
+		OrderSet(in.GetOrder()).
+		LimitSet(in.GetLimit()).
+		WithDeletedSet(in.GetWithDeleted()).
+		PageTokenSet(in.GetPageToken()).
+		SortSet(sorting).
+		FilterSet(adaptor.ToUserFilterCoreModel(in.GetFilter()))
 	for {
-		users, err := c.user.List(stream.Context(), in.GetOrder(), in.GetLimit(), offset)
+		page, err := c.user.List(stream.Context(), *params)
+		if errors.Is(err, errorsPkg.ErrInvalidPageToken) || errors.Is(err, errorsPkg.ErrValidation) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
 		if err != nil {
 			c.logger.Errorln(meta, "get list", err)
 			return status.Error(codes.Internal, err.Error())
 		}
 
-		if len(users) == 0 {
+		if len(page.Users) == 0 {
 			return nil
 		}
 
 		if err = stream.Send(&pb.UserAllListResponse{
-			Users: adaptor.ToUserListPbModel(users),
+			Users:         adaptor.ToUserListPbModel(page.Users),
+			NextPageToken: page.NextPageToken,
 		}); err != nil {
 			c.logger.Errorln(meta, "all users list, send chunk", err)
 			return status.Error(codes.Internal, err.Error())
 		}
-		offset++
+		if page.NextPageToken == "" {
+			return nil
+		}
+		params.PageTokenSet(page.NextPageToken)
 	}
 }
 
+func (c *core) UserHistory(ctx context.Context, in *pb.UserHistoryRequest) (*pb.UserHistoryResponse, error) {
+	meta := grpcPkg.GetMetaFromContext(ctx)
+	c.logger.Debugln(meta, "user history", in.GetName())
+
+	if in.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	changes, err := c.user.History(ctx, in.GetName())
+	if err != nil {
+		c.logger.Errorln(meta, "user history", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UserHistoryResponse{
+		Changes: adaptor.ToUserChangeListPbModel(changes),
+	}, nil
+}
+
+func (c *core) UserGetAsOf(ctx context.Context, in *pb.UserGetAsOfRequest) (*pb.UserGetAsOfResponse, error) {
+	meta := grpcPkg.GetMetaFromContext(ctx)
+	c.logger.Debugln(meta, "user get as of", in.GetName(), in.GetAt())
+
+	if in.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if !in.GetAt().IsValid() {
+		return nil, status.Error(codes.InvalidArgument, "at is required")
+	}
+	user, err := c.user.GetAsOf(ctx, in.GetName(), in.GetAt().AsTime())
+	if errors.Is(err, errorsPkg.ErrUserNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		c.logger.Errorln(meta, "user get as of", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UserGetAsOfResponse{
+		User: adaptor.ToUserPbModel(user),
+	}, nil
+}
+
 func (c *core) Data(ctx context.Context, in *pb.DataRequest) (*pb.DataResponse, error) {
 	data, err := c.user.Data(ctx, in.GetUid())
-	if errors.Is(err, redis.Nil) {
+	if errors.Is(err, cachePkg.ErrNotFound) {
 		return nil, status.Error(codes.NotFound, "key is incorrect or data in not ready yet")
 	}
+	if err != nil {
+		c.logger.Errorln("data", err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
 
 	return &pb.DataResponse{
 		Body: &anypb.Any{
@@ -3,13 +3,19 @@ package data
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	sessionMockPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/mock"
+	sessionModels "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/models"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/token"
 	userMockPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/mock"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
@@ -22,14 +28,15 @@ func TestDataApi_UserAllList(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
 	ctx := context.WithValue(context.Background(), "meta", "meta")
+	users := []models.User{{}, {}}
 
 	cases := []struct {
 		name    string
 		listErr error
 		sendErr error
 		expErr  error
-		first   []models.User
-		second  []models.User
+		first   models.UserListPage
+		second  models.UserListPage
 		toSend  *pb.UserAllListResponse
 	}{
 		{
@@ -37,27 +44,45 @@ func TestDataApi_UserAllList(t *testing.T) {
 			listErr: nil,
 			sendErr: nil,
 			expErr:  nil,
-			first:   []models.User{{}, {}},
-			second:  []models.User{},
-			toSend:  &pb.UserAllListResponse{Users: adaptor.ToUserListPbModel([]models.User{{}, {}})},
+			first:   models.UserListPage{Users: users, NextPageToken: "next"},
+			second:  models.UserListPage{Users: []models.User{}},
+			toSend:  &pb.UserAllListResponse{Users: adaptor.ToUserListPbModel(users), NextPageToken: "next"},
+		},
+		{
+			name:    "success, last page without next page token",
+			listErr: nil,
+			sendErr: nil,
+			expErr:  nil,
+			first:   models.UserListPage{Users: users},
+			second:  models.UserListPage{},
+			toSend:  &pb.UserAllListResponse{Users: adaptor.ToUserListPbModel(users)},
+		},
+		{
+			name:    "failed, List invalid page token",
+			listErr: errorsPkg.ErrInvalidPageToken,
+			sendErr: nil,
+			expErr:  status.Error(codes.InvalidArgument, errorsPkg.ErrInvalidPageToken.Error()),
+			first:   models.UserListPage{},
+			second:  models.UserListPage{},
+			toSend:  nil,
 		},
 		{
 			name:    "failed, List unexpected error",
 			listErr: errorsPkg.ErrUnexpected,
 			sendErr: nil,
 			expErr:  status.Error(codes.Internal, errorsPkg.ErrUnexpected.Error()),
-			first:   []models.User{{}, {}},
-			second:  []models.User{},
-			toSend:  &pb.UserAllListResponse{Users: adaptor.ToUserListPbModel([]models.User{{}, {}})},
+			first:   models.UserListPage{Users: users, NextPageToken: "next"},
+			second:  models.UserListPage{},
+			toSend:  &pb.UserAllListResponse{Users: adaptor.ToUserListPbModel(users), NextPageToken: "next"},
 		},
 		{
 			name:    "failed, Send unexpected error",
 			listErr: nil,
 			sendErr: errorsPkg.ErrUnexpected,
 			expErr:  status.Error(codes.Internal, errorsPkg.ErrUnexpected.Error()),
-			first:   []models.User{{}, {}},
-			second:  []models.User{},
-			toSend:  &pb.UserAllListResponse{Users: adaptor.ToUserListPbModel([]models.User{{}, {}})},
+			first:   models.UserListPage{Users: users, NextPageToken: "next"},
+			second:  models.UserListPage{},
+			toSend:  &pb.UserAllListResponse{Users: adaptor.ToUserListPbModel(users), NextPageToken: "next"},
 		},
 	}
 
@@ -65,19 +90,198 @@ func TestDataApi_UserAllList(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			mockUser := userMockPkg.NewMockInterface(ctl)
 			mockStream := apiMockPkg.NewMockUser_UserAllListServer(ctl)
-			userCtl := New(mockUser, loggerPkg.NewFatal())
+			userCtl := New(mockUser, nil, loggerPkg.NewFatal())
 
 			gomock.InOrder(
 				mockStream.EXPECT().Context().Return(ctx).Times(2),
-				mockUser.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				mockUser.EXPECT().List(gomock.Any(), models.UserListParams{Limit: 2, Sort: []models.UserSort{}}).
 					Return(c.first, c.listErr).Times(1),
 				mockStream.EXPECT().Send(c.toSend).
 					Return(c.sendErr).MaxTimes(1),
 				mockStream.EXPECT().Context().Return(ctx).MaxTimes(1),
-				mockUser.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				mockUser.EXPECT().List(gomock.Any(), models.UserListParams{Limit: 2, PageToken: "next", Sort: []models.UserSort{}}).
 					Return(c.second, c.listErr).MaxTimes(1),
 			)
-			err := userCtl.UserAllList(&pb.UserAllListRequest{}, mockStream)
+			err := userCtl.UserAllList(&pb.UserAllListRequest{Limit: 2}, mockStream)
+
+			require.ErrorIs(t, err, c.expErr)
+		})
+	}
+}
+
+func TestDataApi_UserGetAsOf(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	ctx := context.Background()
+	at := time.Unix(1660412940, 0).UTC()
+	user := models.User{Name: "Ivan", Email: "ivan@email.com", Version: 2}
+
+	cases := []struct {
+		name   string
+		in     *pb.UserGetAsOfRequest
+		getErr error
+		calls  int
+		expErr error
+		expRes *pb.UserGetAsOfResponse
+	}{
+		{
+			name:   "success",
+			in:     &pb.UserGetAsOfRequest{Name: user.Name, At: timestamppb.New(at)},
+			calls:  1,
+			expRes: &pb.UserGetAsOfResponse{User: adaptor.ToUserPbModel(user)},
+		},
+		{
+			name:   "failed, name is empty",
+			in:     &pb.UserGetAsOfRequest{At: timestamppb.New(at)},
+			expErr: status.Error(codes.InvalidArgument, "name is required"),
+		},
+		{
+			name:   "failed, time is not set",
+			in:     &pb.UserGetAsOfRequest{Name: user.Name},
+			expErr: status.Error(codes.InvalidArgument, "at is required"),
+		},
+		{
+			name:   "failed, user not found",
+			in:     &pb.UserGetAsOfRequest{Name: user.Name, At: timestamppb.New(at)},
+			getErr: errorsPkg.ErrUserNotFound,
+			calls:  1,
+			expErr: status.Error(codes.NotFound, errorsPkg.ErrUserNotFound.Error()),
+		},
+		{
+			name:   "failed, unexpected error",
+			in:     &pb.UserGetAsOfRequest{Name: user.Name, At: timestamppb.New(at)},
+			getErr: errorsPkg.ErrUnexpected,
+			calls:  1,
+			expErr: status.Error(codes.Internal, errorsPkg.ErrUnexpected.Error()),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockUser := userMockPkg.NewMockInterface(ctl)
+			userCtl := New(mockUser, nil, loggerPkg.NewFatal())
+
+			mockUser.EXPECT().GetAsOf(gomock.Any(), user.Name, at).
+				Return(user, c.getErr).Times(c.calls)
+			res, err := userCtl.UserGetAsOf(ctx, c.in)
+
+			require.ErrorIs(t, err, c.expErr)
+			require.Equal(t, c.expRes, res)
+		})
+	}
+}
+
+func TestDataApi_UserLogin(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	ctx := context.Background()
+	expToken := sessionModels.Token{
+		AccessToken:  "access",
+		RefreshToken: "id.secret",
+		ExpiresIn:    900,
+		SessionID:    "id",
+	}
+
+	cases := []struct {
+		name     string
+		loginErr error
+		expErr   error
+		expRes   *pb.UserLoginResponse
+	}{
+		{
+			name:     "success",
+			loginErr: nil,
+			expErr:   nil,
+			expRes:   &pb.UserLoginResponse{Token: adaptor.ToTokenPbModel(expToken)},
+		},
+		{
+			name:     "failed, invalid credentials",
+			loginErr: errorsPkg.ErrInvalidCredentials,
+			expErr:   status.Error(codes.Unauthenticated, errorsPkg.ErrInvalidCredentials.Error()),
+			expRes:   nil,
+		},
+		{
+			name:     "failed, unexpected error",
+			loginErr: errorsPkg.ErrUnexpected,
+			expErr:   status.Error(codes.Internal, errorsPkg.ErrUnexpected.Error()),
+			expRes:   nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockSession := sessionMockPkg.NewMockInterface(ctl)
+			userCtl := New(nil, mockSession, loggerPkg.NewFatal())
+
+			mockSession.EXPECT().Login(gomock.Any(), "Ivan", "123").
+				Return(expToken, c.loginErr).Times(1)
+			res, err := userCtl.UserLogin(ctx, &pb.UserLoginRequest{Name: "Ivan", Password: "123"})
+
+			require.ErrorIs(t, err, c.expErr)
+			require.Equal(t, c.expRes, res)
+		})
+	}
+}
+
+func TestDataApi_SessionList(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cases := []struct {
+		name      string
+		bearer    string
+		claims    token.Claims
+		authErr   error
+		authTimes int
+		listTimes int
+		expErr    error
+	}{
+		{
+			name:      "success",
+			bearer:    "Bearer access",
+			claims:    token.Claims{Subject: "Ivan"},
+			authErr:   nil,
+			authTimes: 1,
+			listTimes: 1,
+			expErr:    nil,
+		},
+		{
+			name:      "failed, no access token",
+			bearer:    "",
+			authTimes: 0,
+			listTimes: 0,
+			expErr:    status.Error(codes.Unauthenticated, "access token required"),
+		},
+		{
+			name:      "failed, invalid access token",
+			bearer:    "Bearer access",
+			authErr:   errorsPkg.ErrInvalidToken,
+			authTimes: 1,
+			listTimes: 0,
+			expErr:    status.Error(codes.Unauthenticated, errorsPkg.ErrInvalidToken.Error()),
+		},
+		{
+			name:      "failed, other user's token",
+			bearer:    "Bearer access",
+			claims:    token.Claims{Subject: "Boris"},
+			authErr:   nil,
+			authTimes: 1,
+			listTimes: 0,
+			expErr:    status.Error(codes.PermissionDenied, "access to other user's sessions is denied"),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockSession := sessionMockPkg.NewMockInterface(ctl)
+			userCtl := New(nil, mockSession, loggerPkg.NewFatal())
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", c.bearer))
+
+			mockSession.EXPECT().Authorize(gomock.Any(), "access").
+				Return(c.claims, c.authErr).Times(c.authTimes)
+			mockSession.EXPECT().List(gomock.Any(), "Ivan").
+				Return([]sessionModels.Session{{ID: "id"}}, nil).Times(c.listTimes)
+			_, err := userCtl.SessionList(ctx, &pb.SessionListRequest{Name: "Ivan"})
 
 			require.ErrorIs(t, err, c.expErr)
 		})
@@ -0,0 +1,120 @@
+package data
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
+	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	grpcPkg "gitlab.ozon.dev/iTukaev/homework/pkg/grpc"
+)
+
+func (c *core) UserLogin(ctx context.Context, in *pb.UserLoginRequest) (*pb.UserLoginResponse, error) {
+	meta := grpcPkg.GetMetaFromContext(ctx)
+	c.logger.Debugln(meta, "user login", in.GetName())
+
+	token, err := c.session.Login(ctx, in.GetName(), in.GetPassword())
+	if err != nil {
+		c.logger.Errorln(meta, "user login", err)
+		return nil, sessionStatus(err)
+	}
+
+	return &pb.UserLoginResponse{
+		Token: adaptor.ToTokenPbModel(token),
+	}, nil
+}
+
+func (c *core) UserLogout(ctx context.Context, in *pb.UserLogoutRequest) (*pb.UserLogoutResponse, error) {
+	meta := grpcPkg.GetMetaFromContext(ctx)
+	c.logger.Debugln(meta, "user logout")
+
+	if err := c.session.Logout(ctx, in.GetRefreshToken()); err != nil {
+		c.logger.Errorln(meta, "user logout", err)
+		return nil, sessionStatus(err)
+	}
+
+	return &pb.UserLogoutResponse{}, nil
+}
+
+func (c *core) TokenRefresh(ctx context.Context, in *pb.TokenRefreshRequest) (*pb.TokenRefreshResponse, error) {
+	meta := grpcPkg.GetMetaFromContext(ctx)
+	c.logger.Debugln(meta, "token refresh")
+
+	token, err := c.session.Refresh(ctx, in.GetRefreshToken())
+	if err != nil {
+		c.logger.Errorln(meta, "token refresh", err)
+		return nil, sessionStatus(err)
+	}
+
+	return &pb.TokenRefreshResponse{
+		Token: adaptor.ToTokenPbModel(token),
+	}, nil
+}
+
+func (c *core) SessionList(ctx context.Context, in *pb.SessionListRequest) (*pb.SessionListResponse, error) {
+	meta := grpcPkg.GetMetaFromContext(ctx)
+	c.logger.Debugln(meta, "session list", in.GetName())
+
+	if err := c.authorize(ctx, in.GetName()); err != nil {
+		return nil, err
+	}
+
+	sessions, err := c.session.List(ctx, in.GetName())
+	if err != nil {
+		c.logger.Errorln(meta, "session list", err)
+		return nil, sessionStatus(err)
+	}
+
+	return &pb.SessionListResponse{
+		Sessions: adaptor.ToSessionListPbModel(sessions),
+	}, nil
+}
+
+func (c *core) SessionRevoke(ctx context.Context, in *pb.SessionRevokeRequest) (*pb.SessionRevokeResponse, error) {
+	meta := grpcPkg.GetMetaFromContext(ctx)
+	c.logger.Debugln(meta, "session revoke", in.GetName(), in.GetSessionId())
+
+	if err := c.authorize(ctx, in.GetName()); err != nil {
+		return nil, err
+	}
+
+	if err := c.session.Revoke(ctx, in.GetName(), in.GetSessionId()); err != nil {
+		c.logger.Errorln(meta, "session revoke", err)
+		return nil, sessionStatus(err)
+	}
+
+	return &pb.SessionRevokeResponse{}, nil
+}
+
+// authorize checks the bearer access token belongs to the user.
+func (c *core) authorize(ctx context.Context, name string) error {
+	bearer := grpcPkg.GetBearerFromContext(ctx)
+	if bearer == "" {
+		return status.Error(codes.Unauthenticated, "access token required")
+	}
+	claims, err := c.session.Authorize(ctx, bearer)
+	if err != nil {
+		return sessionStatus(err)
+	}
+	if claims.Subject != name {
+		return status.Error(codes.PermissionDenied, "access to other user's sessions is denied")
+	}
+	return nil
+}
+
+func sessionStatus(err error) error {
+	switch {
+	case errors.Is(err, errorsPkg.ErrInvalidCredentials):
+		return status.Error(codes.Unauthenticated, errorsPkg.ErrInvalidCredentials.Error())
+	case errors.Is(err, errorsPkg.ErrInvalidToken):
+		return status.Error(codes.Unauthenticated, errorsPkg.ErrInvalidToken.Error())
+	case errors.Is(err, errorsPkg.ErrSessionNotFound):
+		return status.Error(codes.NotFound, errorsPkg.ErrSessionNotFound.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
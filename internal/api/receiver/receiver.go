@@ -4,24 +4,34 @@ import (
 	"context"
 	"encoding/json"
 	"io"
-	"time"
 
-	"github.com/Shopify/sarama"
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/envelope"
 	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
 	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/grpc"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
 )
 
-func New(user pb.UserClient, logger *zap.SugaredLogger, producer sarama.SyncProducer) pb.UserServer {
+const (
+	// maxBatchSize limits the users of one batch message.
+	maxBatchSize = 1000
+	// importBatchSize is the size of the batches the imported users are sent in.
+	importBatchSize = 100
+)
+
+func New(user pb.UserClient, logger *zap.SugaredLogger, producer brokerPkg.Publisher) pb.UserServer {
 	return &core{
 		producer: producer,
 		user:     user,
@@ -30,7 +40,7 @@ func New(user pb.UserClient, logger *zap.SugaredLogger, producer sarama.SyncProd
 }
 
 type core struct {
-	producer sarama.SyncProducer
+	producer brokerPkg.Publisher
 	user     pb.UserClient
 	pb.UnimplementedUserServer
 	logger *zap.SugaredLogger
@@ -43,19 +53,12 @@ func (c *core) UserCreate(ctx context.Context, in *pb.UserCreateRequest) (*pb.Us
 
 	c.logger.Debugf("[%s] user create: [%s]", meta, in.User.String())
 
-	user := adaptor.ToUserCoreModel(in.User).CreatedAtSet(time.Now().Unix())
+	user := adaptor.ToUserCoreModel(in.User)
 
-	msg, err := json.Marshal(user)
-	if err != nil {
-		c.logger.Errorf("[%s] marshal err: %v", meta, err)
-		return nil, status.Error(codes.Internal, err.Error())
-	}
+	env := envelope.New(ctx, consts.UserCreate)
+	env.Payload = &pbModels.Envelope_User{User: adaptor.ToUserRequestPbModel(*user)}
 
-	if err = c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-		Topic: consts.TopicValidate,
-		Key:   sarama.StringEncoder(consts.UserCreate),
-		Value: sarama.ByteEncoder(msg),
-	}); err != nil {
+	if err := c.sendEnvelopeWithCtx(ctx, consts.TopicValidate, user.Name, env); err != nil {
 		c.logger.Errorf("[%s] send message err: %v", meta, err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -65,30 +68,91 @@ func (c *core) UserCreate(ctx context.Context, in *pb.UserCreateRequest) (*pb.Us
 	}, nil
 }
 
+func (c *core) UserBatchCreate(ctx context.Context, in *pb.UserBatchCreateRequest) (*pb.UserBatchCreateResponse, error) {
+	meta := grpc.GetMetaFromContext(ctx)
+
+	c.logger.Debugf("[%s] user batch create: [%d]", meta, len(in.GetUsers()))
+
+	if len(in.GetUsers()) == 0 || len(in.GetUsers()) > maxBatchSize {
+		return nil, status.Errorf(codes.InvalidArgument, "batch size must be from 1 to %d", maxBatchSize)
+	}
+
+	uid, err := c.sendBatchWithCtx(ctx, in.GetUsers(), in.GetPubSub().String())
+	if err != nil {
+		c.logger.Errorf("[%s] send batch err: %v", meta, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UserBatchCreateResponse{
+		Uid: uid,
+	}, nil
+}
+
+func (c *core) UserImport(stream pb.User_UserImportServer) error {
+	meta := grpc.GetMetaFromContext(stream.Context())
+	c.logger.Debugf("[%s] user import", meta)
+
+	uids := make([]string, 0)
+	users := make([]*pbModels.User, 0, importBatchSize)
+	var pub string
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			c.logger.Errorf("[%s] user import: next user: %v", meta, err)
+			return status.Error(codes.Internal, err.Error())
+		}
+		if pub == "" {
+			pub = in.GetPubSub().String()
+		}
+		users = append(users, in.GetUser())
+		if len(users) < importBatchSize {
+			continue
+		}
+
+		uid, err := c.sendBatchWithCtx(stream.Context(), users, pub)
+		if err != nil {
+			c.logger.Errorf("[%s] user import: send batch err: %v", meta, err)
+			return status.Error(codes.Internal, err.Error())
+		}
+		uids = append(uids, uid)
+		users = make([]*pbModels.User, 0, importBatchSize)
+	}
+
+	if len(users) != 0 {
+		uid, err := c.sendBatchWithCtx(stream.Context(), users, pub)
+		if err != nil {
+			c.logger.Errorf("[%s] user import: send batch err: %v", meta, err)
+			return status.Error(codes.Internal, err.Error())
+		}
+		uids = append(uids, uid)
+	}
+
+	return stream.SendAndClose(&pb.UserImportResponse{
+		Uids: uids,
+	})
+}
+
 func (c *core) UserUpdate(ctx context.Context, in *pb.UserUpdateRequest) (*pb.UserUpdateResponse, error) {
 	meta := grpc.GetMetaFromContext(ctx)
 	uid := uuid.New().String()
 	ctx = helper.InjectUidPubToCtx(ctx, uid, in.GetPubSub().String())
 
-	c.logger.Debugf("[%s] user create: [%s %s]", meta, in.GetName(), in.Profile.String())
+	c.logger.Debugf("[%s] user update: [%s]", meta, in.GetName())
 
-	user := models.NewUser().
-		NameSet(in.GetName()).
-		PasswordSet(in.Profile.GetPassword()).
-		EmailSet(in.Profile.GetEmail()).
-		FullNameSet(in.Profile.GetFullName())
-
-	msg, err := json.Marshal(user)
+	version, err := expectedVersion(ctx, in.GetVersion())
 	if err != nil {
-		c.logger.Errorf("[%s] marshal err: %v", meta, err)
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	profile := adaptor.ToProfileCoreModel(in.GetName(), in.GetProfile()).
+		VersionSet(version)
 
-	if err = c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-		Topic: consts.TopicValidate,
-		Key:   sarama.StringEncoder(consts.UserUpdate),
-		Value: sarama.ByteEncoder(msg),
-	}); err != nil {
+	env := envelope.New(ctx, consts.UserUpdate)
+	env.Payload = &pbModels.Envelope_Profile{Profile: adaptor.ToProfileUpdatePbModel(*profile)}
+
+	if err := c.sendEnvelopeWithCtx(ctx, consts.TopicValidate, in.GetName(), env); err != nil {
 		c.logger.Errorf("[%s] send message err: %v", meta, err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -105,11 +169,18 @@ func (c *core) UserDelete(ctx context.Context, in *pb.UserDeleteRequest) (*pb.Us
 
 	c.logger.Debugf("[%s] user delete: [%s]", meta, in.GetName())
 
-	if err := c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-		Topic: consts.TopicValidate,
-		Key:   sarama.StringEncoder(consts.UserDelete),
-		Value: sarama.ByteEncoder(in.GetName()),
-	}); err != nil {
+	version, err := expectedVersion(ctx, in.GetVersion())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	env := envelope.New(ctx, consts.UserDelete)
+	env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{
+		Name:    in.GetName(),
+		Version: version,
+	}}
+
+	if err := c.sendEnvelopeWithCtx(ctx, consts.TopicValidate, in.GetName(), env); err != nil {
 		c.logger.Errorf("[%s] send message err: %v", meta, err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -119,18 +190,40 @@ func (c *core) UserDelete(ctx context.Context, in *pb.UserDeleteRequest) (*pb.Us
 	}, nil
 }
 
+func (c *core) UserRestore(ctx context.Context, in *pb.UserRestoreRequest) (*pb.UserRestoreResponse, error) {
+	meta := grpc.GetMetaFromContext(ctx)
+	uid := uuid.New().String()
+	ctx = helper.InjectUidPubToCtx(ctx, uid, in.GetPubSub().String())
+
+	c.logger.Debugf("[%s] user restore: [%s]", meta, in.GetName())
+
+	env := envelope.New(ctx, consts.UserRestore)
+	env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: in.GetName()}}
+
+	if err := c.sendEnvelopeWithCtx(ctx, consts.TopicValidate, in.GetName(), env); err != nil {
+		c.logger.Errorf("[%s] send message err: %v", meta, err)
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.UserRestoreResponse{
+		Uid: uid,
+	}, nil
+}
+
 func (c *core) UserGet(ctx context.Context, in *pb.UserGetRequest) (*pb.UserGetResponse, error) {
 	meta := grpc.GetMetaFromContext(ctx)
 	uid := uuid.New().String()
 	ctx = helper.InjectUidPubToCtx(ctx, uid, in.GetPubSub().String())
 
-	c.logger.Debugf("[%s] user get: [%s]", meta, in.GetName())
+	c.logger.Debugf("[%s] user get: [%s %v]", meta, in.GetName(), in.GetWithDeleted())
+
+	env := envelope.New(ctx, consts.UserGet)
+	env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{
+		Name:        in.GetName(),
+		WithDeleted: in.GetWithDeleted(),
+	}}
 
-	if err := c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-		Topic: consts.TopicValidate,
-		Key:   sarama.StringEncoder(consts.UserGet),
-		Value: sarama.ByteEncoder(in.GetName()),
-	}); err != nil {
+	if err := c.sendEnvelopeWithCtx(ctx, consts.TopicValidate, in.GetName(), env); err != nil {
 		c.logger.Errorf("[%s] send message err: %v", meta, err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -145,24 +238,35 @@ func (c *core) UserList(ctx context.Context, in *pb.UserListRequest) (*pb.UserLi
 	uid := uuid.New().String()
 	ctx = helper.InjectUidPubToCtx(ctx, uid, in.GetPubSub().String())
 
-	c.logger.Debugf("[%s] user list: [%v %v %v]", meta, in.GetLimit(), in.GetOffset(), in.GetOrder())
+	c.logger.Debugf("[%s] user list: [%v %v %v %v %s %s %v]", meta, in.GetLimit(), in.GetOffset(),
+		in.GetOrder(), in.GetWithDeleted(), in.GetPageToken(), in.GetOrderBy(), in.GetFilter())
 
+	sorting, err := models.ParseOrderBy(in.GetOrderBy())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	params := models.NewUserListParams().
 		LimitSet(in.GetLimit()).
 		OffsetSet(in.GetOffset()).
-		OrderSet(in.GetOrder())
-
-	msg, err := json.Marshal(params)
-	if err != nil {
-		c.logger.Errorf("[%s] marshal err: %v", meta, err)
-		return nil, status.Error(codes.Internal, err.Error())
+		OrderSet(in.GetOrder()).
+		WithDeletedSet(in.GetWithDeleted()).
+		PageTokenSet(in.GetPageToken()).
+		SortSet(sorting).
+		FilterSet(adaptor.ToUserFilterCoreModel(in.GetFilter()))
+	if err = params.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if params.PageToken != "" {
+		token, err := models.DecodePageToken(params.PageToken)
+		if err != nil || !token.Match(*params) {
+			return nil, status.Error(codes.InvalidArgument, errorsPkg.ErrInvalidPageToken.Error())
+		}
 	}
 
-	if err = c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-		Topic: consts.TopicData,
-		Key:   sarama.StringEncoder(consts.UserList),
-		Value: sarama.ByteEncoder(msg),
-	}); err != nil {
+	env := envelope.New(ctx, consts.UserList)
+	env.Payload = &pbModels.Envelope_ListParams{ListParams: adaptor.ToUserListParamsPbModel(*params)}
+
+	if err := c.sendEnvelopeWithCtx(ctx, consts.TopicData, uid, env); err != nil {
 		c.logger.Errorf("[%s] send message err: %v", meta, err)
 		return nil, status.Error(codes.Internal, err.Error())
 	}
@@ -177,8 +281,12 @@ func (c *core) UserAllList(in *pb.UserAllListRequest, stream pb.User_UserAllList
 	c.logger.Debugf("[%s] all users list: [%v %v]", meta, in.GetOrder(), in.GetLimit())
 
 	dataStream, err := c.user.UserAllList(stream.Context(), &pb.UserAllListRequest{
-		Order: in.GetOrder(),
-		Limit: in.GetLimit(),
+		Order:       in.GetOrder(),
+		Limit:       in.GetLimit(),
+		WithDeleted: in.GetWithDeleted(),
+		PageToken:   in.GetPageToken(),
+		OrderBy:     in.GetOrderBy(),
+		Filter:      in.GetFilter(),
 	})
 	if err != nil {
 		c.logger.Errorf("[%s] all user list: stream: %v", meta, err)
@@ -190,6 +298,9 @@ func (c *core) UserAllList(in *pb.UserAllListRequest, stream pb.User_UserAllList
 		if errors.Is(err, io.EOF) {
 			return nil
 		}
+		if status.Code(err) == codes.InvalidArgument {
+			return err
+		}
 		if err != nil {
 			c.logger.Errorf("[%s] all users list: next chunk: %v", meta, err)
 			return status.Error(codes.Internal, err.Error())
@@ -202,13 +313,94 @@ func (c *core) UserAllList(in *pb.UserAllListRequest, stream pb.User_UserAllList
 }
 
 func (c *core) Data(ctx context.Context, in *pb.DataRequest) (*pb.DataResponse, error) {
-	return c.user.Data(ctx, in)
+	resp, err := c.user.Data(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	if err = json.Unmarshal(resp.GetBody().GetValue(), &user); err == nil && user.Version != 0 {
+		if err = grpc.SetETag(ctx, user.Version); err != nil {
+			c.logger.Errorf("set ETag: %v", err)
+		}
+	}
+	return resp, nil
+}
+
+func (c *core) UserHistory(ctx context.Context, in *pb.UserHistoryRequest) (*pb.UserHistoryResponse, error) {
+	return c.user.UserHistory(ctx, in)
+}
+
+func (c *core) UserGetAsOf(ctx context.Context, in *pb.UserGetAsOfRequest) (*pb.UserGetAsOfResponse, error) {
+	return c.user.UserGetAsOf(ctx, in)
+}
+
+func (c *core) UserLogin(ctx context.Context, in *pb.UserLoginRequest) (*pb.UserLoginResponse, error) {
+	return c.user.UserLogin(ctx, in)
+}
+
+func (c *core) UserLogout(ctx context.Context, in *pb.UserLogoutRequest) (*pb.UserLogoutResponse, error) {
+	return c.user.UserLogout(ctx, in)
+}
+
+func (c *core) TokenRefresh(ctx context.Context, in *pb.TokenRefreshRequest) (*pb.TokenRefreshResponse, error) {
+	return c.user.TokenRefresh(ctx, in)
+}
+
+func (c *core) SessionList(ctx context.Context, in *pb.SessionListRequest) (*pb.SessionListResponse, error) {
+	return c.user.SessionList(grpc.ForwardAuthorization(ctx), in)
+}
+
+func (c *core) SessionRevoke(ctx context.Context, in *pb.SessionRevokeRequest) (*pb.SessionRevokeResponse, error) {
+	return c.user.SessionRevoke(grpc.ForwardAuthorization(ctx), in)
+}
+
+// expectedVersion returns the version of the request or of the If-Match header.
+func expectedVersion(ctx context.Context, version uint64) (uint64, error) {
+	if version != 0 {
+		return version, nil
+	}
+	return grpc.GetIfMatchFromContext(ctx)
+}
+
+// sendBatchWithCtx sends the users to validation as one batch and returns the uid of its result.
+func (c *core) sendBatchWithCtx(ctx context.Context, users []*pbModels.User, pub string) (string, error) {
+	uid := uuid.New().String()
+	ctx = helper.InjectUidPubToCtx(ctx, uid, pub)
+
+	batch := &pbModels.UserBatch{Users: make([]*pbModels.User, 0, len(users))}
+	for _, user := range users {
+		if user == nil {
+			user = &pbModels.User{}
+		}
+		batch.Users = append(batch.Users, adaptor.ToUserRequestPbModel(*adaptor.ToUserCoreModel(user)))
+	}
+
+	env := envelope.New(ctx, consts.UserBatchCreate)
+	env.Payload = &pbModels.Envelope_Batch{Batch: batch}
+
+	if err := c.sendEnvelopeWithCtx(ctx, consts.TopicValidate, uid, env); err != nil {
+		return "", errors.Wrap(err, "send message")
+	}
+	return uid, nil
+}
+
+// sendEnvelopeWithCtx sends the envelope of the request keyed by key.
+// The deadline of the incoming call, if the client set one, is the deadline of the request.
+func (c *core) sendEnvelopeWithCtx(ctx context.Context, topic, key string, env *pbModels.Envelope) error {
+	if deadline, ok := ctx.Deadline(); ok {
+		env.Deadline = timestamppb.New(deadline)
+	}
+	message, err := envelope.Message(topic, key, env)
+	if err != nil {
+		return err
+	}
+	return c.sendMessageWithCtx(ctx, message)
 }
 
-func (c *core) sendMessageWithCtx(ctx context.Context, message *sarama.ProducerMessage) error {
+func (c *core) sendMessageWithCtx(ctx context.Context, message *brokerPkg.Message) error {
 	if err := helper.InjectHeaders(ctx, message); err != nil {
 		return err
 	}
-	_, _, err := c.producer.SendMessage(message)
-	return err
+	return c.producer.Publish(ctx, message)
 }
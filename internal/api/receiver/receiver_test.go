@@ -0,0 +1,234 @@
+package receiver
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+	brokerMockPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/mock"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+	apiMockPkg "gitlab.ozon.dev/iTukaev/homework/pkg/mock"
+)
+
+// newCtx returns the context with the span started by the tracing interceptor.
+func newCtx(t *testing.T) context.Context {
+	span := opentracing.StartSpan("client")
+	t.Cleanup(span.Finish)
+	return opentracing.ContextWithSpan(context.Background(), span)
+}
+
+func newUsers(count int) []*pbModels.User {
+	users := make([]*pbModels.User, 0, count)
+	for i := 0; i < count; i++ {
+		users = append(users, &pbModels.User{Name: "Ivan", Email: "ivan@mail.ru", Password: "password"})
+	}
+	return users
+}
+
+// batchSize returns the users of the batch envelope of the message.
+func batchSize(t *testing.T, msg *brokerPkg.Message) int {
+	require.Equal(t, consts.TopicValidate, msg.Topic)
+	env := &pbModels.Envelope{}
+	require.NoError(t, proto.Unmarshal(msg.Value, env))
+	require.Equal(t, consts.UserBatchCreate, env.GetOperation())
+	return len(env.GetBatch().GetUsers())
+}
+
+func TestReceiverApi_UserBatchCreate(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	ctx := newCtx(t)
+
+	cases := []struct {
+		name       string
+		users      []*pbModels.User
+		publishErr error
+		published  bool
+		expErr     error
+	}{
+		{
+			name:      "success",
+			users:     newUsers(2),
+			published: true,
+		},
+		{
+			name:   "failed, empty batch",
+			users:  nil,
+			expErr: status.Errorf(codes.InvalidArgument, "batch size must be from 1 to %d", maxBatchSize),
+		},
+		{
+			name:   "failed, batch too large",
+			users:  newUsers(maxBatchSize + 1),
+			expErr: status.Errorf(codes.InvalidArgument, "batch size must be from 1 to %d", maxBatchSize),
+		},
+		{
+			name:       "failed, Publish unexpected error",
+			users:      newUsers(2),
+			publishErr: errorsPkg.ErrUnexpected,
+			published:  true,
+			expErr:     status.Error(codes.Internal, "send message: "+errorsPkg.ErrUnexpected.Error()),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			producer := brokerMockPkg.NewMockPublisher(ctl)
+			if c.published {
+				producer.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *brokerPkg.Message) error {
+						require.Equal(t, len(c.users), batchSize(t, msg))
+						return c.publishErr
+					})
+			}
+
+			server := New(nil, loggerPkg.NewFatal(), producer)
+			resp, err := server.UserBatchCreate(ctx, &pb.UserBatchCreateRequest{Users: c.users})
+			if c.expErr != nil {
+				require.Equal(t, c.expErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, resp.GetUid())
+		})
+	}
+}
+
+func TestReceiverApi_Deadline(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	deadline := time.Now().Add(time.Minute)
+	withDeadline, cancel := context.WithDeadline(newCtx(t), deadline)
+	defer cancel()
+
+	cases := []struct {
+		name        string
+		ctx         context.Context
+		expDeadline bool
+	}{
+		{
+			name:        "deadline of the call",
+			ctx:         withDeadline,
+			expDeadline: true,
+		},
+		{
+			name: "no deadline",
+			ctx:  newCtx(t),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			producer := brokerMockPkg.NewMockPublisher(ctl)
+			producer.EXPECT().Publish(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, msg *brokerPkg.Message) error {
+					env := &pbModels.Envelope{}
+					require.NoError(t, proto.Unmarshal(msg.Value, env))
+					if !c.expDeadline {
+						require.Nil(t, env.GetDeadline())
+						return nil
+					}
+					require.True(t, deadline.Equal(env.GetDeadline().AsTime()))
+					return nil
+				})
+
+			server := New(nil, loggerPkg.NewFatal(), producer)
+			_, err := server.UserBatchCreate(c.ctx, &pb.UserBatchCreateRequest{Users: newUsers(1)})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestReceiverApi_UserImport(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	ctx := newCtx(t)
+
+	cases := []struct {
+		name       string
+		users      int
+		recvErr    error
+		publishErr error
+		batches    []int
+		expErr     error
+	}{
+		{
+			name:    "success, sent in batches",
+			users:   importBatchSize + 1,
+			batches: []int{importBatchSize, 1},
+		},
+		{
+			name:  "success, nothing imported",
+			users: 0,
+		},
+		{
+			name:    "failed, Recv unexpected error",
+			users:   1,
+			recvErr: errorsPkg.ErrUnexpected,
+			expErr:  status.Error(codes.Internal, errorsPkg.ErrUnexpected.Error()),
+		},
+		{
+			name:       "failed, Publish unexpected error",
+			users:      1,
+			publishErr: errorsPkg.ErrUnexpected,
+			batches:    []int{1},
+			expErr:     status.Error(codes.Internal, "send message: "+errorsPkg.ErrUnexpected.Error()),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			producer := brokerMockPkg.NewMockPublisher(ctl)
+			stream := apiMockPkg.NewMockUser_UserImportServer(ctl)
+			stream.EXPECT().Context().Return(ctx).AnyTimes()
+
+			var calls []*gomock.Call
+			for _, user := range newUsers(c.users) {
+				calls = append(calls, stream.EXPECT().Recv().
+					Return(&pb.UserImportRequest{User: user, PubSub: pb.Wait_pub}, nil))
+			}
+			last := io.EOF
+			if c.recvErr != nil {
+				last = c.recvErr
+			}
+			calls = append(calls, stream.EXPECT().Recv().Return(nil, last))
+			gomock.InOrder(calls...)
+
+			for _, size := range c.batches {
+				size := size
+				producer.EXPECT().Publish(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, msg *brokerPkg.Message) error {
+						require.Equal(t, size, batchSize(t, msg))
+						return c.publishErr
+					})
+			}
+			if c.expErr == nil {
+				stream.EXPECT().SendAndClose(gomock.Any()).
+					DoAndReturn(func(resp *pb.UserImportResponse) error {
+						require.Len(t, resp.GetUids(), len(c.batches))
+						return nil
+					})
+			}
+
+			err := New(nil, loggerPkg.NewFatal(), producer).UserImport(stream)
+			if c.expErr != nil {
+				require.Equal(t, c.expErr.Error(), err.Error())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
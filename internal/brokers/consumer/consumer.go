@@ -0,0 +1,383 @@
+package consumer
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+const (
+	defaultRetries      = 3
+	defaultWorkers      = 1
+	defaultBackoff      = time.Second
+	defaultMaxBackoff   = time.Minute
+	defaultRestartDelay = 5 * time.Second
+
+	// queueSize is the number of the messages waiting for the worker
+	queueSize = 16
+
+	dlqSuffix = "_dlq"
+)
+
+// Headers of the dead-lettered messages.
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderAttempt           = "x-attempt"
+	HeaderError             = "x-error"
+	HeaderFailedAt          = "x-failed-at"
+)
+
+// Config sets how many times the failed message is retried before it is dead-lettered,
+// the delay of the first retry doubles with every next one up to MaxBackoff.
+// Workers handle the messages of every claimed partition, the messages with the same key by one worker in order,
+// so the next messages of the key wait while the failed one is retried.
+type Config struct {
+	Retries    int           `mapstructure:"retries"`
+	Backoff    time.Duration `mapstructure:"backoff"`
+	MaxBackoff time.Duration `mapstructure:"max_backoff"`
+	Workers    int           `mapstructure:"workers"`
+}
+
+// HandleFunc handles the message of the topic, the message is retried if it returns an error.
+// The errors caused by errorsPkg.ErrValidation are not retried.
+type HandleFunc func(ctx context.Context, msg *brokerPkg.Message) error
+
+type Interface interface {
+	Run(ctx context.Context) error
+}
+
+// New returns the consumer of the topics.
+// The messages failed after all the retries are sent to the dead-letter topics by producer.
+func New(group brokerPkg.Subscriber, producer brokerPkg.Publisher, topics []string, handle HandleFunc,
+	cfg Config, logger *zap.SugaredLogger) Interface {
+	if cfg.Retries == 0 {
+		cfg.Retries = defaultRetries
+	}
+	if cfg.Backoff == 0 {
+		cfg.Backoff = defaultBackoff
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = defaultMaxBackoff
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	return &consumer{
+		group:  group,
+		topics: topics,
+		handler: &handler{
+			producer: producer,
+			handle:   handle,
+			cfg:      cfg,
+			logger:   logger,
+		},
+		logger: logger,
+	}
+}
+
+type consumer struct {
+	group   brokerPkg.Subscriber
+	topics  []string
+	handler *handler
+	logger  *zap.SugaredLogger
+}
+
+// Run consumes the topics until ctx is done and closes the group.
+func (c *consumer) Run(ctx context.Context) error {
+	for {
+		// Subscribe returns on every rebalance, so it is called in the loop
+		if err := c.group.Subscribe(ctx, c.topics, c.handler); err != nil {
+			c.logger.Errorf("on consume: %v", err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(defaultRestartDelay):
+			}
+		}
+		if ctx.Err() != nil {
+			return c.group.Close()
+		}
+	}
+}
+
+// DLQTopic is the topic of the messages of topic failed after all the retries.
+func DLQTopic(topic string) string {
+	return topic + dlqSuffix
+}
+
+type handler struct {
+	producer brokerPkg.Publisher
+	handle   HandleFunc
+	cfg      Config
+	logger   *zap.SugaredLogger
+}
+
+// ConsumeClaim dispatches the messages of the partition to the workers by their keys.
+// The messages are marked in the partition order, when all the messages before them are completed,
+// so the not completed ones are consumed again after the failure or the rebalance.
+func (h *handler) ConsumeClaim(session brokerPkg.Session, claim brokerPkg.Claim) error {
+	ctx, cancel := context.WithCancel(session.Context())
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		once    sync.Once
+		failure error
+	)
+	marker := newMarker(session)
+	fail := func(err error) {
+		once.Do(func() {
+			failure = err
+			cancel()
+		})
+	}
+	queues := make([]chan *brokerPkg.Message, h.cfg.Workers)
+	for i := range queues {
+		queues[i] = make(chan *brokerPkg.Message, queueSize)
+		w := &worker{
+			handler: h,
+			marker:  marker,
+			fail:    fail,
+			retries: make(map[string]*retry),
+		}
+		wg.Add(1)
+		go func(queue <-chan *brokerPkg.Message) {
+			defer wg.Done()
+			w.run(ctx, queue)
+		}(queues[i])
+	}
+
+dispatch:
+	for {
+		select {
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				break dispatch
+			}
+			marker.add(msg)
+			select {
+			case queues[workerIndex(msg.Key, len(queues))] <- msg:
+			case <-ctx.Done():
+				break dispatch
+			}
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+
+	for _, queue := range queues {
+		close(queue)
+	}
+	wg.Wait()
+	return failure
+}
+
+// worker handles the messages with the same key in order. The failed message is retried after the backoff
+// holding back only the next messages of its key, the messages of the other keys are handled meanwhile.
+type worker struct {
+	*handler
+	marker  *marker
+	fail    func(error)
+	retries map[string]*retry
+	// held is the number of the messages waiting for the retries of their keys
+	held int
+}
+
+// retry is the failed message of the key waiting for its next attempt followed by the next messages of the key.
+type retry struct {
+	attempt  int
+	at       time.Time
+	messages []*brokerPkg.Message
+}
+
+// run handles the messages of the queue until it is closed and the retries are over or ctx is done.
+func (w *worker) run(ctx context.Context, queue <-chan *brokerPkg.Message) {
+	for {
+		if queue == nil && len(w.retries) == 0 {
+			return
+		}
+		// the held messages are limited, the queue is not read until the retries release them
+		in := queue
+		if w.held >= queueSize {
+			in = nil
+		}
+		var (
+			timer *time.Timer
+			due   <-chan time.Time
+		)
+		if at, ok := w.next(); ok {
+			timer = time.NewTimer(time.Until(at))
+			due = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case msg, ok := <-in:
+			if !ok {
+				queue = nil
+				break
+			}
+			key := string(msg.Key)
+			if r, ok := w.retries[key]; ok {
+				r.messages = append(r.messages, msg)
+				w.held++
+				break
+			}
+			w.consume(ctx, key, []*brokerPkg.Message{msg}, 0)
+		case <-due:
+			now := time.Now()
+			for key, r := range w.retries {
+				if r.at.After(now) {
+					continue
+				}
+				delete(w.retries, key)
+				w.held -= len(r.messages)
+				w.consume(ctx, key, r.messages, r.attempt)
+			}
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// next is the time of the earliest retry.
+func (w *worker) next() (time.Time, bool) {
+	var (
+		at    time.Time
+		found bool
+	)
+	for _, r := range w.retries {
+		if !found || r.at.Before(at) {
+			at, found = r.at, true
+		}
+	}
+	return at, found
+}
+
+// consume processes the messages of the key in order starting from the attempt of the first one,
+// the failed message and the messages after it wait for its retry.
+func (w *worker) consume(ctx context.Context, key string, messages []*brokerPkg.Message, attempt int) {
+	for i, msg := range messages {
+		again, err := w.process(ctx, msg, attempt)
+		if err != nil {
+			w.fail(err)
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if again {
+			w.retries[key] = &retry{
+				attempt:  attempt + 1,
+				at:       time.Now().Add(w.backoff(attempt + 1)),
+				messages: messages[i:],
+			}
+			w.held += len(messages) - i
+			return
+		}
+		w.marker.complete(msg)
+		attempt = 0
+	}
+}
+
+// process handles the attempt of the message and returns true if the message failed and is retried.
+// The message failed after all the retries is sent to the dead-letter topic,
+// it returns an error if the message is not sent.
+func (h *handler) process(ctx context.Context, msg *brokerPkg.Message, attempt int) (bool, error) {
+	err := h.handle(ctx, msg)
+	if err == nil || ctx.Err() != nil {
+		return false, nil
+	}
+
+	if attempt < h.cfg.Retries && !errors.Is(err, errorsPkg.ErrValidation) {
+		h.logger.Warnf("message [%s/%d/%d] attempt %d: %v", msg.Topic, msg.Partition, msg.Offset, attempt, err)
+		return true, nil
+	}
+	h.logger.Errorf("message [%s/%d/%d] dead-lettered: %v", msg.Topic, msg.Partition, msg.Offset, err)
+	message := h.dlqMessage(msg, attempt, err)
+	if err = h.producer.Publish(ctx, message); err != nil {
+		return false, errors.Wrapf(err, "send to [%s]", message.Topic)
+	}
+	return false, nil
+}
+
+func (h *handler) dlqMessage(msg *brokerPkg.Message, attempt int, err error) *brokerPkg.Message {
+	headers := originHeaders(msg, headerMap(msg.Headers))
+	headers[HeaderAttempt] = strconv.Itoa(attempt)
+	headers[HeaderError] = err.Error()
+	headers[HeaderFailedAt] = time.Now().UTC().Format(time.RFC3339)
+	return &brokerPkg.Message{
+		Topic:   DLQTopic(msg.Topic),
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: recordHeaders(headers),
+	}
+}
+
+// workerIndex is the index of the worker of the key, the messages with the same key are handled by one worker.
+func workerIndex(key []byte, workers int) int {
+	if workers == 1 {
+		return 0
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write(key)
+	return int(hash.Sum32() % uint32(workers))
+}
+
+// backoff is the delay of the attempt.
+func (h *handler) backoff(attempt int) time.Duration {
+	delay := h.cfg.Backoff
+	for i := 1; i < attempt && delay < h.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > h.cfg.MaxBackoff {
+		delay = h.cfg.MaxBackoff
+	}
+	return delay
+}
+
+// originHeaders copies the headers of the message adding where it was consumed first.
+func originHeaders(msg *brokerPkg.Message, headers map[string]string) map[string]string {
+	res := make(map[string]string, len(headers)+4)
+	for key, value := range headers {
+		res[key] = value
+	}
+	if _, ok := res[HeaderOriginalTopic]; !ok {
+		res[HeaderOriginalTopic] = msg.Topic
+		res[HeaderOriginalPartition] = strconv.FormatInt(int64(msg.Partition), 10)
+		res[HeaderOriginalOffset] = strconv.FormatInt(msg.Offset, 10)
+	}
+	return res
+}
+
+func headerMap(headers []brokerPkg.Header) map[string]string {
+	res := make(map[string]string, len(headers))
+	for _, header := range headers {
+		res[string(header.Key)] = string(header.Value)
+	}
+	return res
+}
+
+func recordHeaders(headers map[string]string) []brokerPkg.Header {
+	res := make([]brokerPkg.Header, 0, len(headers))
+	for key, value := range headers {
+		res = append(res, brokerPkg.Header{
+			Key:   []byte(key),
+			Value: []byte(value),
+		})
+	}
+	return res
+}
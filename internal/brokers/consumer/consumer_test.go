@@ -0,0 +1,354 @@
+package consumer
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+	kafkaPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/kafka"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+)
+
+// session records the marked messages.
+type session struct {
+	brokerPkg.Session
+	ctx    context.Context
+	mu     sync.Mutex
+	marked []int64
+}
+
+func (s *session) Context() context.Context {
+	return s.ctx
+}
+
+func (s *session) MarkMessage(msg *brokerPkg.Message, _ string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.marked = append(s.marked, msg.Offset)
+}
+
+func (s *session) Marked() []int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int64(nil), s.marked...)
+}
+
+// claim returns the messages.
+type claim struct {
+	brokerPkg.Claim
+	messages chan *brokerPkg.Message
+}
+
+func (c *claim) Messages() <-chan *brokerPkg.Message {
+	return c.messages
+}
+
+func header(key, value string) brokerPkg.Header {
+	return brokerPkg.Header{Key: []byte(key), Value: []byte(value)}
+}
+
+func TestHandler_process(t *testing.T) {
+	cases := []struct {
+		name      string
+		msg       *brokerPkg.Message
+		attempt   int
+		handleErr error
+		sendErr   error
+		expSent   map[string]string
+		expRetry  bool
+		expErr    bool
+	}{
+		{
+			name: "success",
+			msg:  &brokerPkg.Message{Topic: "topic", Offset: 7},
+		},
+		{
+			name:      "failed, retried",
+			msg:       &brokerPkg.Message{Topic: "topic", Offset: 7, Headers: []brokerPkg.Header{header("uid", "1")}},
+			attempt:   2,
+			handleErr: errorsPkg.ErrUnexpected,
+			expRetry:  true,
+		},
+		{
+			name:      "failed last retry, dead-lettered",
+			msg:       &brokerPkg.Message{Topic: "topic", Offset: 7, Headers: []brokerPkg.Header{header("uid", "1")}},
+			attempt:   3,
+			handleErr: errorsPkg.ErrUnexpected,
+			expSent: map[string]string{
+				"topic": "topic_dlq", "uid": "1", HeaderOriginalTopic: "topic", HeaderOriginalOffset: "7", HeaderAttempt: "3",
+				HeaderError: errorsPkg.ErrUnexpected.Error(),
+			},
+		},
+		{
+			name:      "failed validation, dead-lettered",
+			msg:       &brokerPkg.Message{Topic: "topic", Offset: 7},
+			handleErr: errors.Wrap(errorsPkg.ErrValidation, "invalid message operation"),
+			expSent: map[string]string{
+				"topic": "topic_dlq", HeaderOriginalTopic: "topic", HeaderAttempt: "0",
+				HeaderError: "invalid message operation: validation error",
+			},
+		},
+		{
+			name:      "failed send",
+			msg:       &brokerPkg.Message{Topic: "topic", Offset: 7},
+			attempt:   3,
+			handleErr: errorsPkg.ErrUnexpected,
+			sendErr:   sarama.ErrOutOfBrokers,
+			expErr:    true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var sent *sarama.ProducerMessage
+			producer := mocks.NewSyncProducer(t, nil)
+			if c.sendErr != nil {
+				producer.ExpectSendMessageAndFail(c.sendErr)
+			}
+			if c.expSent != nil {
+				producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+					sent = msg
+					return nil
+				})
+			}
+
+			h := New(nil, kafkaPkg.NewPublisher(producer), nil, func(_ context.Context, _ *brokerPkg.Message) error {
+				return c.handleErr
+			}, Config{}, loggerPkg.NewFatal()).(*consumer).handler
+
+			retry, err := h.process(context.Background(), c.msg, c.attempt)
+			assert.Equal(t, c.expErr, err != nil)
+			assert.Equal(t, c.expRetry, retry)
+
+			if c.expSent != nil {
+				require.NotNil(t, sent)
+				headers := map[string]string{"topic": sent.Topic}
+				for _, h := range sent.Headers {
+					headers[string(h.Key)] = string(h.Value)
+				}
+				for key, value := range c.expSent {
+					assert.Equal(t, value, headers[key], key)
+				}
+			}
+			assert.NoError(t, producer.Close())
+		})
+	}
+}
+
+func TestHandler_backoff(t *testing.T) {
+	h := &handler{cfg: Config{Backoff: time.Second, MaxBackoff: 5 * time.Second}}
+	assert.Equal(t, time.Second, h.backoff(1))
+	assert.Equal(t, 2*time.Second, h.backoff(2))
+	assert.Equal(t, 4*time.Second, h.backoff(3))
+	assert.Equal(t, 5*time.Second, h.backoff(4))
+}
+
+func TestRedriveMessage(t *testing.T) {
+	msg := redriveMessage(&brokerPkg.Message{
+		Topic: "topic_data_dlq",
+		Key:   []byte("create"),
+		Headers: []brokerPkg.Header{
+			header("uid", "1"),
+			header(HeaderOriginalTopic, "topic_data"),
+			header(HeaderAttempt, "3"),
+			header(HeaderError, "unexpected error"),
+		},
+	})
+	assert.Equal(t, "topic_data", msg.Topic)
+	require.Len(t, msg.Headers, 1)
+	assert.Equal(t, "uid", string(msg.Headers[0].Key))
+}
+
+func TestHandler_ConsumeClaim(t *testing.T) {
+	keys := []string{"anna", "boris", "anna", "clara", "boris", "anna"}
+	messages := make(chan *brokerPkg.Message, len(keys))
+	for i, key := range keys {
+		messages <- &brokerPkg.Message{Topic: "topic", Key: []byte(key), Offset: int64(i)}
+	}
+	close(messages)
+
+	var (
+		mu      sync.Mutex
+		handled = make(map[string][]int64)
+	)
+	h := New(nil, nil, nil, func(_ context.Context, msg *brokerPkg.Message) error {
+		// the first message of anna is the slowest, so the others are completed before it
+		if msg.Offset == 0 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		handled[string(msg.Key)] = append(handled[string(msg.Key)], msg.Offset)
+		return nil
+	}, Config{Workers: 3}, loggerPkg.NewFatal()).(*consumer).handler
+
+	s := &session{ctx: context.Background()}
+	require.NoError(t, h.ConsumeClaim(s, &claim{messages: messages}))
+
+	assert.Equal(t, map[string][]int64{"anna": {0, 2, 5}, "boris": {1, 4}, "clara": {3}}, handled)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5}, s.Marked())
+}
+
+func TestHandler_ConsumeClaimRetry(t *testing.T) {
+	keys := []string{"anna", "boris", "anna", "boris", "clara"}
+	messages := make(chan *brokerPkg.Message, len(keys))
+	for i, key := range keys {
+		messages <- &brokerPkg.Message{Topic: "topic", Key: []byte(key), Offset: int64(i)}
+	}
+	close(messages)
+
+	var sent []string
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		sent = append(sent, msg.Topic)
+		return nil
+	})
+
+	var (
+		mu      sync.Mutex
+		order   []int64
+		handled = make(map[string][]int64)
+	)
+	h := New(nil, kafkaPkg.NewPublisher(producer), nil, func(_ context.Context, msg *brokerPkg.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		order = append(order, msg.Offset)
+		handled[string(msg.Key)] = append(handled[string(msg.Key)], msg.Offset)
+		switch {
+		// the first message of anna is completed on its second attempt
+		case msg.Offset == 0 && len(order) == 1:
+			return errorsPkg.ErrUnexpected
+		// the message of clara fails every attempt
+		case msg.Offset == 4:
+			return errorsPkg.ErrUnexpected
+		}
+		return nil
+	}, Config{Retries: 2, Backoff: 50 * time.Millisecond, Workers: 1}, loggerPkg.NewFatal()).(*consumer).handler
+
+	s := &session{ctx: context.Background()}
+	require.NoError(t, h.ConsumeClaim(s, &claim{messages: messages}))
+
+	// boris and clara are not held back by the retry of anna, the messages of anna are handled in order
+	assert.Equal(t, []int64{0, 1, 3, 4}, order[:4])
+	assert.Equal(t, map[string][]int64{"anna": {0, 0, 2}, "boris": {1, 3}, "clara": {4, 4, 4}}, handled)
+	assert.Equal(t, []string{"topic_dlq"}, sent)
+	assert.Equal(t, []int64{0, 1, 2, 3, 4}, s.Marked())
+	assert.NoError(t, producer.Close())
+}
+
+func TestHandler_ConsumeClaimFailed(t *testing.T) {
+	messages := make(chan *brokerPkg.Message, 3)
+	for i, key := range []string{"anna", "boris", "clara"} {
+		messages <- &brokerPkg.Message{Topic: "topic", Key: []byte(key), Offset: int64(i)}
+	}
+	close(messages)
+
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+
+	h := New(nil, kafkaPkg.NewPublisher(producer), nil, func(_ context.Context, msg *brokerPkg.Message) error {
+		if msg.Offset == 1 {
+			return errorsPkg.ErrUnexpected
+		}
+		return nil
+	}, Config{Retries: 1, Backoff: time.Millisecond}, loggerPkg.NewFatal()).(*consumer).handler
+
+	s := &session{ctx: context.Background()}
+	assert.Error(t, h.ConsumeClaim(s, &claim{messages: messages}))
+	// the failed message is not marked, so it and the messages after it are consumed again
+	assert.Equal(t, []int64{0}, s.Marked())
+	assert.NoError(t, producer.Close())
+}
+
+func TestMarker(t *testing.T) {
+	s := &session{ctx: context.Background()}
+	m := newMarker(s)
+	msgs := make([]*brokerPkg.Message, 4)
+	for i := range msgs {
+		msgs[i] = &brokerPkg.Message{Offset: int64(10 + i)}
+		m.add(msgs[i])
+	}
+
+	m.complete(msgs[1])
+	m.complete(msgs[3])
+	assert.Empty(t, s.Marked())
+	m.complete(msgs[0])
+	assert.Equal(t, []int64{10, 11}, s.Marked())
+	m.complete(msgs[2])
+	assert.Equal(t, []int64{10, 11, 12, 13}, s.Marked())
+}
+
+func TestDrain(t *testing.T) {
+	cases := []struct {
+		name     string
+		yielded  int
+		newest   int64
+		fnErr    error
+		canceled bool
+		expRead  int
+		expErr   error
+	}{
+		{
+			name:    "stops at the newest offset",
+			yielded: 3,
+			newest:  2,
+			expRead: 2,
+		},
+		{
+			name:    "stops idle before the newest offset",
+			yielded: 2,
+			newest:  5,
+			expRead: 2,
+		},
+		{
+			name:    "failed, fn error",
+			yielded: 2,
+			newest:  2,
+			fnErr:   errorsPkg.ErrUnexpected,
+			expRead: 1,
+			expErr:  errorsPkg.ErrUnexpected,
+		},
+		{
+			name:     "failed, canceled",
+			newest:   2,
+			canceled: true,
+			expErr:   context.Canceled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			consumer := mocks.NewConsumer(t, nil)
+			expected := consumer.ExpectConsumePartition("topic_dlq", 0, sarama.OffsetOldest)
+			for i := 0; i < c.yielded; i++ {
+				expected.YieldMessage(&sarama.ConsumerMessage{Value: []byte(strconv.Itoa(i))})
+			}
+			pc, err := consumer.ConsumePartition("topic_dlq", 0, sarama.OffsetOldest)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if c.canceled {
+				cancel()
+			}
+			read := 0
+			err = drain(ctx, pc, c.newest, 20*time.Millisecond, func(msg *sarama.ConsumerMessage) error {
+				read++
+				return c.fnErr
+			})
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expRead, read)
+			assert.NoError(t, consumer.Close())
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package consumer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/pkg/errors"
+
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+	kafkaPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/kafka"
+)
+
+// dlqIdleTimeout ends reading the partition when the rest of its offsets hold no messages.
+const dlqIdleTimeout = 5 * time.Second
+
+// DLQ inspects the dead-letter topics and sends their messages back to the original topics.
+type DLQ interface {
+	// List calls fn for every message of the dead-letter topic.
+	List(ctx context.Context, topic string, fn func(msg *sarama.ConsumerMessage) error) error
+	// Redrive sends the messages of the dead-letter topic, not re-driven by the group yet,
+	// to their original topics and returns the number of the sent messages.
+	Redrive(ctx context.Context, topic string) (int, error)
+}
+
+// NewDLQ returns the DLQ tool, the re-driven offsets are committed for group.
+func NewDLQ(client sarama.Client, producer sarama.SyncProducer, group string) DLQ {
+	return &dlq{
+		client:   client,
+		producer: producer,
+		group:    group,
+		idle:     dlqIdleTimeout,
+	}
+}
+
+type dlq struct {
+	client   sarama.Client
+	producer sarama.SyncProducer
+	group    string
+	idle     time.Duration
+}
+
+func (d *dlq) List(ctx context.Context, topic string, fn func(msg *sarama.ConsumerMessage) error) error {
+	partitions, err := d.client.Partitions(topic)
+	if err != nil {
+		return errors.Wrap(err, "partitions")
+	}
+	for _, partition := range partitions {
+		if err = d.read(ctx, topic, partition, sarama.OffsetOldest, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *dlq) Redrive(ctx context.Context, topic string) (int, error) {
+	partitions, err := d.client.Partitions(topic)
+	if err != nil {
+		return 0, errors.Wrap(err, "partitions")
+	}
+	manager, err := sarama.NewOffsetManagerFromClient(d.group, d.client)
+	if err != nil {
+		return 0, errors.Wrap(err, "new offset manager")
+	}
+	// closing the manager commits the marked offsets
+	defer func() {
+		_ = manager.Close()
+	}()
+
+	sent := 0
+	for _, partition := range partitions {
+		pom, err := manager.ManagePartition(topic, partition)
+		if err != nil {
+			return sent, errors.Wrap(err, "manage partition")
+		}
+		offset, _ := pom.NextOffset()
+		if offset < 0 {
+			offset = sarama.OffsetOldest
+		}
+		err = d.read(ctx, topic, partition, offset, func(msg *sarama.ConsumerMessage) error {
+			if _, _, err := d.producer.SendMessage(kafkaPkg.ProducerMessage(redriveMessage(kafkaPkg.Message(msg)))); err != nil {
+				return errors.Wrapf(err, "redrive [%d/%d]", msg.Partition, msg.Offset)
+			}
+			pom.MarkOffset(msg.Offset+1, "")
+			sent++
+			return nil
+		})
+		_ = pom.Close()
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// read calls fn for the messages of the partition from offset up to the last one written before the call.
+func (d *dlq) read(ctx context.Context, topic string, partition int32, offset int64,
+	fn func(msg *sarama.ConsumerMessage) error) error {
+	newest, err := d.client.GetOffset(topic, partition, sarama.OffsetNewest)
+	if err != nil {
+		return errors.Wrap(err, "newest offset")
+	}
+	if offset >= newest {
+		return nil
+	}
+	if offset == sarama.OffsetOldest {
+		if offset, err = d.client.GetOffset(topic, partition, sarama.OffsetOldest); err != nil {
+			return errors.Wrap(err, "oldest offset")
+		}
+		if offset >= newest {
+			return nil
+		}
+	}
+
+	consumer, err := sarama.NewConsumerFromClient(d.client)
+	if err != nil {
+		return errors.Wrap(err, "new consumer")
+	}
+	defer func() {
+		_ = consumer.Close()
+	}()
+	pc, err := consumer.ConsumePartition(topic, partition, offset)
+	if err != nil {
+		return errors.Wrap(err, "consume partition")
+	}
+	defer func() {
+		_ = pc.Close()
+	}()
+
+	return drain(ctx, pc, newest, d.idle, fn)
+}
+
+// drain calls fn for the messages of the partition consumer up to the newest offset.
+// The offsets of the topic may have gaps, like the transaction markers or the compacted records,
+// so it stops at the high water mark as well and when no message comes for idle.
+func drain(ctx context.Context, pc sarama.PartitionConsumer, newest int64, idle time.Duration,
+	fn func(msg *sarama.ConsumerMessage) error) error {
+	timer := time.NewTimer(idle)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		case msg, ok := <-pc.Messages():
+			if !ok {
+				return nil
+			}
+			if err := fn(msg); err != nil {
+				return err
+			}
+			if next := msg.Offset + 1; next >= newest || next >= pc.HighWaterMarkOffset() {
+				return nil
+			}
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(idle)
+		}
+	}
+}
+
+// redriveMessage is the message of the original topic without the failure headers,
+// it is retried again if it fails.
+func redriveMessage(msg *brokerPkg.Message) *brokerPkg.Message {
+	headers := headerMap(msg.Headers)
+	topic := headers[HeaderOriginalTopic]
+	if topic == "" {
+		topic = strings.TrimSuffix(msg.Topic, dlqSuffix)
+	}
+	for key := range headers {
+		if strings.HasPrefix(key, "x-") {
+			delete(headers, key)
+		}
+	}
+	return &brokerPkg.Message{
+		Topic:   topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: recordHeaders(headers),
+	}
+}
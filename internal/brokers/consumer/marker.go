@@ -0,0 +1,44 @@
+package consumer
+
+import (
+	"sync"
+
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+// marker marks the completed messages of the partition in the order they were consumed.
+type marker struct {
+	mu      sync.Mutex
+	session brokerPkg.Session
+	pending []*brokerPkg.Message
+	done    map[int64]bool
+}
+
+func newMarker(session brokerPkg.Session) *marker {
+	return &marker{
+		session: session,
+		done:    make(map[int64]bool),
+	}
+}
+
+// add registers the consumed message, it is called in the partition order.
+func (m *marker) add(msg *brokerPkg.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending = append(m.pending, msg)
+}
+
+// complete marks the message and the completed messages after it,
+// if all the messages before it are completed.
+func (m *marker) complete(msg *brokerPkg.Message) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.done[msg.Offset] = true
+	for len(m.pending) != 0 && m.done[m.pending[0].Offset] {
+		delete(m.done, m.pending[0].Offset)
+		m.session.MarkMessage(m.pending[0], "")
+		m.pending[0] = nil
+		m.pending = m.pending[1:]
+	}
+}
@@ -1,20 +1,26 @@
 package data
 
 import (
-	"github.com/Shopify/sarama"
+	"context"
+
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/envelope"
 	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	sessionPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session"
 	userPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
-	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
 )
 
-func NewHandler(user userPkg.Interface, logger *zap.SugaredLogger, producer sarama.SyncProducer) *Handler {
+// NewHandler returns the handler of the data requests, the sessions of the deleted users
+// and of the users with the changed password are revoked by session.
+func NewHandler(user userPkg.Interface, session sessionPkg.Interface, logger *zap.SugaredLogger,
+	producer brokerPkg.Publisher) *Handler {
 	return &Handler{
 		logger: logger,
-		sender: newSender(user, logger, producer),
+		sender: newSender(user, session, logger, producer),
 	}
 }
 
@@ -23,54 +29,49 @@ type Handler struct {
 	sender sender
 }
 
-func (h *Handler) Setup(sarama.ConsumerGroupSession) error {
-	return nil
-}
-
-func (h *Handler) Cleanup(sarama.ConsumerGroupSession) error {
-	return nil
-}
-
-func (h *Handler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for msg := range claim.Messages() {
-		return h.handleMessage(session, msg)
+// Handle handles the message consumed by the consumer runtime, which marks it or retries it on error.
+func (h *Handler) Handle(ctx context.Context, msg *brokerPkg.Message) error {
+	env, err := envelope.Decode(msg)
+	if err != nil {
+		return errors.Wrap(err, "decode")
 	}
-	return nil
-}
+	ctx = envelope.Context(ctx, env)
 
-func (h *Handler) handleMessage(session sarama.ConsumerGroupSession, msg *sarama.ConsumerMessage) error {
-	uid, pub := helper.ExtractUidPubFromMessage(msg)
-	ctx := helper.InjectUidPubToCtx(session.Context(), uid, pub)
+	if envelope.Expired(env) {
+		return h.sender.sendError(ctx, string(msg.Key), env, errors.Wrap(errorsPkg.ErrTimeout, "request deadline"))
+	}
 
-	switch string(msg.Key) {
+	switch env.GetOperation() {
 	case consts.UserCreate:
-		if err := h.sender.userCreate(ctx, msg); err != nil {
+		if err := h.sender.userCreate(ctx, msg, env); err != nil {
 			return errors.Wrap(err, "user create")
 		}
-		session.MarkMessage(msg, "")
+	case consts.UserBatchCreate:
+		if err := h.sender.userBatchCreate(ctx, msg, env); err != nil {
+			return errors.Wrap(err, "user batch create")
+		}
 	case consts.UserUpdate:
-		if err := h.sender.userUpdate(ctx, msg); err != nil {
+		if err := h.sender.userUpdate(ctx, msg, env); err != nil {
 			return errors.Wrap(err, "user update")
 		}
-		session.MarkMessage(msg, "")
 	case consts.UserDelete:
-		if err := h.sender.userDelete(ctx, msg); err != nil {
+		if err := h.sender.userDelete(ctx, msg, env); err != nil {
 			return errors.Wrap(err, "user delete")
 		}
-		session.MarkMessage(msg, "")
+	case consts.UserRestore:
+		if err := h.sender.userRestore(ctx, msg, env); err != nil {
+			return errors.Wrap(err, "user restore")
+		}
 	case consts.UserGet:
-		if err := h.sender.userGet(ctx, msg); err != nil {
+		if err := h.sender.userGet(ctx, msg, env); err != nil {
 			return errors.Wrap(err, "user get")
 		}
-		session.MarkMessage(msg, "")
 	case consts.UserList:
-		if err := h.sender.userList(ctx, msg); err != nil {
+		if err := h.sender.userList(ctx, msg, env); err != nil {
 			return errors.Wrap(err, "user list")
 		}
-		session.MarkMessage(msg, "")
 	default:
-		session.MarkMessage(msg, "invalid_key")
-		return errors.Wrap(errorsPkg.ErrValidation, "invalid message key")
+		return errors.Wrap(errorsPkg.ErrValidation, "invalid message operation")
 	}
 	return nil
 }
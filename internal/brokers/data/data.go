@@ -2,17 +2,20 @@ package data
 
 import (
 	"context"
-	"encoding/json"
 
-	"github.com/Shopify/sarama"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/envelope"
 	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	sessionPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session"
 	userPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
 )
 
@@ -21,16 +24,21 @@ const (
 )
 
 type sender interface {
-	userCreate(ctx context.Context, msg *sarama.ConsumerMessage) error
-	userUpdate(ctx context.Context, msg *sarama.ConsumerMessage) error
-	userDelete(ctx context.Context, msg *sarama.ConsumerMessage) error
-	userGet(ctx context.Context, msg *sarama.ConsumerMessage) error
-	userList(ctx context.Context, msg *sarama.ConsumerMessage) error
+	userCreate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userBatchCreate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userUpdate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userDelete(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userRestore(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userGet(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userList(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	sendError(ctx context.Context, key string, env *pbModels.Envelope, err error) error
 }
 
-func newSender(user userPkg.Interface, logger *zap.SugaredLogger, producer sarama.SyncProducer) sender {
+func newSender(user userPkg.Interface, session sessionPkg.Interface, logger *zap.SugaredLogger,
+	producer brokerPkg.Publisher) sender {
 	return &core{
 		user:     user,
+		session:  session,
 		producer: producer,
 		logger:   logger,
 	}
@@ -38,172 +46,306 @@ func newSender(user userPkg.Interface, logger *zap.SugaredLogger, producer saram
 
 type core struct {
 	user     userPkg.Interface
-	producer sarama.SyncProducer
+	session  sessionPkg.Interface
+	producer brokerPkg.Publisher
 	logger   *zap.SugaredLogger
 }
 
-func (c *core) userCreate(ctx context.Context, msg *sarama.ConsumerMessage) error {
+func (c *core) userCreate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, brokerDataService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	var user models.User
-	if err := json.Unmarshal(msg.Value, &user); err != nil {
-		return errors.Wrap(err, "unmarshal")
-	}
+	user := adaptor.ToUserCoreModel(env.GetUser())
 
 	c.logger.Debugf("user [%s]", user.String())
 
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicMailing,
-		Key:   sarama.StringEncoder(consts.UserCreate),
-	}
-
-	if err := c.user.Create(ctx, user); err != nil {
-		if errors.Is(err, errorsPkg.ErrUserAlreadyExists) {
+	if err := c.user.Create(c.withOutbox(ctx, user.Name, replyWith(envelope.Reply(env))), *user); err != nil {
+		if c.processed(err) {
+			return nil
+		}
+		if errors.Is(err, errorsPkg.ErrUserAlreadyExists) || errors.Is(err, errorsPkg.ErrEmailAlreadyExists) ||
+			errorsPkg.IsFieldError(err) {
 			c.logger.Errorf("user create: %v", err)
-			return c.sendErrorWithCtx(ctx, message, err.Error())
+			return c.sendError(ctx, user.Name, env, err)
 		}
 		return err
 	}
 
-	return c.sendMessageWithCtx(ctx, message)
+	// the reply is written to the outbox with the change
+	return nil
 }
 
-func (c *core) userUpdate(ctx context.Context, msg *sarama.ConsumerMessage) error {
+// userBatchCreate creates the valid users of the batch and sends the result of every user.
+func (c *core) userBatchCreate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, brokerDataService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	var user models.User
-	if err := json.Unmarshal(msg.Value, &user); err != nil {
-		return errors.Wrap(err, "message unmarshal")
+	batch := adaptor.ToUserBatchCoreModel(env.GetBatch())
+
+	c.logger.Debugf("batch of [%d] users", len(batch.Users))
+
+	results := make([]models.UserBatchResult, len(batch.Users))
+	valid := make([]models.User, 0, len(batch.Users))
+	index := make([]int, 0, len(batch.Users))
+	for i, user := range batch.Users {
+		results[i].Name = user.Name
+		if i < len(batch.Errors) && batch.Errors[i] != "" {
+			results[i].Error = batch.Errors[i]
+			continue
+		}
+		valid = append(valid, user)
+		index = append(index, i)
 	}
 
-	c.logger.Debugf("user [%s]", user.String())
+	// the batch is keyed by the request, its users are spread over the partitions anyway
+	uid, _ := helper.ExtractUidPubFromCtx(ctx)
+	// reply adds the results of the valid users to the results of the batch
+	reply := func(created []error) (*pbModels.Envelope, error) {
+		res := make([]models.UserBatchResult, len(results))
+		copy(res, results)
+		for j, err := range created {
+			if err != nil {
+				res[index[j]].Error = err.Error()
+			}
+		}
+		rep := envelope.Reply(env)
+		rep.Payload = &pbModels.Envelope_BatchResults{BatchResults: adaptor.ToUserBatchResultsPbModel(res)}
+		return rep, nil
+	}
 
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicMailing,
-		Key:   sarama.StringEncoder(consts.UserUpdate),
+	var created []error
+	if len(valid) != 0 {
+		written := false
+		var err error
+		created, err = c.user.CreateBatch(c.withOutbox(ctx, uid, func(created []error) (*pbModels.Envelope, error) {
+			written = true
+			return reply(created)
+		}), valid)
+		if err != nil {
+			if c.processed(err) {
+				return nil
+			}
+			return err
+		}
+		for _, err := range created {
+			if err != nil {
+				c.logger.Errorf("user batch create: %v", err)
+			}
+		}
+		if written {
+			return nil
+		}
 	}
 
-	if err := c.user.Update(ctx, user); err != nil {
-		if errors.Is(err, errorsPkg.ErrUserNotFound) {
+	// no user reached the repository, there is no change to write the reply with
+	rep, err := reply(created)
+	if err != nil {
+		return err
+	}
+	return c.sendReply(ctx, uid, rep)
+}
+
+func (c *core) userUpdate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
+	span := helper.GetSpanFromMessage(msg, brokerDataService)
+	ctx = opentracing.ContextWithSpan(ctx, span)
+	defer span.Finish()
+
+	profile := adaptor.ToProfileUpdateCoreModel(env.GetProfile())
+
+	c.logger.Debugf("profile [%s]", profile.String())
+
+	if err := c.user.Update(c.withOutbox(ctx, profile.Name, replyWith(envelope.Reply(env))), *profile); err != nil {
+		if c.processed(err) {
+			return nil
+		}
+		if errors.Is(err, errorsPkg.ErrUserNotFound) || errors.Is(err, errorsPkg.ErrVersionConflict) ||
+			errors.Is(err, errorsPkg.ErrEmailAlreadyExists) || errorsPkg.IsFieldError(err) {
 			c.logger.Errorf("user update: %v", err)
-			return c.sendErrorWithCtx(ctx, message, err.Error())
+			return c.sendError(ctx, profile.Name, env, err)
 		}
 		return err
 	}
+	if profile.Password != nil {
+		c.revokeSessions(ctx, profile.Name)
+	}
 
-	return c.sendMessageWithCtx(ctx, message)
+	// the reply is written to the outbox with the change
+	return nil
 }
 
-func (c *core) userDelete(ctx context.Context, msg *sarama.ConsumerMessage) error {
+func (c *core) userDelete(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, brokerDataService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	name := string(msg.Value)
-
-	c.logger.Debugf("name: [%s]", name)
+	name, version := env.GetKey().GetName(), env.GetKey().GetVersion()
 
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicMailing,
-		Key:   sarama.StringEncoder(consts.UserDelete),
-	}
+	c.logger.Debugf("name: [%s], version: [%d]", name, version)
 
-	if err := c.user.Delete(ctx, name); err != nil {
-		if errors.Is(err, errorsPkg.ErrUserNotFound) {
+	if err := c.user.Delete(c.withOutbox(ctx, name, replyWith(envelope.Reply(env))), name, version); err != nil {
+		if c.processed(err) {
+			return nil
+		}
+		if errors.Is(err, errorsPkg.ErrUserNotFound) || errors.Is(err, errorsPkg.ErrVersionConflict) {
 			c.logger.Errorf("user delete: %v", err)
-			return c.sendErrorWithCtx(ctx, message, err.Error())
+			return c.sendError(ctx, name, env, err)
 		}
 		return err
 	}
+	c.revokeSessions(ctx, name)
 
-	return c.sendMessageWithCtx(ctx, message)
+	// the reply is written to the outbox with the change
+	return nil
 }
 
-func (c *core) userGet(ctx context.Context, msg *sarama.ConsumerMessage) error {
+// processed returns true if the change of the redelivered request is already applied,
+// its reply was written to the outbox with the change and is not sent again.
+func (c *core) processed(err error) bool {
+	if !errors.Is(err, errorsPkg.ErrRequestProcessed) {
+		return false
+	}
+	c.logger.Infof("skip redelivered: %v", err)
+	return true
+}
+
+// revokeSessions closes the sessions of the user, the sessions of the deleted user
+// left after a failure are closed on their refresh.
+func (c *core) revokeSessions(ctx context.Context, name string) {
+	if err := c.session.RevokeAll(ctx, name); err != nil {
+		c.logger.Errorf("revoke sessions of [%s]: %v", name, err)
+	}
+}
+
+func (c *core) userRestore(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, brokerDataService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	name := string(msg.Value)
+	name := env.GetKey().GetName()
 
 	c.logger.Debugf("name: [%s]", name)
 
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicMailing,
-		Key:   sarama.StringEncoder(consts.UserGet),
+	if err := c.user.Restore(c.withOutbox(ctx, name, replyWith(envelope.Reply(env))), name); err != nil {
+		if c.processed(err) {
+			return nil
+		}
+		if errors.Is(err, errorsPkg.ErrUserNotFound) {
+			c.logger.Errorf("user restore: %v", err)
+			return c.sendError(ctx, name, env, err)
+		}
+		return err
 	}
 
-	user, err := c.user.Get(ctx, name)
+	// the reply is written to the outbox with the change
+	return nil
+}
+
+func (c *core) userGet(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
+	span := helper.GetSpanFromMessage(msg, brokerDataService)
+	ctx = opentracing.ContextWithSpan(ctx, span)
+	defer span.Finish()
+
+	name, withDeleted := env.GetKey().GetName(), env.GetKey().GetWithDeleted()
+
+	c.logger.Debugf("name: [%s], with deleted: [%v]", name, withDeleted)
+
+	user, err := c.user.Get(ctx, name, withDeleted)
 	if err != nil {
 		if errors.Is(err, errorsPkg.ErrUserNotFound) {
 			c.logger.Errorf("user get: %v", err)
-			return c.sendErrorWithCtx(ctx, message, err.Error())
+			return c.sendError(ctx, name, env, err)
 		}
 		return err
 	}
 
-	data, err := json.Marshal(user)
-	if err != nil {
-		return errors.Wrap(err, "marshal user")
-	}
-	message.Value = sarama.ByteEncoder(data)
+	reply := envelope.Reply(env)
+	reply.Payload = &pbModels.Envelope_User{User: adaptor.ToUserPbModel(user)}
 
-	return c.sendMessageWithCtx(ctx, message)
+	return c.sendReply(ctx, name, reply)
 }
 
-func (c *core) userList(ctx context.Context, msg *sarama.ConsumerMessage) error {
+func (c *core) userList(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, brokerDataService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	params := models.NewUserListParams()
-	if err := json.Unmarshal(msg.Value, params); err != nil {
-		return errors.Wrap(err, "unmarshal list parameters")
-	}
+	params := adaptor.ToUserListParamsCoreModel(env.GetListParams())
+
+	c.logger.Debugf("parameters: [%d %d %v %v %s %v %v]", params.Limit, params.Offset,
+		params.Order, params.WithDeleted, params.PageToken, params.Sort, params.Filter)
 
-	c.logger.Debugf("parameters: [%d %d %v]", params.Limit, params.Offset, params.Order)
+	// the list is not about one user, it is keyed by the request
+	uid, _ := helper.ExtractUidPubFromCtx(ctx)
 
-	list, err := c.user.List(ctx, params.Order, params.Limit, params.Offset)
+	page, err := c.user.List(ctx, *params)
 	if err != nil {
+		if errors.Is(err, errorsPkg.ErrInvalidPageToken) || errors.Is(err, errorsPkg.ErrValidation) {
+			c.logger.Errorf("user list: %v", err)
+			return c.sendError(ctx, uid, env, err)
+		}
 		return err
 	}
 
-	data, err := json.Marshal(list)
-	if err != nil {
-		return errors.Wrap(err, "marshal user")
-	}
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicMailing,
-		Key:   sarama.StringEncoder(consts.UserList),
-		Value: sarama.ByteEncoder(data),
-	}
+	reply := envelope.Reply(env)
+	reply.Payload = &pbModels.Envelope_Page{Page: adaptor.ToUserListPagePbModel(page)}
 
-	return c.sendMessageWithCtx(ctx, message)
+	return c.sendReply(ctx, uid, reply)
 }
 
-func (c *core) sendErrorWithCtx(
+// withOutbox returns the context of the change which reply is written to the outbox by the repository.
+// reply returns the reply by the results of the batch users.
+func (c *core) withOutbox(
 	ctx context.Context,
-	message *sarama.ProducerMessage,
-	description string,
-) error {
-	if err := helper.InjectHeaders(ctx, message); err != nil {
+	key string,
+	reply func(results []error) (*pbModels.Envelope, error),
+) context.Context {
+	return models.WithOutbox(ctx, func(results []error) (models.OutboxMessage, error) {
+		env, err := reply(results)
+		if err != nil {
+			return models.OutboxMessage{}, err
+		}
+		message, err := envelope.Message(consts.TopicMailing, key, env)
+		if err != nil {
+			return models.OutboxMessage{}, err
+		}
+		if err = helper.InjectHeaders(ctx, message); err != nil {
+			return models.OutboxMessage{}, err
+		}
+		return adaptor.ToOutboxMessage(message), nil
+	})
+}
+
+// replyWith returns the reply of the change of one user.
+func replyWith(env *pbModels.Envelope) func([]error) (*pbModels.Envelope, error) {
+	return func([]error) (*pbModels.Envelope, error) {
+		return env, nil
+	}
+}
+
+// sendError replies to the request with the error.
+func (c *core) sendError(ctx context.Context, key string, env *pbModels.Envelope, err error) error {
+	reply := envelope.Reply(env)
+	reply.Payload = &pbModels.Envelope_Error{Error: envelope.Error(err)}
+	message, err := envelope.Message(consts.TopicError, key, reply)
+	if err != nil {
 		return err
 	}
-	message.Topic = consts.TopicError
-	message.Value = sarama.StringEncoder(description)
+	return c.sendMessageWithCtx(ctx, message)
+}
 
-	_, _, err := c.producer.SendMessage(message)
-	return err
+func (c *core) sendReply(ctx context.Context, key string, reply *pbModels.Envelope) error {
+	message, err := envelope.Message(consts.TopicMailing, key, reply)
+	if err != nil {
+		return err
+	}
+	return c.sendMessageWithCtx(ctx, message)
 }
 
-func (c *core) sendMessageWithCtx(ctx context.Context, message *sarama.ProducerMessage) error {
+func (c *core) sendMessageWithCtx(ctx context.Context, message *brokerPkg.Message) error {
 	if err := helper.InjectHeaders(ctx, message); err != nil {
 		return err
 	}
-	_, _, err := c.producer.SendMessage(message)
-	return err
+	return c.producer.Publish(ctx, message)
 }
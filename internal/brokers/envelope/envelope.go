@@ -0,0 +1,228 @@
+package envelope
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+)
+
+const (
+	// Version is the version of the envelopes produced by the services.
+	Version = 1
+
+	// versionKey is the header of the envelope version, the messages without it have the legacy format.
+	versionKey = "envelope"
+)
+
+// New returns the envelope of the operation of the request in ctx without the deadline,
+// the receiver sets it only if the client set the deadline of the call.
+func New(ctx context.Context, operation string) *pbModels.Envelope {
+	uid, pub := helper.ExtractUidPubFromCtx(ctx)
+	return &pbModels.Envelope{
+		Version:   Version,
+		Operation: operation,
+		RequestId: uid,
+		Wait:      pbModels.WaitMode(pbModels.WaitMode_value[pub]),
+		CreatedAt: timestamppb.Now(),
+	}
+}
+
+// Reply returns the envelope of the result of the request env, the payload is not copied.
+func Reply(env *pbModels.Envelope) *pbModels.Envelope {
+	return &pbModels.Envelope{
+		Version:   Version,
+		Operation: env.GetOperation(),
+		RequestId: env.GetRequestId(),
+		Wait:      env.GetWait(),
+		CreatedAt: env.GetCreatedAt(),
+		Deadline:  env.GetDeadline(),
+	}
+}
+
+// Context returns ctx with the request of the envelope.
+func Context(ctx context.Context, env *pbModels.Envelope) context.Context {
+	return helper.InjectUidPubToCtx(ctx, env.GetRequestId(), env.GetWait().String())
+}
+
+// Expired returns true if the deadline of the request is passed.
+func Expired(env *pbModels.Envelope) bool {
+	return env.GetDeadline() != nil && time.Now().After(env.GetDeadline().AsTime())
+}
+
+// Message returns the message of the envelope keyed by key.
+func Message(topic, key string, env *pbModels.Envelope) (*brokerPkg.Message, error) {
+	data, err := proto.Marshal(env)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal envelope")
+	}
+	message := helper.NewMessage(topic, env.GetOperation(), key, data)
+	message.Headers = append(message.Headers, brokerPkg.Header{
+		Key:   []byte(versionKey),
+		Value: []byte(strconv.Itoa(int(env.GetVersion()))),
+	})
+	return message, nil
+}
+
+// Decode returns the envelope of the message, the message of the legacy format is converted to the envelope.
+// The envelopes of the versions newer than Version are not decoded.
+func Decode(msg *brokerPkg.Message) (*pbModels.Envelope, error) {
+	for _, header := range msg.Headers {
+		if string(header.Key) != versionKey {
+			continue
+		}
+		version, err := strconv.ParseUint(string(header.Value), 10, 32)
+		if err != nil {
+			return nil, errors.Wrap(errorsPkg.ErrValidation, "envelope version: "+err.Error())
+		}
+		if version > Version {
+			return nil, errors.Wrapf(errorsPkg.ErrValidation, "envelope version [%d] is not supported", version)
+		}
+		env := &pbModels.Envelope{}
+		if err = proto.Unmarshal(msg.Value, env); err != nil {
+			return nil, errors.Wrap(errorsPkg.ErrValidation, "unmarshal envelope: "+err.Error())
+		}
+		if env.GetVersion() > Version {
+			return nil, errors.Wrapf(errorsPkg.ErrValidation, "envelope version [%d] is not supported", env.GetVersion())
+		}
+		return env, nil
+	}
+	return decodeLegacy(msg)
+}
+
+// decodeLegacy converts the JSON payload and the uid and pub headers of the legacy message.
+func decodeLegacy(msg *brokerPkg.Message) (*pbModels.Envelope, error) {
+	uid, pub := helper.ExtractUidPubFromMessage(msg)
+	env := &pbModels.Envelope{
+		Version:   0,
+		Operation: helper.ExtractOperationFromMessage(msg),
+		RequestId: uid,
+		Wait:      pbModels.WaitMode(pbModels.WaitMode_value[pub]),
+		CreatedAt: timestamppb.New(msg.Timestamp),
+	}
+
+	// the errors were the plain descriptions
+	if msg.Topic == consts.TopicError {
+		env.Payload = &pbModels.Envelope_Error{Error: &pbModels.Error{Message: string(msg.Value)}}
+		return env, nil
+	}
+
+	var err error
+	if msg.Topic == consts.TopicMailing {
+		err = decodeLegacyResult(env, msg.Value)
+	} else {
+		err = decodeLegacyRequest(env, msg.Value)
+	}
+	if err != nil {
+		return nil, errors.Wrap(errorsPkg.ErrValidation, "unmarshal legacy "+env.Operation+": "+err.Error())
+	}
+	return env, nil
+}
+
+func decodeLegacyRequest(env *pbModels.Envelope, value []byte) error {
+	switch env.Operation {
+	case consts.UserCreate:
+		var user models.User
+		if err := json.Unmarshal(value, &user); err != nil {
+			return err
+		}
+		env.Payload = &pbModels.Envelope_User{User: adaptor.ToUserRequestPbModel(user)}
+	case consts.UserBatchCreate:
+		var batch models.UserBatch
+		if err := json.Unmarshal(value, &batch); err != nil {
+			return err
+		}
+		env.Payload = &pbModels.Envelope_Batch{Batch: adaptor.ToUserBatchPbModel(batch)}
+	case consts.UserUpdate:
+		var profile models.Profile
+		if err := json.Unmarshal(value, &profile); err != nil {
+			return err
+		}
+		env.Payload = &pbModels.Envelope_Profile{Profile: adaptor.ToProfileUpdatePbModel(profile)}
+	case consts.UserDelete:
+		params := models.UnmarshalUserDeleteParams(value)
+		env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: params.Name, Version: params.Version}}
+	case consts.UserRestore:
+		env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: string(value)}}
+	case consts.UserGet:
+		params := models.UnmarshalUserGetParams(value)
+		env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: params.Name, WithDeleted: params.WithDeleted}}
+	case consts.UserList:
+		var params models.UserListParams
+		if err := json.Unmarshal(value, &params); err != nil {
+			return err
+		}
+		env.Payload = &pbModels.Envelope_ListParams{ListParams: adaptor.ToUserListParamsPbModel(params)}
+	}
+	return nil
+}
+
+func decodeLegacyResult(env *pbModels.Envelope, value []byte) error {
+	switch env.Operation {
+	case consts.UserBatchCreate:
+		var results []models.UserBatchResult
+		if err := json.Unmarshal(value, &results); err != nil {
+			return err
+		}
+		env.Payload = &pbModels.Envelope_BatchResults{BatchResults: adaptor.ToUserBatchResultsPbModel(results)}
+	case consts.UserGet:
+		var user models.User
+		if err := json.Unmarshal(value, &user); err != nil {
+			return err
+		}
+		env.Payload = &pbModels.Envelope_User{User: adaptor.ToUserPbModel(user)}
+	case consts.UserList:
+		var page models.UserListPage
+		if err := json.Unmarshal(value, &page); err != nil {
+			return err
+		}
+		env.Payload = &pbModels.Envelope_Page{Page: adaptor.ToUserListPagePbModel(page)}
+	}
+	return nil
+}
+
+// Error returns the structured error of the request.
+func Error(err error) *pbModels.Error {
+	res := &pbModels.Error{
+		Code:    pbModels.Error_UNKNOWN,
+		Message: err.Error(),
+	}
+	var fieldErr *errorsPkg.FieldError
+	if errors.As(err, &fieldErr) {
+		res.Field = fieldErr.Field
+	}
+
+	switch {
+	case errors.Is(err, errorsPkg.ErrUserNotFound):
+		res.Code = pbModels.Error_NOT_FOUND
+	case errors.Is(err, errorsPkg.ErrUserAlreadyExists):
+		res.Code = pbModels.Error_ALREADY_EXISTS
+	case errors.Is(err, errorsPkg.ErrEmailAlreadyExists):
+		res.Code = pbModels.Error_EMAIL_ALREADY_EXISTS
+	case errors.Is(err, errorsPkg.ErrVersionConflict):
+		res.Code = pbModels.Error_VERSION_CONFLICT
+	case errors.Is(err, errorsPkg.ErrInvalidPageToken):
+		res.Code = pbModels.Error_INVALID_PAGE_TOKEN
+	case errors.Is(err, errorsPkg.ErrInvalidField):
+		res.Code = pbModels.Error_INVALID_FIELD
+	case errors.Is(err, errorsPkg.ErrFieldTooLong):
+		res.Code = pbModels.Error_FIELD_TOO_LONG
+	case errors.Is(err, errorsPkg.ErrTimeout):
+		res.Code = pbModels.Error_DEADLINE_EXCEEDED
+	case errors.Is(err, errorsPkg.ErrValidation):
+		res.Code = pbModels.Error_VALIDATION
+	}
+	return res
+}
@@ -0,0 +1,267 @@
+package envelope
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+)
+
+// consumed returns the copy of the published message as it is consumed.
+func consumed(message *brokerPkg.Message) *brokerPkg.Message {
+	msg := *message
+	msg.Headers = append([]brokerPkg.Header(nil), message.Headers...)
+	return &msg
+}
+
+func TestDecode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(helper.InjectUidPubToCtx(context.Background(), "1", "cache"), time.Minute)
+	defer cancel()
+
+	env := New(ctx, consts.UserDelete)
+	env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: "Ivan", Version: 2}}
+	message, err := Message(consts.TopicData, "Ivan", env)
+	require.NoError(t, err)
+
+	newer := proto.Clone(env).(*pbModels.Envelope)
+	newer.Version = Version + 1
+	newerMessage, err := Message(consts.TopicData, "Ivan", newer)
+	require.NoError(t, err)
+	// the version of the body is newer than the version of the header
+	newerBody := consumed(newerMessage)
+	for i := range newerBody.Headers {
+		if string(newerBody.Headers[i].Key) == versionKey {
+			newerBody.Headers[i].Value = []byte(strconv.Itoa(Version))
+		}
+	}
+
+	email := "ivan@mail.ru"
+	createdAt := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	legacy := func(topic, operation, value string) *brokerPkg.Message {
+		return consumed(helper.NewMessage(topic, operation, "Ivan", []byte(value)))
+	}
+	legacyEnvelope := func(operation string) *pbModels.Envelope {
+		return &pbModels.Envelope{Operation: operation, CreatedAt: timestamppb.New(time.Time{})}
+	}
+	withPayload := func(env *pbModels.Envelope, set func(env *pbModels.Envelope)) *pbModels.Envelope {
+		set(env)
+		return env
+	}
+
+	cases := []struct {
+		name   string
+		msg    *brokerPkg.Message
+		exp    *pbModels.Envelope
+		expErr error
+	}{
+		{
+			name: "envelope",
+			msg:  consumed(message),
+			exp:  env,
+		},
+		{
+			name: "legacy create",
+			msg: consumed(helper.NewMessage(consts.TopicData, consts.UserCreate, "Ivan",
+				[]byte(`{"name":"Ivan","password":"secret","email":"ivan@mail.ru"}`))),
+			exp: &pbModels.Envelope{
+				Operation: consts.UserCreate,
+				CreatedAt: timestamppb.New(time.Time{}),
+				Payload: &pbModels.Envelope_User{User: &pbModels.User{
+					Name: "Ivan", Password: "secret", Email: "ivan@mail.ru",
+				}},
+			},
+		},
+		{
+			name: "legacy restore",
+			msg: consumed(helper.NewMessage(consts.TopicData, consts.UserRestore, "Ivan",
+				[]byte("Ivan"))),
+			exp: &pbModels.Envelope{
+				Operation: consts.UserRestore,
+				CreatedAt: timestamppb.New(time.Time{}),
+				Payload:   &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: "Ivan"}},
+			},
+		},
+		{
+			name: "legacy error",
+			msg: consumed(helper.NewMessage(consts.TopicError, consts.UserGet, "Ivan",
+				[]byte("user not found"))),
+			exp: &pbModels.Envelope{
+				Operation: consts.UserGet,
+				CreatedAt: timestamppb.New(time.Time{}),
+				Payload:   &pbModels.Envelope_Error{Error: &pbModels.Error{Message: "user not found"}},
+			},
+		},
+		{
+			name:   "newer version",
+			msg:    consumed(newerMessage),
+			expErr: errorsPkg.ErrValidation,
+		},
+		{
+			name:   "newer version of the envelope",
+			msg:    newerBody,
+			expErr: errorsPkg.ErrValidation,
+		},
+		{
+			name: "legacy update",
+			msg:  legacy(consts.TopicData, consts.UserUpdate, `{"name":"Ivan","email":"ivan@mail.ru","version":2}`),
+			exp: withPayload(legacyEnvelope(consts.UserUpdate), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_Profile{Profile: adaptor.ToProfileUpdatePbModel(
+					models.Profile{Name: "Ivan", Email: &email, Version: 2})}
+			}),
+		},
+		{
+			name: "legacy delete",
+			msg:  legacy(consts.TopicData, consts.UserDelete, `{"name":"Ivan","version":2}`),
+			exp: withPayload(legacyEnvelope(consts.UserDelete), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: "Ivan", Version: 2}}
+			}),
+		},
+		{
+			name: "legacy delete by raw name",
+			msg:  legacy(consts.TopicData, consts.UserDelete, "Ivan"),
+			exp: withPayload(legacyEnvelope(consts.UserDelete), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: "Ivan"}}
+			}),
+		},
+		{
+			name: "legacy get",
+			msg:  legacy(consts.TopicData, consts.UserGet, `{"name":"Ivan","with_deleted":true}`),
+			exp: withPayload(legacyEnvelope(consts.UserGet), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_Key{Key: &pbModels.UserKey{Name: "Ivan", WithDeleted: true}}
+			}),
+		},
+		{
+			name: "legacy list",
+			msg:  legacy(consts.TopicData, consts.UserList, `{"limit":10,"offset":20,"order":true}`),
+			exp: withPayload(legacyEnvelope(consts.UserList), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_ListParams{ListParams: adaptor.ToUserListParamsPbModel(
+					models.UserListParams{Limit: 10, Offset: 20, Order: true})}
+			}),
+		},
+		{
+			name: "legacy batch",
+			msg: legacy(consts.TopicData, consts.UserBatchCreate,
+				`{"users":[{"name":"Ivan","password":"secret"},{"name":"Petr"}],"errors":["","field: [password] cannot be empty"]}`),
+			exp: withPayload(legacyEnvelope(consts.UserBatchCreate), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_Batch{Batch: adaptor.ToUserBatchPbModel(models.UserBatch{
+					Users:  []models.User{{Name: "Ivan", Password: "secret"}, {Name: "Petr"}},
+					Errors: []string{"", "field: [password] cannot be empty"},
+				})}
+			}),
+		},
+		{
+			name: "legacy batch result",
+			msg: legacy(consts.TopicMailing, consts.UserBatchCreate,
+				`[{"name":"Ivan"},{"name":"Petr","error":"user already exists"}]`),
+			exp: withPayload(legacyEnvelope(consts.UserBatchCreate), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_BatchResults{BatchResults: adaptor.ToUserBatchResultsPbModel(
+					[]models.UserBatchResult{{Name: "Ivan"}, {Name: "Petr", Error: "user already exists"}})}
+			}),
+		},
+		{
+			name: "legacy get result",
+			msg: legacy(consts.TopicMailing, consts.UserGet,
+				`{"name":"Ivan","email":"ivan@mail.ru","created_at":"2022-10-01T12:00:00Z","version":1}`),
+			exp: withPayload(legacyEnvelope(consts.UserGet), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_User{User: adaptor.ToUserPbModel(
+					models.User{Name: "Ivan", Email: email, CreatedAt: createdAt, Version: 1})}
+			}),
+		},
+		{
+			name: "legacy list result",
+			msg: legacy(consts.TopicMailing, consts.UserList,
+				`{"users":[{"name":"Ivan","created_at":"2022-10-01T12:00:00Z"}],"next_page_token":"next"}`),
+			exp: withPayload(legacyEnvelope(consts.UserList), func(env *pbModels.Envelope) {
+				env.Payload = &pbModels.Envelope_Page{Page: adaptor.ToUserListPagePbModel(models.UserListPage{
+					Users:         []models.User{{Name: "Ivan", CreatedAt: createdAt}},
+					NextPageToken: "next",
+				})}
+			}),
+		},
+		{
+			name: "legacy invalid",
+			msg: consumed(helper.NewMessage(consts.TopicMailing, consts.UserList, "1",
+				[]byte("users"))),
+			expErr: errorsPkg.ErrValidation,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			env, err := Decode(c.msg)
+			if c.expErr != nil {
+				assert.ErrorIs(t, err, c.expErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(c.exp, env), "expected %v, got %v", c.exp, env)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expCode  pbModels.Error_Code
+		expField string
+	}{
+		{
+			name:    "not found",
+			err:     errors.Wrap(errorsPkg.ErrUserNotFound, "get"),
+			expCode: pbModels.Error_NOT_FOUND,
+		},
+		{
+			name:     "field too long",
+			err:      &errorsPkg.FieldError{Field: "email", Err: errorsPkg.ErrFieldTooLong},
+			expCode:  pbModels.Error_FIELD_TOO_LONG,
+			expField: "email",
+		},
+		{
+			name:    "unknown",
+			err:     errorsPkg.ErrUnexpected,
+			expCode: pbModels.Error_UNKNOWN,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			res := Error(c.err)
+			assert.Equal(t, c.expCode, res.GetCode())
+			assert.Equal(t, c.expField, res.GetField())
+			assert.Equal(t, c.err.Error(), res.GetMessage())
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	ctx, cancel := context.WithTimeout(helper.InjectUidPubToCtx(context.Background(), "1", "cache"), time.Minute)
+	defer cancel()
+
+	env := New(ctx, consts.UserCreate)
+	assert.Equal(t, "1", env.GetRequestId())
+	assert.Equal(t, pbModels.WaitMode_cache, env.GetWait())
+	// the deadline is set by the receiver, not by the deadline of ctx
+	assert.Nil(t, env.GetDeadline())
+	assert.False(t, Expired(env))
+}
+
+func TestExpired(t *testing.T) {
+	assert.False(t, Expired(&pbModels.Envelope{}))
+	assert.False(t, Expired(&pbModels.Envelope{Deadline: timestamppb.New(time.Now().Add(time.Minute))}))
+	assert.True(t, Expired(&pbModels.Envelope{Deadline: timestamppb.New(time.Now().Add(-time.Minute))}))
+}
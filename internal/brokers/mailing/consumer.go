@@ -1,19 +1,21 @@
 package mailing
 
 import (
-	"github.com/Shopify/sarama"
-	"github.com/go-redis/redis/v8"
+	"context"
+
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/envelope"
 	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
-	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
 )
 
-func NewHandler(logger *zap.SugaredLogger, producer sarama.SyncProducer, client *redis.Client) *Handler {
+func NewHandler(logger *zap.SugaredLogger, cache cachePkg.Interface) *Handler {
 	return &Handler{
 		logger: logger,
-		sender: newSender(logger, producer, client),
+		sender: newSender(logger, cache),
 	}
 }
 
@@ -22,34 +24,21 @@ type Handler struct {
 	sender sender
 }
 
-func (h *Handler) Setup(sarama.ConsumerGroupSession) error {
-	return nil
-}
-
-func (h *Handler) Cleanup(sarama.ConsumerGroupSession) error {
-	return nil
-}
-
-func (h *Handler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
-	for msg := range claim.Messages() {
-		return h.handleMessage(session, msg)
+// Handle handles the message consumed by the consumer runtime, which marks it or retries it on error.
+func (h *Handler) Handle(ctx context.Context, msg *brokerPkg.Message) error {
+	env, err := envelope.Decode(msg)
+	if err != nil {
+		return errors.Wrap(err, "decode")
 	}
-	return nil
-}
-
-func (h *Handler) handleMessage(session sarama.ConsumerGroupSession, msg *sarama.ConsumerMessage) error {
-	uid, pub := helper.ExtractUidPubFromMessage(msg)
-	ctx := helper.InjectUidPubToCtx(session.Context(), uid, pub)
+	ctx = envelope.Context(ctx, env)
 
 	switch msg.Topic {
 	case consts.TopicMailing:
-		session.MarkMessage(msg, "success")
-		if err := h.sender.sendSuccess(ctx, msg); err != nil {
+		if err = h.sender.sendSuccess(ctx, msg, env); err != nil {
 			return errors.Wrap(err, "send message")
 		}
 	case consts.TopicError:
-		session.MarkMessage(msg, "error")
-		if err := h.sender.sendError(ctx, msg); err != nil {
+		if err = h.sender.sendError(ctx, msg, env); err != nil {
 			return errors.Wrap(err, "send message")
 		}
 	}
@@ -2,15 +2,16 @@ package mailing
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
-	"github.com/Shopify/sarama"
-	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
-	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
-	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
 )
 
@@ -21,94 +22,84 @@ const (
 )
 
 type sender interface {
-	sendSuccess(ctx context.Context, msg *sarama.ConsumerMessage) error
-	sendError(ctx context.Context, msg *sarama.ConsumerMessage) error
+	sendSuccess(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	sendError(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
 }
 
-func newSender(logger *zap.SugaredLogger, producer sarama.SyncProducer, client *redis.Client) sender {
+func newSender(logger *zap.SugaredLogger, cache cachePkg.Interface) sender {
 	return &core{
-		producer: producer,
-		logger:   logger,
-		cache:    client,
+		logger: logger,
+		cache:  cache,
 	}
 }
 
 type core struct {
-	producer sarama.SyncProducer
-	logger   *zap.SugaredLogger
-	cache    *redis.Client
+	logger *zap.SugaredLogger
+	cache  cachePkg.Interface
 }
 
-func (c *core) sendSuccess(ctx context.Context, msg *sarama.ConsumerMessage) error {
+// sendSuccess delivers the result to the waiting client, the message is retried by the consumer if the cache fails.
+func (c *core) sendSuccess(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, mailingService)
 	defer span.Finish()
-	uid, pub := helper.ExtractUidPubFromMessage(msg)
-
-	switch pub {
-	case pb.Wait_pub.String():
-		if err := c.cache.Publish(ctx, string(msg.Key), msg.Value).Err(); err != nil {
-			if err = c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-				Topic:   consts.TopicMailing,
-				Key:     sarama.ByteEncoder(msg.Key),
-				Value:   sarama.ByteEncoder(msg.Value),
-				Headers: adaptor.ConsumerHeaderToProducer(msg.Headers),
-			}); err != nil {
-				return err
-			}
+
+	data, err := result(env)
+	if err != nil {
+		return err
+	}
+
+	switch env.GetWait() {
+	case pbModels.WaitMode_pub:
+		if err = c.cache.Publish(ctx, env.GetOperation(), data); err != nil {
+			return errors.Wrap(err, "publish")
 		}
-	case pb.Wait_cache.String():
-		if err := c.cache.Set(ctx, uid, msg.Value, expirationCached).Err(); err != nil {
-			if err = c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-				Topic:   consts.TopicMailing,
-				Key:     sarama.ByteEncoder(msg.Key),
-				Value:   sarama.ByteEncoder(msg.Value),
-				Headers: adaptor.ConsumerHeaderToProducer(msg.Headers),
-			}); err != nil {
-				return err
-			}
+	case pbModels.WaitMode_cache:
+		if err = c.cache.Set(ctx, env.GetRequestId(), data, expirationCached); err != nil {
+			return errors.Wrap(err, "cache")
 		}
 	}
 
 	return nil
 }
 
-func (c *core) sendError(ctx context.Context, msg *sarama.ConsumerMessage) error {
+// sendError delivers the error to the waiting client, the message is retried by the consumer if the cache fails.
+func (c *core) sendError(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, mailingService)
 	defer span.Finish()
-	uid, pub := helper.ExtractUidPubFromMessage(msg)
-
-	switch pub {
-	case pb.Wait_pub.String():
-		if err := c.cache.Set(ctx, string(msg.Key), msg.Value, expirationCached).Err(); err != nil {
-			if err = c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-				Topic:   consts.TopicError,
-				Key:     sarama.ByteEncoder(msg.Key),
-				Value:   sarama.ByteEncoder(msg.Value),
-				Headers: adaptor.ConsumerHeaderToProducer(msg.Headers),
-			}); err != nil {
-				return err
-			}
+
+	// the clients wait for the description of the error
+	data := []byte(env.GetError().GetMessage())
+
+	switch env.GetWait() {
+	case pbModels.WaitMode_pub:
+		if err := c.cache.Set(ctx, env.GetOperation(), data, expirationCached); err != nil {
+			return errors.Wrap(err, "cache")
 		}
-	case pb.Wait_cache.String():
-		if err := c.cache.Publish(ctx, uid, msg.Value).Err(); err != nil {
-			if err = c.sendMessageWithCtx(ctx, &sarama.ProducerMessage{
-				Topic:   consts.TopicError,
-				Key:     sarama.ByteEncoder(msg.Key),
-				Value:   sarama.ByteEncoder(msg.Value),
-				Headers: adaptor.ConsumerHeaderToProducer(msg.Headers),
-			}); err != nil {
-				return err
-			}
+	case pbModels.WaitMode_cache:
+		if err := c.cache.Publish(ctx, env.GetRequestId(), data); err != nil {
+			return errors.Wrap(err, "publish")
 		}
 	}
 
 	return nil
 }
 
-func (c *core) sendMessageWithCtx(ctx context.Context, message *sarama.ProducerMessage) error {
-	if err := helper.InjectHeaders(ctx, message); err != nil {
-		return err
+// result returns the result of the envelope in the format the clients wait for.
+func result(env *pbModels.Envelope) ([]byte, error) {
+	var value interface{}
+	switch payload := env.GetPayload().(type) {
+	case *pbModels.Envelope_User:
+		value = adaptor.ToUserResultCoreModel(payload.User)
+	case *pbModels.Envelope_BatchResults:
+		value = adaptor.ToUserBatchResultsCoreModel(payload.BatchResults)
+	case *pbModels.Envelope_Page:
+		value = adaptor.ToUserListPageCoreModel(payload.Page)
+	default:
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal result")
 	}
-	_, _, err := c.producer.SendMessage(message)
-	return err
+	return data, nil
 }
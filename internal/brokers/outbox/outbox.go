@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+const (
+	defaultInterval      = time.Second
+	defaultBatchSize     = 100
+	defaultRetention     = 24 * time.Hour
+	defaultPurgeInterval = time.Hour
+)
+
+// Config sets how often the outbox is polled and how many messages are published at once.
+// The published messages are kept for Retention, the older ones are purged every PurgeInterval.
+// The redelivered request of the kept message is not applied again, so Retention must cover the redelivery.
+type Config struct {
+	Interval      time.Duration `mapstructure:"interval"`
+	BatchSize     uint64        `mapstructure:"batch_size"`
+	Retention     time.Duration `mapstructure:"retention"`
+	PurgeInterval time.Duration `mapstructure:"purge_interval"`
+}
+
+// Store is the outbox written by the repository with the user changes.
+type Store interface {
+	OutboxList(ctx context.Context, limit uint64) ([]models.OutboxMessage, error)
+	OutboxMarkPublished(ctx context.Context, ids []uint64) error
+	OutboxPurge(ctx context.Context, before time.Time) (int64, error)
+}
+
+type Interface interface {
+	Run(ctx context.Context)
+}
+
+// New returns the relay publishing the outbox messages in the order they were written.
+func New(store Store, producer brokerPkg.Publisher, cfg Config, logger *zap.SugaredLogger) Interface {
+	if cfg.Interval == 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.Retention == 0 {
+		cfg.Retention = defaultRetention
+	}
+	if cfg.PurgeInterval == 0 {
+		cfg.PurgeInterval = defaultPurgeInterval
+	}
+	return &relay{
+		store:    store,
+		producer: producer,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+type relay struct {
+	store    Store
+	producer brokerPkg.Publisher
+	cfg      Config
+	logger   *zap.SugaredLogger
+}
+
+// Run publishes the outbox every interval and purges it every purge interval until ctx is done.
+func (r *relay) Run(ctx context.Context) {
+	r.logger.Infoln("Start outbox relay, interval", r.cfg.Interval)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	purgeTicker := time.NewTicker(r.cfg.PurgeInterval)
+	defer purgeTicker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			r.logger.Infoln("Outbox relay stopped")
+			return
+		case <-purgeTicker.C:
+			purged, err := r.store.OutboxPurge(ctx, time.Now().Add(-r.cfg.Retention))
+			if err != nil {
+				r.logger.Errorf("outbox relay: purge: %v", err)
+				continue
+			}
+			if purged > 0 {
+				r.logger.Infof("%d published outbox messages purged", purged)
+			}
+		case <-ticker.C:
+			for {
+				published, err := r.publish(ctx)
+				if err != nil {
+					r.logger.Errorf("outbox relay: %v", err)
+					break
+				}
+				// the full batch means more messages are waiting
+				if published < r.cfg.BatchSize {
+					break
+				}
+			}
+		}
+	}
+}
+
+// publish sends the pending messages and marks the sent ones as published.
+// It stops at the first failed message to keep the order, the message is sent again on the next run
+// or, if the store claims the listed messages, after the claim expires.
+// A message sent but not marked is sent again too, the consumers get it at least once.
+func (r *relay) publish(ctx context.Context) (uint64, error) {
+	messages, err := r.store.OutboxList(ctx, r.cfg.BatchSize)
+	if err != nil {
+		return 0, errors.Wrap(err, "list")
+	}
+
+	ids := make([]uint64, 0, len(messages))
+	var sendErr error
+	for _, message := range messages {
+		if sendErr = r.producer.Publish(ctx, adaptor.ToBrokerMessage(message)); sendErr != nil {
+			sendErr = errors.Wrapf(sendErr, "send message [%d]", message.ID)
+			break
+		}
+		ids = append(ids, message.ID)
+	}
+
+	if len(ids) != 0 {
+		if err = r.store.OutboxMarkPublished(ctx, ids); err != nil {
+			return 0, errors.Wrap(err, "mark published")
+		}
+	}
+	return uint64(len(ids)), sendErr
+}
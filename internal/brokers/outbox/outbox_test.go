@@ -0,0 +1,107 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	repoMockPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/mock"
+	kafkaPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/kafka"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+)
+
+func TestRelay_publish(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	messages := []models.OutboxMessage{
+		{ID: 1, Topic: "mailing", Key: "create", Headers: map[string]string{"uid": "1"}},
+		{ID: 2, Topic: "mailing", Key: "update", Value: []byte("value")},
+	}
+
+	cases := []struct {
+		name      string
+		listErr   error
+		sendErrs  []error
+		marked    []uint64
+		published uint64
+		expErr    bool
+	}{
+		{
+			name:      "success",
+			sendErrs:  []error{nil, nil},
+			marked:    []uint64{1, 2},
+			published: 2,
+		},
+		{
+			name:      "failed send stops publishing",
+			sendErrs:  []error{nil, sarama.ErrOutOfBrokers},
+			marked:    []uint64{1},
+			published: 1,
+			expErr:    true,
+		},
+		{
+			name:     "failed first send marks nothing",
+			sendErrs: []error{sarama.ErrOutOfBrokers},
+			expErr:   true,
+		},
+		{
+			name:    "failed list",
+			listErr: errorsPkg.ErrUnexpected,
+			expErr:  true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			store := repoMockPkg.NewMockInterface(ctl)
+			store.EXPECT().OutboxList(gomock.Any(), uint64(defaultBatchSize)).
+				Return(messages, c.listErr).Times(1)
+			if c.marked != nil {
+				store.EXPECT().OutboxMarkPublished(gomock.Any(), c.marked).Return(nil).Times(1)
+			}
+
+			producer := mocks.NewSyncProducer(t, nil)
+			for _, err := range c.sendErrs {
+				if err != nil {
+					producer.ExpectSendMessageAndFail(err)
+					continue
+				}
+				producer.ExpectSendMessageAndSucceed()
+			}
+
+			r := New(store, kafkaPkg.NewPublisher(producer), Config{}, loggerPkg.NewFatal()).(*relay)
+			published, err := r.publish(context.Background())
+			assert.Equal(t, c.expErr, err != nil)
+			assert.Equal(t, c.published, published)
+			assert.NoError(t, producer.Close())
+		})
+	}
+}
+
+func TestRelay_RunPurge(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := repoMockPkg.NewMockInterface(ctl)
+	store.EXPECT().OutboxPurge(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Minute)
+			cancel()
+			return 1, nil
+		}).MinTimes(1)
+
+	producer := mocks.NewSyncProducer(t, nil)
+	cfg := Config{Interval: time.Hour, Retention: time.Hour, PurgeInterval: time.Millisecond}
+	New(store, kafkaPkg.NewPublisher(producer), cfg, loggerPkg.NewFatal()).Run(ctx)
+	assert.NoError(t, producer.Close())
+}
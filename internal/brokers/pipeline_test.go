@@ -0,0 +1,231 @@
+package brokers_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/opentracing/opentracing-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+
+	receiverPkg "gitlab.ozon.dev/iTukaev/homework/internal/api/receiver"
+	consumerPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/consumer"
+	dataPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/data"
+	mailingPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/mailing"
+	outboxPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/outbox"
+	validatorPkg "gitlab.ozon.dev/iTukaev/homework/internal/brokers/validator"
+	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	sessionPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session"
+	sessionMockPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/mock"
+	userPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
+	userMockPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/mock"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/password"
+	localPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/local"
+	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+	memoryBrokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker/memory"
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+	memoryCachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache/memory"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+)
+
+const timeout = 5 * time.Second
+
+// waitCached returns the value of the key set by the mailing service.
+func waitCached(t *testing.T, cache cachePkg.Interface, key string) []byte {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if data, err := cache.Get(context.Background(), key); err == nil {
+			return data
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("no value of [%s] cached", key)
+	return nil
+}
+
+// runServices consumes the topics of the validator, data and mailing services until ctx is done.
+func runServices(ctx context.Context, wg *sync.WaitGroup, broker memoryBrokerPkg.Broker, cache cachePkg.Interface,
+	user userPkg.Interface, session sessionPkg.Interface, logger *zap.SugaredLogger) {
+	services := []struct {
+		group  string
+		topics []string
+		handle consumerPkg.HandleFunc
+	}{
+		{
+			group:  consts.GroupValidate,
+			topics: []string{consts.TopicValidate},
+			handle: validatorPkg.NewHandler(logger, broker).Handle,
+		},
+		{
+			group:  consts.GroupData,
+			topics: []string{consts.TopicData},
+			handle: dataPkg.NewHandler(user, session, logger, broker).Handle,
+		},
+		{
+			group:  consts.GroupMailing,
+			topics: []string{consts.TopicError, consts.TopicMailing},
+			handle: mailingPkg.NewHandler(logger, cache).Handle,
+		},
+	}
+	for _, service := range services {
+		wg.Add(1)
+		go func(consumer consumerPkg.Interface) {
+			defer wg.Done()
+			_ = consumer.Run(ctx)
+		}(consumerPkg.New(broker.Subscriber(service.group), broker, service.topics, service.handle,
+			consumerPkg.Config{}, logger))
+	}
+}
+
+// TestPipeline passes the requests of the receiver through the validator, data and mailing services
+// connected by the in-memory broker.
+func TestPipeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	user := userMockPkg.NewMockInterface(ctrl)
+	session := sessionMockPkg.NewMockInterface(ctrl)
+	logger := loggerPkg.NewFatal()
+	broker := memoryBrokerPkg.New(2)
+	defer broker.Close()
+	cache := memoryCachePkg.New()
+	defer cache.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	runServices(ctx, &wg, broker, cache, user, session, logger)
+
+	server := receiverPkg.New(nil, logger, broker)
+	// the span of the request is started by the tracing interceptor
+	span := opentracing.StartSpan("client")
+	defer span.Finish()
+	reqCtx := opentracing.ContextWithSpan(ctx, span)
+
+	t.Run("get, result cached", func(t *testing.T) {
+		got := models.User{
+			Name:      "Ivan",
+			Email:     "ivan@mail.ru",
+			FullName:  "Ivan Ivanov",
+			CreatedAt: time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2022, 10, 2, 12, 0, 0, 0, time.UTC),
+			Version:   2,
+		}
+		user.EXPECT().Get(gomock.Any(), "Ivan", false).Return(got, nil)
+
+		resp, err := server.UserGet(reqCtx, &pb.UserGetRequest{Name: "Ivan", PubSub: pb.Wait_cache})
+		require.NoError(t, err)
+
+		exp, err := json.Marshal(got)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(exp), string(waitCached(t, cache, resp.GetUid())))
+	})
+
+	t.Run("get, not found", func(t *testing.T) {
+		user.EXPECT().Get(gomock.Any(), "Petr", false).Return(models.User{}, errorsPkg.ErrUserNotFound)
+
+		_, err := server.UserGet(reqCtx, &pb.UserGetRequest{Name: "Petr", PubSub: pb.Wait_pub})
+		require.NoError(t, err)
+
+		assert.Equal(t, errorsPkg.ErrUserNotFound.Error(), string(waitCached(t, cache, consts.UserGet)))
+	})
+
+	t.Run("create, not valid", func(t *testing.T) {
+		_, err := server.UserCreate(reqCtx, &pb.UserCreateRequest{
+			User:   &pbModels.User{Name: "Ivan", Email: "ivan@mail.ru", FullName: "Ivan Ivanov"},
+			PubSub: pb.Wait_pub,
+		})
+		require.NoError(t, err)
+
+		assert.Contains(t, string(waitCached(t, cache, consts.UserCreate)), "field: [password] cannot be empty")
+	})
+}
+
+// TestPipeline_Outbox changes the user in the local repository, the replies are written to the outbox
+// with the changes and published to the in-memory broker by the relay.
+func TestPipeline_Outbox(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	session := sessionMockPkg.NewMockInterface(ctrl)
+	logger := loggerPkg.NewFatal()
+	broker := memoryBrokerPkg.New(2)
+	defer broker.Close()
+	cache := memoryCachePkg.New()
+	defer cache.Close()
+	userCache := memoryCachePkg.New()
+	defer userCache.Close()
+
+	hasher, err := password.New(password.Config{Algorithm: password.Bcrypt, Cost: bcrypt.MinCost})
+	require.NoError(t, err)
+	repo := localPkg.New(1, logger)
+	user := userPkg.New(repo, logger, userCache, hasher)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+		repo.Close()
+	}()
+
+	runServices(ctx, &wg, broker, cache, user, session, logger)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		outboxPkg.New(repo, broker, outboxPkg.Config{Interval: 10 * time.Millisecond}, logger).Run(ctx)
+	}()
+
+	server := receiverPkg.New(nil, logger, broker)
+	span := opentracing.StartSpan("client")
+	defer span.Finish()
+	reqCtx := opentracing.ContextWithSpan(ctx, span)
+
+	t.Run("create", func(t *testing.T) {
+		resp, err := server.UserCreate(reqCtx, &pb.UserCreateRequest{
+			User:   &pbModels.User{Name: "Ivan", Email: "ivan@mail.ru", FullName: "Ivan Ivanov", Password: "password"},
+			PubSub: pb.Wait_cache,
+		})
+		require.NoError(t, err)
+		waitCached(t, cache, resp.GetUid())
+
+		got, err := user.Get(ctx, "Ivan", false)
+		require.NoError(t, err)
+		assert.Equal(t, "Ivan Ivanov", got.FullName)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		fullName := "Ivan Petrov"
+		resp, err := server.UserUpdate(reqCtx, &pb.UserUpdateRequest{
+			Name:    "Ivan",
+			Profile: &pbModels.Profile{FullName: &fullName},
+			PubSub:  pb.Wait_cache,
+		})
+		require.NoError(t, err)
+		waitCached(t, cache, resp.GetUid())
+
+		got, err := user.Get(ctx, "Ivan", false)
+		require.NoError(t, err)
+		assert.Equal(t, fullName, got.FullName)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		session.EXPECT().RevokeAll(gomock.Any(), "Ivan").Return(nil)
+
+		resp, err := server.UserDelete(reqCtx, &pb.UserDeleteRequest{Name: "Ivan", PubSub: pb.Wait_cache})
+		require.NoError(t, err)
+		waitCached(t, cache, resp.GetUid())
+
+		_, err = user.Get(ctx, "Ivan", false)
+		assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+	})
+}
@@ -2,17 +2,19 @@ package validator
 
 import (
 	"context"
-	"encoding/json"
 	"regexp"
 
-	"github.com/Shopify/sarama"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/envelope"
 	"gitlab.ozon.dev/iTukaev/homework/internal/consts"
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
 )
 
@@ -25,13 +27,16 @@ var (
 )
 
 type sender interface {
-	userCreate(ctx context.Context, msg *sarama.ConsumerMessage) error
-	userUpdate(ctx context.Context, msg *sarama.ConsumerMessage) error
-	userDelete(ctx context.Context, msg *sarama.ConsumerMessage) error
-	userGet(ctx context.Context, msg *sarama.ConsumerMessage) error
+	userCreate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userBatchCreate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userUpdate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userDelete(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userRestore(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	userGet(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error
+	sendError(ctx context.Context, key string, env *pbModels.Envelope, err error) error
 }
 
-func newSender(logger *zap.SugaredLogger, producer sarama.SyncProducer) sender {
+func newSender(logger *zap.SugaredLogger, producer brokerPkg.Publisher) sender {
 	return &core{
 		producer: producer,
 		logger:   logger,
@@ -39,94 +44,113 @@ func newSender(logger *zap.SugaredLogger, producer sarama.SyncProducer) sender {
 }
 
 type core struct {
-	producer sarama.SyncProducer
+	producer brokerPkg.Publisher
 	logger   *zap.SugaredLogger
 }
 
-func (c *core) userCreate(ctx context.Context, msg *sarama.ConsumerMessage) error {
+func (c *core) userCreate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, validateService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	user := models.NewUser()
-	if err := json.Unmarshal(msg.Value, &user); err != nil {
-		return errors.Wrap(err, "unmarshal")
-	}
+	user := adaptor.ToUserCoreModel(env.GetUser())
 
 	c.logger.Debugf("user [%s]", user.String())
 
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicData,
-		Key:   sarama.StringEncoder(consts.UserCreate),
-		Value: sarama.ByteEncoder(msg.Value),
-	}
 	if err := createValidator(user); err != nil {
-		return c.sendValidationErrorWithCtx(ctx, message, err.Error())
+		return c.sendError(ctx, user.Name, env, err)
 	}
 
-	return c.sendMessageWithCtx(ctx, message)
+	return c.sendEnvelopeWithCtx(ctx, user.Name, env)
 }
 
-func (c *core) userUpdate(ctx context.Context, msg *sarama.ConsumerMessage) error {
+// userBatchCreate forwards the whole batch, the invalid users are marked with their errors.
+func (c *core) userBatchCreate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, validateService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	user := models.NewUser()
-	if err := json.Unmarshal(msg.Value, &user); err != nil {
-		return errors.Wrap(err, "message unmarshal")
-	}
+	batch := adaptor.ToUserBatchCoreModel(env.GetBatch())
 
-	c.logger.Debugf("user [%s]", user.String())
+	c.logger.Debugf("batch of [%d] users", len(batch.Users))
 
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicData,
-		Key:   sarama.StringEncoder(consts.UserUpdate),
-		Value: sarama.ByteEncoder(msg.Value),
+	// the batch is keyed by the request, its users are spread over the partitions anyway
+	uid, _ := helper.ExtractUidPubFromCtx(ctx)
+	if len(batch.Users) == 0 {
+		return c.sendError(ctx, uid, env, errors.Wrap(errorsPkg.ErrValidation, "empty batch"))
 	}
-	if err := updateValidator(user); err != nil {
-		return c.sendValidationErrorWithCtx(ctx, message, err.Error())
+
+	errs := make([]string, len(batch.Users))
+	for i := range batch.Users {
+		if err := createValidator(&batch.Users[i]); err != nil {
+			errs[i] = err.Error()
+		}
 	}
+	env.GetBatch().Errors = errs
 
-	return c.sendMessageWithCtx(ctx, message)
+	return c.sendEnvelopeWithCtx(ctx, uid, env)
 }
 
-func (c *core) userDelete(ctx context.Context, msg *sarama.ConsumerMessage) error {
+func (c *core) userUpdate(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, validateService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	name := string(msg.Value)
+	profile := adaptor.ToProfileUpdateCoreModel(env.GetProfile())
+
+	c.logger.Debugf("profile [%s]", profile.String())
 
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicData,
-		Key:   sarama.StringEncoder(consts.UserDelete),
-		Value: sarama.ByteEncoder(msg.Value),
+	if err := updateValidator(profile); err != nil {
+		return c.sendError(ctx, profile.Name, env, err)
 	}
-	if err := deleteValidator(name); err != nil {
-		return c.sendValidationErrorWithCtx(ctx, message, err.Error())
+
+	return c.sendEnvelopeWithCtx(ctx, profile.Name, env)
+}
+
+func (c *core) userDelete(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
+	span := helper.GetSpanFromMessage(msg, validateService)
+	ctx = opentracing.ContextWithSpan(ctx, span)
+	defer span.Finish()
+
+	params := models.NewUserDeleteParams().
+		NameSet(env.GetKey().GetName()).
+		VersionSet(env.GetKey().GetVersion())
+
+	if err := deleteValidator(params); err != nil {
+		return c.sendError(ctx, params.Name, env, err)
 	}
 
-	return c.sendMessageWithCtx(ctx, message)
+	return c.sendEnvelopeWithCtx(ctx, params.Name, env)
 }
 
-func (c *core) userGet(ctx context.Context, msg *sarama.ConsumerMessage) error {
+func (c *core) userRestore(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
 	span := helper.GetSpanFromMessage(msg, validateService)
 	ctx = opentracing.ContextWithSpan(ctx, span)
 	defer span.Finish()
 
-	name := string(msg.Value)
+	name := env.GetKey().GetName()
 
-	message := &sarama.ProducerMessage{
-		Topic: consts.TopicData,
-		Key:   sarama.StringEncoder(consts.UserGet),
-		Value: sarama.ByteEncoder(msg.Value),
+	if err := restoreValidator(name); err != nil {
+		return c.sendError(ctx, name, env, err)
 	}
-	if err := getValidator(name); err != nil {
-		return c.sendValidationErrorWithCtx(ctx, message, err.Error())
+
+	return c.sendEnvelopeWithCtx(ctx, name, env)
+}
+
+func (c *core) userGet(ctx context.Context, msg *brokerPkg.Message, env *pbModels.Envelope) error {
+	span := helper.GetSpanFromMessage(msg, validateService)
+	ctx = opentracing.ContextWithSpan(ctx, span)
+	defer span.Finish()
+
+	params := models.NewUserGetParams().
+		NameSet(env.GetKey().GetName()).
+		WithDeletedSet(env.GetKey().GetWithDeleted())
+
+	if err := getValidator(params); err != nil {
+		return c.sendError(ctx, params.Name, env, err)
 	}
 
-	return c.sendMessageWithCtx(ctx, message)
+	return c.sendEnvelopeWithCtx(ctx, params.Name, env)
 }
 
 func createValidator(user *models.User) error {
@@ -145,55 +169,69 @@ func createValidator(user *models.User) error {
 	return nil
 }
 
-func updateValidator(user *models.User) error {
-	if user.Name == "" {
+func updateValidator(profile *models.Profile) error {
+	if profile.Name == "" {
 		return errors.Wrap(errorsPkg.ErrValidation, "field: [name] cannot be empty")
 	}
-	if user.Password == "" {
+	if profile.Empty() {
+		return errors.Wrap(errorsPkg.ErrValidation, "nothing to update")
+	}
+	if profile.Password != nil && *profile.Password == "" {
 		return errors.Wrap(errorsPkg.ErrValidation, "field: [password] cannot be empty")
 	}
-	if !email.MatchString(user.Email) {
+	if profile.Email != nil && !email.MatchString(*profile.Email) {
 		return errors.Wrap(errorsPkg.ErrValidation, "field: [email] has invalid format")
 	}
-	if user.FullName == "" {
+	if profile.FullName != nil && *profile.FullName == "" {
 		return errors.Wrap(errorsPkg.ErrValidation, "field: [full_name] cannot be empty")
 	}
 	return nil
 }
 
-func deleteValidator(name string) error {
-	if name == "" {
+func deleteValidator(params *models.UserDeleteParams) error {
+	if params.Name == "" {
 		return errors.Wrap(errorsPkg.ErrValidation, "field: [name] cannot be empty")
 	}
 	return nil
 }
 
-func getValidator(name string) error {
+func restoreValidator(name string) error {
 	if name == "" {
 		return errors.Wrap(errorsPkg.ErrValidation, "field: [name] cannot be empty")
 	}
 	return nil
 }
 
-func (c *core) sendValidationErrorWithCtx(
-	ctx context.Context,
-	message *sarama.ProducerMessage,
-	description string,
-) error {
-	if err := helper.InjectHeaders(ctx, message); err != nil {
+func getValidator(params *models.UserGetParams) error {
+	if params.Name == "" {
+		return errors.Wrap(errorsPkg.ErrValidation, "field: [name] cannot be empty")
+	}
+	return nil
+}
+
+// sendError replies to the request with the error.
+func (c *core) sendError(ctx context.Context, key string, env *pbModels.Envelope, err error) error {
+	reply := envelope.Reply(env)
+	reply.Payload = &pbModels.Envelope_Error{Error: envelope.Error(err)}
+	message, err := envelope.Message(consts.TopicError, key, reply)
+	if err != nil {
 		return err
 	}
-	message.Topic = consts.TopicError
-	message.Value = sarama.StringEncoder(description)
+	return c.sendMessageWithCtx(ctx, message)
+}
 
-	_, _, err := c.producer.SendMessage(message)
-	return err
+// sendEnvelopeWithCtx forwards the validated request to the data service.
+func (c *core) sendEnvelopeWithCtx(ctx context.Context, key string, env *pbModels.Envelope) error {
+	message, err := envelope.Message(consts.TopicData, key, env)
+	if err != nil {
+		return err
+	}
+	return c.sendMessageWithCtx(ctx, message)
 }
 
-func (c *core) sendMessageWithCtx(ctx context.Context, message *sarama.ProducerMessage) error {
+func (c *core) sendMessageWithCtx(ctx context.Context, message *brokerPkg.Message) error {
 	if err := helper.InjectHeaders(ctx, message); err != nil {
 		return err
 	}
-	_, _, err := c.producer.SendMessage(message)
-	return err
+	return c.producer.Publish(ctx, message)
 }
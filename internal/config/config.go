@@ -1,6 +1,13 @@
 package config
 
 import (
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/consumer"
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/outbox"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/password"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo/embedded"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo/local"
 	pgModels "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres/models"
 	redisPkg "gitlab.ozon.dev/iTukaev/homework/pkg/redis"
 )
@@ -14,6 +21,7 @@ type Interface interface {
 type ExternalServices interface {
 	LogLevel() string
 	Brokers() []string
+	ConsumerConfig() consumer.Config
 	JService() string
 	JHost() string
 }
@@ -28,7 +36,14 @@ type Transport interface {
 
 type Data interface {
 	PGConfig() pgModels.Config
-	Local() bool
+	Storage() string
+	LocalConfig() local.Config
+	EmbeddedConfig() embedded.Config
 	WorkersCount() int
+	Cache() string
 	RedisConfig() redisPkg.Config
+	HasherConfig() password.Config
+	AuthConfig() session.Config
+	PurgeConfig() user.PurgeConfig
+	OutboxConfig() outbox.Config
 }
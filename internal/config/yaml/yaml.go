@@ -6,7 +6,15 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/viper"
 
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/consumer"
+	"gitlab.ozon.dev/iTukaev/homework/internal/brokers/outbox"
 	configPkg "gitlab.ozon.dev/iTukaev/homework/internal/config"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/password"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo/embedded"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo/local"
 	pgModels "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres/models"
 	redisPkg "gitlab.ozon.dev/iTukaev/homework/pkg/redis"
 )
@@ -64,8 +72,65 @@ func (config) RedisConfig() redisPkg.Config {
 	return cfg
 }
 
-func (config) Local() bool {
-	return viper.GetBool("local")
+func (config) HasherConfig() password.Config {
+	var cfg password.Config
+	if err := viper.UnmarshalKey("hasher", &cfg); err != nil {
+		log.Fatalf("Hasher config unmarshal error: %v\n", err)
+	}
+	return cfg
+}
+
+func (config) AuthConfig() session.Config {
+	var cfg session.Config
+	if err := viper.UnmarshalKey("auth", &cfg); err != nil {
+		log.Fatalf("Auth config unmarshal error: %v\n", err)
+	}
+	return cfg
+}
+
+func (config) PurgeConfig() user.PurgeConfig {
+	var cfg user.PurgeConfig
+	if err := viper.UnmarshalKey("purge", &cfg); err != nil {
+		log.Fatalf("Purge config unmarshal error: %v\n", err)
+	}
+	return cfg
+}
+
+func (config) OutboxConfig() outbox.Config {
+	var cfg outbox.Config
+	if err := viper.UnmarshalKey("outbox", &cfg); err != nil {
+		log.Fatalf("Outbox config unmarshal error: %v\n", err)
+	}
+	return cfg
+}
+
+// Storage returns the users storage, the legacy "local: true" selects the memory storage if it is not set.
+func (config) Storage() string {
+	storage := viper.GetString("storage")
+	if storage == "" && viper.GetBool("local") {
+		return repo.Memory
+	}
+	return storage
+}
+
+func (config) LocalConfig() local.Config {
+	var cfg local.Config
+	if err := viper.UnmarshalKey("local_storage", &cfg); err != nil {
+		log.Fatalf("Local storage config unmarshal error: %v\n", err)
+	}
+	return cfg
+}
+
+func (config) EmbeddedConfig() embedded.Config {
+	var cfg embedded.Config
+	if err := viper.UnmarshalKey("embedded", &cfg); err != nil {
+		log.Fatalf("Embedded storage config unmarshal error: %v\n", err)
+	}
+	return cfg
+}
+
+func (config) Cache() string {
+	return viper.GetString("cache")
 }
 
 func (config) WorkersCount() int {
@@ -76,6 +141,14 @@ func (config) Brokers() []string {
 	return viper.GetStringSlice("brokers")
 }
 
+func (config) ConsumerConfig() consumer.Config {
+	var cfg consumer.Config
+	if err := viper.UnmarshalKey("consumer", &cfg); err != nil {
+		log.Fatalf("Consumer config unmarshal error: %v\n", err)
+	}
+	return cfg
+}
+
 func (config) JService() string {
 	return viper.GetString("jaeger.service")
 }
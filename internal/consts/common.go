@@ -1,10 +1,12 @@
 package consts
 
 const (
-	UserCreate  = "create"
-	UserUpdate  = "update"
-	UserDelete  = "delete"
-	UserGet     = "get"
-	UserList    = "list"
-	UserAllList = "all_list"
+	UserCreate      = "create"
+	UserBatchCreate = "batch_create"
+	UserUpdate      = "update"
+	UserDelete      = "delete"
+	UserRestore     = "restore"
+	UserGet         = "get"
+	UserList        = "list"
+	UserAllList     = "all_list"
 )
@@ -9,4 +9,6 @@ const (
 	GroupValidate = "group_validate"
 	GroupData     = "group_data"
 	GroupMailing  = "group_mailing"
+	// GroupDLQ commits the offsets of the re-driven dead-lettered messages
+	GroupDLQ = "group_dlq"
 )
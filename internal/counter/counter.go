@@ -23,8 +23,9 @@ var (
 	Success  *core
 	Errors   *core
 
-	Hit  *simple
-	Miss *simple
+	Hit       *simple
+	Miss      *simple
+	Coalesced *simple
 )
 
 func init() {
@@ -42,6 +43,7 @@ func init() {
 
 	Hit = new(simple)
 	Miss = new(simple)
+	Coalesced = new(simple)
 }
 
 func (c *core) Inc(param string) {
@@ -1,11 +1,47 @@
 package customerrors
 
-import "github.com/pkg/errors"
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
 
 var (
-	ErrUserNotFound      = errors.New("user not found")
-	ErrUserAlreadyExists = errors.New("user already exists")
-	ErrTimeout           = errors.New("deadline exceeded")
-	ErrUnexpected        = errors.New("unexpected error")
-	ErrValidation        = errors.New("validation error")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrUserAlreadyExists  = errors.New("user already exists")
+	ErrEmailAlreadyExists = errors.New("email already exists")
+	ErrTimeout            = errors.New("deadline exceeded")
+	ErrUnexpected         = errors.New("unexpected error")
+	ErrValidation         = errors.New("validation error")
+	ErrPasswordMismatch   = errors.New("password mismatch")
+	ErrVersionConflict    = errors.New("user version conflict")
+	ErrInvalidPageToken   = errors.New("invalid page token")
+	ErrInvalidField       = errors.New("invalid field value")
+	ErrFieldTooLong       = errors.New("field value is too long")
+	ErrRequestProcessed   = errors.New("request already processed")
+
+	ErrInvalidCredentials = errors.New("invalid user name or password")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrSessionNotFound    = errors.New("session not found")
 )
+
+// FieldError is the storage constraint violation of the user field,
+// Err is ErrUserAlreadyExists, ErrEmailAlreadyExists, ErrInvalidField or ErrFieldTooLong.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%v, field: [%s]", e.Err, e.Field)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// IsFieldError returns true if the error is caused by the user field.
+func IsFieldError(err error) bool {
+	var fieldErr *FieldError
+	return errors.As(err, &fieldErr)
+}
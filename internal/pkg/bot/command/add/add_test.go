@@ -12,8 +12,8 @@ import (
 
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
-	"gitlab.ozon.dev/iTukaev/homework/pkg/adaptor"
 	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
 	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
 	apiMockPkg "gitlab.ozon.dev/iTukaev/homework/pkg/mock"
 )
@@ -71,7 +71,12 @@ func TestAddCommand_Process(t *testing.T) {
 
 			gomock.InOrder(
 				mockClient.EXPECT().UserCreate(ctx, &pb.UserCreateRequest{
-					User: adaptor.ToUserPbModel(user),
+					User: &pbModels.User{
+						Name:     user.Name,
+						Password: user.Password,
+						Email:    user.Email,
+						FullName: user.FullName,
+					},
 				}).Return(&pb.UserCreateResponse{}, c.expErr).MaxTimes(1),
 			)
 			text := addCommand.Process(ctx, c.args)
@@ -14,7 +14,7 @@ import (
 
 const (
 	deleteName        = "del"
-	deleteDescription = "delete user [/del <name>]"
+	deleteDescription = "delete user, it can be restored later [/del <name>]"
 )
 
 func New(api pb.UserClient, logger *zap.SugaredLogger) commandPkg.Interface {
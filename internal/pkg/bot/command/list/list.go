@@ -2,6 +2,7 @@ package list
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 
@@ -12,6 +13,15 @@ import (
 	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
 )
 
+const (
+	sortOption   = "sort"
+	domainOption = "domain"
+	nameOption   = "name"
+	fromOption   = "from"
+	toOption     = "to"
+	pageOption   = "page"
+)
+
 func New(api pb.UserClient, logger *zap.SugaredLogger) commandPkg.Interface {
 	return &command{
 		api:    api,
@@ -25,8 +35,8 @@ type command struct {
 }
 
 func (c *command) Process(ctx context.Context, args string) string {
-	params := strings.Split(args, " ")
-	if len(params) != 3 {
+	params := strings.Fields(args)
+	if len(params) < 2 {
 		return "invalid arguments"
 	}
 	order, err := strconv.ParseBool(params[0])
@@ -37,16 +47,42 @@ func (c *command) Process(ctx context.Context, args string) string {
 	if err != nil {
 		return "invalid [limit] argument"
 	}
-	offset, err := strconv.ParseUint(params[2], 10, 64)
-	if err != nil {
-		return "invalid [offset] argument"
-	}
-
-	list, err := c.api.UserList(ctx, &pb.UserListRequest{
+	request := &pb.UserListRequest{
 		Order:  order,
 		Limit:  limit,
-		Offset: offset,
-	})
+		Filter: &pb.UserFilter{},
+	}
+	for _, option := range params[2:] {
+		key, value, ok := strings.Cut(option, "=")
+		if !ok || value == "" {
+			return fmt.Sprintf("invalid option [%s]", option)
+		}
+		switch key {
+		case sortOption:
+			// spaces separate the bot arguments, so the direction follows the field after a colon
+			request.OrderBy = strings.ReplaceAll(value, ":", " ")
+		case domainOption:
+			request.Filter.EmailDomain = value
+		case nameOption:
+			request.Filter.FullName = value
+		case fromOption, toOption:
+			created, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Sprintf("invalid [%s] option", key)
+			}
+			if key == fromOption {
+				request.Filter.CreatedFrom = created
+			} else {
+				request.Filter.CreatedTo = created
+			}
+		case pageOption:
+			request.PageToken = value
+		default:
+			return fmt.Sprintf("unknown option [%s]", key)
+		}
+	}
+
+	list, err := c.api.UserList(ctx, request)
 	if err != nil {
 		c.logger.Errorf("user list, arguments [%s]: %v\n", args, err)
 		if st, ok := status.FromError(err); ok {
@@ -69,5 +105,6 @@ func (*command) Name() string {
 }
 
 func (*command) Description() string {
-	return "get all users info [/list <order-true/false> <limit> <offset>]"
+	return "get users info [/list <order-true/false> <limit> sort=<created_at:desc,email> domain=<email domain> " +
+		"name=<full name part> from=<created_at> to=<created_at> page=<next page token>], options are optional"
 }
@@ -0,0 +1,127 @@
+package list
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+	apiMockPkg "gitlab.ozon.dev/iTukaev/homework/pkg/mock"
+)
+
+const (
+	uid = "uid"
+)
+
+func TestListCommand_Process(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		args    string
+		request *pb.UserListRequest
+		expText string
+		expErr  error
+	}{
+		{
+			name: "success",
+			args: "true 10",
+			request: &pb.UserListRequest{
+				Order:  true,
+				Limit:  10,
+				Filter: &pb.UserFilter{},
+			},
+			expText: uid,
+			expErr:  nil,
+		},
+		{
+			name: "success, with options",
+			args: "false 10 sort=created_at:desc,email domain=example.com name=ivan from=100 to=200 page=token",
+			request: &pb.UserListRequest{
+				Order:     false,
+				Limit:     10,
+				PageToken: "token",
+				OrderBy:   "created_at desc,email",
+				Filter: &pb.UserFilter{
+					EmailDomain: "example.com",
+					FullName:    "ivan",
+					CreatedFrom: 100,
+					CreatedTo:   200,
+				},
+			},
+			expText: uid,
+			expErr:  nil,
+		},
+		{
+			name: "failed, UserList returns specific error",
+			args: "true 10 sort=age",
+			request: &pb.UserListRequest{
+				Order:   true,
+				Limit:   10,
+				OrderBy: "age",
+				Filter:  &pb.UserFilter{},
+			},
+			expText: "unknown sort field",
+			expErr:  status.Error(codes.InvalidArgument, "unknown sort field"),
+		},
+		{
+			name: "failed, UserList returns error",
+			args: "true 10",
+			request: &pb.UserListRequest{
+				Order:  true,
+				Limit:  10,
+				Filter: &pb.UserFilter{},
+			},
+			expText: "internal error",
+			expErr:  errorsPkg.ErrUnexpected,
+		},
+		{
+			name:    "failed, invalid arguments",
+			args:    "true",
+			expText: "invalid arguments",
+		},
+		{
+			name:    "failed, invalid limit",
+			args:    "true ten",
+			expText: "invalid [limit] argument",
+		},
+		{
+			name:    "failed, invalid option",
+			args:    "true 10 domain",
+			expText: "invalid option [domain]",
+		},
+		{
+			name:    "failed, unknown option",
+			args:    "true 10 age=10",
+			expText: "unknown option [age]",
+		},
+		{
+			name:    "failed, invalid created_at",
+			args:    "true 10 from=yesterday",
+			expText: "invalid [from] option",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockClient := apiMockPkg.NewMockUserClient(ctl)
+			listCommand := New(mockClient, loggerPkg.NewFatal())
+
+			if c.request != nil {
+				mockClient.EXPECT().UserList(ctx, c.request).
+					Return(&pb.UserListResponse{Uid: uid}, c.expErr).Times(1)
+			}
+			text := listCommand.Process(ctx, c.args)
+
+			assert.Equal(t, c.expText, text)
+		})
+	}
+}
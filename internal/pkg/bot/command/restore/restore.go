@@ -0,0 +1,56 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/status"
+
+	commandPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/bot/command"
+	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+)
+
+const (
+	restoreName        = "restore"
+	restoreDescription = "restore deleted user [/restore <name>]"
+)
+
+func New(api pb.UserClient, logger *zap.SugaredLogger) commandPkg.Interface {
+	return &command{
+		api:    api,
+		logger: logger,
+	}
+}
+
+type command struct {
+	api    pb.UserClient
+	logger *zap.SugaredLogger
+}
+
+func (c *command) Process(ctx context.Context, args string) string {
+	args = strings.TrimSpace(args)
+	if args == "" {
+		return "invalid arguments"
+	}
+
+	if _, err := c.api.UserRestore(ctx, &pb.UserRestoreRequest{
+		Name: args,
+	}); err != nil {
+		c.logger.Errorf("user [%s] restore: %v\n", args, err)
+		if st, ok := status.FromError(err); ok {
+			return st.Message()
+		}
+		return "internal error"
+	}
+	return fmt.Sprintf("user [%s] restored", args)
+}
+
+func (*command) Name() string {
+	return restoreName
+}
+
+func (*command) Description() string {
+	return restoreDescription
+}
@@ -0,0 +1,103 @@
+package restore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+	apiMockPkg "gitlab.ozon.dev/iTukaev/homework/pkg/mock"
+)
+
+const (
+	userName = "Ivan"
+)
+
+func TestRestoreCommand_Process(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		args    string
+		expText string
+		expErr  error
+	}{
+		{
+			name:    "success",
+			args:    userName,
+			expText: fmt.Sprintf("user [%s] restored", userName),
+			expErr:  nil,
+		},
+		{
+			name:    "failed, UserRestore returns error",
+			args:    userName,
+			expText: "internal error",
+			expErr:  errorsPkg.ErrUnexpected,
+		},
+		{
+			name:    "failed, UserRestore returns specific error",
+			args:    userName,
+			expText: "error message",
+			expErr:  status.Error(codes.Internal, "error message"),
+		},
+		{
+			name:    "failed, invalid arguments",
+			args:    "",
+			expText: "invalid arguments",
+			expErr:  nil,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockClient := apiMockPkg.NewMockUserClient(ctl)
+			restoreCommand := New(mockClient, loggerPkg.NewFatal())
+
+			gomock.InOrder(
+				mockClient.EXPECT().UserRestore(ctx, &pb.UserRestoreRequest{
+					Name: c.args,
+				}).Return(&pb.UserRestoreResponse{}, c.expErr).MaxTimes(1),
+			)
+			text := restoreCommand.Process(ctx, c.args)
+
+			assert.Equal(t, c.expText, text)
+		})
+	}
+}
+
+func TestRestoreCommand_Name(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		mockClient := apiMockPkg.NewMockUserClient(ctl)
+		restoreCommand := New(mockClient, loggerPkg.NewFatal())
+
+		name := restoreCommand.Name()
+
+		assert.Equal(t, restoreName, name)
+	})
+}
+
+func TestRestoreCommand_Description(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	t.Run("success", func(t *testing.T) {
+		mockClient := apiMockPkg.NewMockUserClient(ctl)
+		restoreCommand := New(mockClient, loggerPkg.NewFatal())
+
+		description := restoreCommand.Description()
+
+		assert.Equal(t, restoreDescription, description)
+	})
+}
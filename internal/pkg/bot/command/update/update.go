@@ -13,6 +13,10 @@ import (
 	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
 )
 
+const (
+	skip = "-"
+)
+
 func New(api pb.UserClient, logger *zap.SugaredLogger) commandPkg.Interface {
 	return &command{
 		api:    api,
@@ -34,9 +38,9 @@ func (c *command) Process(ctx context.Context, args string) string {
 	if _, err := c.api.UserUpdate(ctx, &pb.UserUpdateRequest{
 		Name: params[0],
 		Profile: &pbModels.Profile{
-			Password: &params[1],
-			Email:    &params[2],
-			FullName: &params[3],
+			Password: optional(params[1]),
+			Email:    optional(params[2]),
+			FullName: optional(params[3]),
 		},
 	}); err != nil {
 		c.logger.Errorf("user [%s] update: %v\n", params[0], err)
@@ -48,10 +52,18 @@ func (c *command) Process(ctx context.Context, args string) string {
 	return fmt.Sprintf("user [%s] updated", params[0])
 }
 
+// optional returns nil for the skipped field.
+func optional(param string) *string {
+	if param == skip {
+		return nil
+	}
+	return &param
+}
+
 func (*command) Name() string {
 	return "update"
 }
 
 func (*command) Description() string {
-	return "update user [/update <name> <new password> <new email> <new full_name>]"
+	return "update user, \"-\" keeps the field [/update <name> <new password|-> <new email|-> <new full_name|->]"
 }
@@ -0,0 +1,18 @@
+package models
+
+// Session is a refresh session stored in the cache. Secret keeps the SHA-256 of the
+// refresh token secret, the refresh token itself is never stored.
+type Session struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Secret    string `json:"secret"`
+	CreatedAt int64  `json:"created_at"`
+	ExpiresAt int64  `json:"expires_at"`
+}
+
+type Token struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    int64
+	SessionID    string
+}
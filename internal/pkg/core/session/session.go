@@ -0,0 +1,361 @@
+//go:generate mockgen -source=session.go -destination=./mock/session_mock.go -package=mock
+
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/models"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/token"
+	userPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+)
+
+const (
+	ctxTimeout = 5 * time.Second
+
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+
+	sessionPrefix  = "session:"
+	sessionsPrefix = "sessions:"
+
+	secretLen = 32
+)
+
+type Interface interface {
+	Login(ctx context.Context, name, password string) (models.Token, error)
+	Refresh(ctx context.Context, refreshToken string) (models.Token, error)
+	Logout(ctx context.Context, refreshToken string) error
+	List(ctx context.Context, name string) ([]models.Session, error)
+	Revoke(ctx context.Context, name, id string) error
+	// RevokeAll closes all the sessions of the user, it is called when the user is deleted
+	// or their password is changed.
+	RevokeAll(ctx context.Context, name string) error
+	Authorize(ctx context.Context, accessToken string) (token.Claims, error)
+}
+
+type Config struct {
+	Token      token.Config  `mapstructure:",squash"`
+	AccessTTL  time.Duration `mapstructure:"access_ttl"`
+	RefreshTTL time.Duration `mapstructure:"refresh_ttl"`
+}
+
+func New(user userPkg.Interface, signer token.Signer, cache cachePkg.Interface, cfg Config, logger *zap.SugaredLogger) Interface {
+	if cfg.AccessTTL == 0 {
+		cfg.AccessTTL = defaultAccessTTL
+	}
+	if cfg.RefreshTTL == 0 {
+		cfg.RefreshTTL = defaultRefreshTTL
+	}
+	return &core{
+		user:       user,
+		signer:     signer,
+		cache:      cache,
+		accessTTL:  cfg.AccessTTL,
+		refreshTTL: cfg.RefreshTTL,
+		logger:     logger,
+	}
+}
+
+type core struct {
+	user       userPkg.Interface
+	signer     token.Signer
+	cache      cachePkg.Interface
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+	logger     *zap.SugaredLogger
+}
+
+func (c *core) Login(ctx context.Context, name, password string) (models.Token, error) {
+	c.logger.Debugln("Login", name)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	if err := c.user.CheckPassword(ctx, name, password); err != nil {
+		return models.Token{}, err
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return models.Token{}, err
+	}
+	now := time.Now()
+	session := models.Session{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Secret:    hashSecret(secret),
+		CreatedAt: now.Unix(),
+		ExpiresAt: now.Add(c.refreshTTL).Unix(),
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		return models.Token{}, errors.Wrap(err, "marshal session")
+	}
+
+	// the id is added first, ids of the sessions failed to save are removed by List
+	if err = c.cache.SAdd(ctx, sessionsPrefix+name, session.ID); err != nil {
+		return models.Token{}, errors.Wrap(err, "add session id")
+	}
+	if err = c.cache.Expire(ctx, sessionsPrefix+name, c.refreshTTL); err != nil {
+		return models.Token{}, errors.Wrap(err, "expire session ids")
+	}
+	if err = c.cache.Set(ctx, sessionPrefix+session.ID, data, c.refreshTTL); err != nil {
+		return models.Token{}, errors.Wrap(err, "save session")
+	}
+
+	return c.issue(session, secret)
+}
+
+func (c *core) Refresh(ctx context.Context, refreshToken string) (models.Token, error) {
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	id, secret, err := parseRefreshToken(refreshToken)
+	if err != nil {
+		return models.Token{}, err
+	}
+	c.logger.Debugln("Refresh", id)
+
+	newSecret, err := newSecret()
+	if err != nil {
+		return models.Token{}, err
+	}
+
+	session, old, err := getSession(ctx, c.cache, id)
+	if err != nil {
+		return models.Token{}, err
+	}
+	if !secretEqual(session.Secret, secret) {
+		// an old refresh token is reused, the session is compromised
+		c.logger.Errorf("refresh token reuse, session [%s] revoked", id)
+		if err = c.remove(ctx, session); err != nil {
+			c.logger.Errorf("remove session: %v", err)
+		}
+		return models.Token{}, errors.Wrap(errorsPkg.ErrInvalidToken, "refresh token reused")
+	}
+	// the sessions of the deleted user are closed even if RevokeAll failed
+	if _, err = c.user.Get(ctx, session.Name, false); err != nil {
+		if !errors.Is(err, errorsPkg.ErrUserNotFound) {
+			return models.Token{}, errors.Wrap(err, "session user")
+		}
+		if err = c.remove(ctx, session); err != nil {
+			c.logger.Errorf("remove session: %v", err)
+		}
+		return models.Token{}, errors.Wrapf(errorsPkg.ErrInvalidToken, "user [%s] deleted", session.Name)
+	}
+
+	session.Secret = hashSecret(newSecret)
+	data, err := json.Marshal(session)
+	if err != nil {
+		return models.Token{}, errors.Wrap(err, "marshal session")
+	}
+	// the refresh token is rotated by compare and swap, so it can be exchanged only once
+	err = c.cache.Swap(ctx, sessionPrefix+id, old, data)
+	if errors.Is(err, cachePkg.ErrConflict) {
+		return models.Token{}, errors.Wrap(errorsPkg.ErrInvalidToken, "concurrent refresh")
+	}
+	if errors.Is(err, cachePkg.ErrNotFound) {
+		return models.Token{}, errors.Wrapf(errorsPkg.ErrSessionNotFound, "session: [%s]", id)
+	}
+	if err != nil {
+		return models.Token{}, errors.Wrap(err, "rotate refresh token")
+	}
+
+	return c.issue(session, newSecret)
+}
+
+func (c *core) Logout(ctx context.Context, refreshToken string) error {
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	id, secret, err := parseRefreshToken(refreshToken)
+	if err != nil {
+		return err
+	}
+	c.logger.Debugln("Logout", id)
+
+	session, _, err := getSession(ctx, c.cache, id)
+	if err != nil {
+		return err
+	}
+	if !secretEqual(session.Secret, secret) {
+		return errors.Wrap(errorsPkg.ErrInvalidToken, "refresh token mismatch")
+	}
+
+	return c.remove(ctx, session)
+}
+
+func (c *core) List(ctx context.Context, name string) ([]models.Session, error) {
+	c.logger.Debugln("List sessions", name)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	ids, err := c.cache.SMembers(ctx, sessionsPrefix+name)
+	if err != nil {
+		return nil, errors.Wrap(err, "session ids")
+	}
+
+	sessions := make([]models.Session, 0, len(ids))
+	for _, id := range ids {
+		session, _, err := getSession(ctx, c.cache, id)
+		if errors.Is(err, errorsPkg.ErrSessionNotFound) {
+			// the session is expired, its id is left in the user's set
+			if err = c.cache.SRem(ctx, sessionsPrefix+name, id); err != nil {
+				c.logger.Errorf("remove expired session id: %v", err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		session.Secret = ""
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+func (c *core) Revoke(ctx context.Context, name, id string) error {
+	c.logger.Debugln("Revoke", name, id)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	session, _, err := getSession(ctx, c.cache, id)
+	if err != nil {
+		return err
+	}
+	if session.Name != name {
+		return errors.Wrapf(errorsPkg.ErrSessionNotFound, "session: [%s]", id)
+	}
+
+	return c.remove(ctx, session)
+}
+
+func (c *core) RevokeAll(ctx context.Context, name string) error {
+	c.logger.Debugln("Revoke all", name)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	ids, err := c.cache.SMembers(ctx, sessionsPrefix+name)
+	if err != nil {
+		return errors.Wrap(err, "session ids")
+	}
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, sessionPrefix+id)
+	}
+	keys = append(keys, sessionsPrefix+name)
+
+	if err = c.cache.Del(ctx, keys...); err != nil {
+		return errors.Wrap(err, "remove sessions")
+	}
+	return nil
+}
+
+func (c *core) Authorize(ctx context.Context, accessToken string) (token.Claims, error) {
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	claims, err := c.signer.Verify(accessToken)
+	if err != nil {
+		return token.Claims{}, err
+	}
+
+	// access tokens of the revoked sessions are rejected before their expiration
+	exists, err := c.cache.Exists(ctx, sessionPrefix+claims.Session)
+	if err != nil {
+		return token.Claims{}, errors.Wrap(err, "session exists")
+	}
+	if !exists {
+		return token.Claims{}, errors.Wrap(errorsPkg.ErrInvalidToken, "session closed")
+	}
+
+	return claims, nil
+}
+
+func (c *core) issue(session models.Session, secret string) (models.Token, error) {
+	now := time.Now()
+	access, err := c.signer.Sign(token.Claims{
+		Subject:   session.Name,
+		Session:   session.ID,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(c.accessTTL).Unix(),
+	})
+	if err != nil {
+		return models.Token{}, errors.Wrap(err, "sign access token")
+	}
+
+	return models.Token{
+		AccessToken:  access,
+		RefreshToken: session.ID + "." + secret,
+		ExpiresIn:    int64(c.accessTTL.Seconds()),
+		SessionID:    session.ID,
+	}, nil
+}
+
+func (c *core) remove(ctx context.Context, session models.Session) error {
+	if err := c.cache.Del(ctx, sessionPrefix+session.ID); err != nil {
+		return errors.Wrap(err, "remove session")
+	}
+	// the id left after a failure is removed by List
+	if err := c.cache.SRem(ctx, sessionsPrefix+session.Name, session.ID); err != nil {
+		c.logger.Errorf("remove session id: %v", err)
+	}
+	return nil
+}
+
+// getSession returns the session and its stored data.
+func getSession(ctx context.Context, cache cachePkg.Interface, id string) (models.Session, []byte, error) {
+	data, err := cache.Get(ctx, sessionPrefix+id)
+	if errors.Is(err, cachePkg.ErrNotFound) {
+		return models.Session{}, nil, errors.Wrapf(errorsPkg.ErrSessionNotFound, "session: [%s]", id)
+	}
+	if err != nil {
+		return models.Session{}, nil, errors.Wrap(err, "get session")
+	}
+
+	var session models.Session
+	if err = json.Unmarshal(data, &session); err != nil {
+		return models.Session{}, nil, errors.Wrap(err, "unmarshal session")
+	}
+	return session, data, nil
+}
+
+func parseRefreshToken(refreshToken string) (id, secret string, err error) {
+	parts := strings.SplitN(refreshToken, ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", errors.Wrap(errorsPkg.ErrInvalidToken, "malformed refresh token")
+	}
+	return parts[0], parts[1], nil
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, secretLen)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "refresh secret")
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func secretEqual(hash, secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hash), []byte(hashSecret(secret))) == 1
+}
@@ -0,0 +1,96 @@
+package session
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/token"
+	userMockPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/mock"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	memoryPkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache/memory"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+)
+
+func newCore(t *testing.T) (Interface, *userMockPkg.MockInterface) {
+	ctrl := gomock.NewController(t)
+	user := userMockPkg.NewMockInterface(ctrl)
+	signer, err := token.New(token.Config{Algorithm: token.HS256, Secret: strings.Repeat("s", 32)})
+	require.NoError(t, err)
+	cache := memoryPkg.New()
+	t.Cleanup(func() {
+		_ = cache.Close()
+	})
+	return New(user, signer, cache, Config{}, loggerPkg.NewFatal()), user
+}
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name    string
+		userErr error
+		expErr  error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:    "user deleted",
+			userErr: errorsPkg.ErrUserNotFound,
+			expErr:  errorsPkg.ErrInvalidToken,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			session, user := newCore(t)
+			user.EXPECT().CheckPassword(gomock.Any(), "Ivan", "password").Return(nil)
+			user.EXPECT().Get(gomock.Any(), "Ivan", false).Return(models.User{Name: "Ivan"}, c.userErr)
+
+			tok, err := session.Login(ctx, "Ivan", "password")
+			require.NoError(t, err)
+
+			_, err = session.Refresh(ctx, tok.RefreshToken)
+			if c.expErr != nil {
+				assert.ErrorIs(t, err, c.expErr)
+				// the session of the deleted user is closed
+				_, err = session.Authorize(ctx, tok.AccessToken)
+				assert.ErrorIs(t, err, errorsPkg.ErrInvalidToken)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRevokeAll(t *testing.T) {
+	ctx := context.Background()
+	session, user := newCore(t)
+	user.EXPECT().CheckPassword(gomock.Any(), gomock.Any(), "password").Return(nil).Times(3)
+
+	first, err := session.Login(ctx, "Ivan", "password")
+	require.NoError(t, err)
+	second, err := session.Login(ctx, "Ivan", "password")
+	require.NoError(t, err)
+	other, err := session.Login(ctx, "Petr", "password")
+	require.NoError(t, err)
+
+	require.NoError(t, session.RevokeAll(ctx, "Ivan"))
+
+	for _, tok := range []string{first.AccessToken, second.AccessToken} {
+		_, err = session.Authorize(ctx, tok)
+		assert.ErrorIs(t, err, errorsPkg.ErrInvalidToken)
+	}
+	sessions, err := session.List(ctx, "Ivan")
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+
+	_, err = session.Authorize(ctx, other.AccessToken)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,158 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+const (
+	HS256 = "HS256"
+	EdDSA = "EdDSA"
+
+	typeJWT = "JWT"
+)
+
+// Signer issues and verifies access tokens in JWT compact serialization.
+type Signer interface {
+	Sign(claims Claims) (string, error)
+	Verify(token string) (Claims, error)
+}
+
+type Claims struct {
+	Subject   string `json:"sub"`
+	Session   string `json:"sid"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+type Config struct {
+	Algorithm string `mapstructure:"algorithm"`
+	// Secret is an HMAC key for HS256.
+	Secret string `mapstructure:"secret"`
+	// PrivateKey is a base64 encoded ed25519 seed or private key for EdDSA.
+	PrivateKey string `mapstructure:"private_key"`
+}
+
+func New(cfg Config) (Signer, error) {
+	switch cfg.Algorithm {
+	case "", HS256:
+		if len(cfg.Secret) < sha256.Size {
+			return nil, errors.Errorf("HS256 secret must be at least %d bytes long", sha256.Size)
+		}
+		return &signer{
+			alg: HS256,
+			sign: func(data []byte) []byte {
+				mac := hmac.New(sha256.New, []byte(cfg.Secret))
+				mac.Write(data)
+				return mac.Sum(nil)
+			},
+			verify: func(data, sig []byte) bool {
+				mac := hmac.New(sha256.New, []byte(cfg.Secret))
+				mac.Write(data)
+				return hmac.Equal(sig, mac.Sum(nil))
+			},
+		}, nil
+	case EdDSA:
+		key, err := base64.StdEncoding.DecodeString(cfg.PrivateKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode ed25519 private key")
+		}
+		var private ed25519.PrivateKey
+		switch len(key) {
+		case ed25519.SeedSize:
+			private = ed25519.NewKeyFromSeed(key)
+		case ed25519.PrivateKeySize:
+			private = key
+		default:
+			return nil, errors.New("invalid ed25519 private key size")
+		}
+		public := private.Public().(ed25519.PublicKey)
+		return &signer{
+			alg: EdDSA,
+			sign: func(data []byte) []byte {
+				return ed25519.Sign(private, data)
+			},
+			verify: func(data, sig []byte) bool {
+				return ed25519.Verify(public, data, sig)
+			},
+		}, nil
+	default:
+		return nil, errors.Errorf("unknown token algorithm: [%s]", cfg.Algorithm)
+	}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+}
+
+type signer struct {
+	alg    string
+	sign   func(data []byte) []byte
+	verify func(data, sig []byte) bool
+}
+
+func (s *signer) Sign(claims Claims) (string, error) {
+	head, err := json.Marshal(header{Alg: s.alg, Typ: typeJWT})
+	if err != nil {
+		return "", errors.Wrap(err, "marshal header")
+	}
+	body, err := json.Marshal(claims)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal claims")
+	}
+
+	data := encode(head) + "." + encode(body)
+	return data + "." + encode(s.sign([]byte(data))), nil
+}
+
+func (s *signer) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, errors.Wrap(errorsPkg.ErrInvalidToken, "malformed token")
+	}
+
+	var head header
+	if err := decodeJSON(parts[0], &head); err != nil {
+		return Claims{}, errors.Wrap(errorsPkg.ErrInvalidToken, "header")
+	}
+	// the algorithm is fixed by configuration, the header can not change it
+	if head.Alg != s.alg {
+		return Claims{}, errors.Wrap(errorsPkg.ErrInvalidToken, "unexpected algorithm")
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !s.verify([]byte(parts[0]+"."+parts[1]), sig) {
+		return Claims{}, errors.Wrap(errorsPkg.ErrInvalidToken, "signature")
+	}
+
+	var claims Claims
+	if err = decodeJSON(parts[1], &claims); err != nil {
+		return Claims{}, errors.Wrap(errorsPkg.ErrInvalidToken, "claims")
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return Claims{}, errors.Wrap(errorsPkg.ErrInvalidToken, "token expired")
+	}
+	return claims, nil
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeJSON(part string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,99 @@
+package token
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+var (
+	secret = strings.Repeat("s", 32)
+	seed   = base64.StdEncoding.EncodeToString(make([]byte, ed25519.SeedSize))
+	claims = Claims{
+		Subject:  "Ivan",
+		Session:  "session",
+		IssuedAt: time.Now().Unix(),
+	}
+)
+
+func TestSigner_SignVerify(t *testing.T) {
+	cases := []struct {
+		name   string
+		config Config
+	}{
+		{
+			name:   "HS256",
+			config: Config{Algorithm: HS256, Secret: secret},
+		},
+		{
+			name:   "EdDSA",
+			config: Config{Algorithm: EdDSA, PrivateKey: seed},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			signer, err := New(c.config)
+			require.NoError(t, err)
+
+			exp := claims
+			exp.ExpiresAt = time.Now().Add(time.Minute).Unix()
+			token, err := signer.Sign(exp)
+			require.NoError(t, err)
+
+			actual, err := signer.Verify(token)
+			assert.NoError(t, err)
+			assert.Equal(t, exp, actual)
+
+			tampered := token[:len(token)-2] + "AA"
+			_, err = signer.Verify(tampered)
+			assert.ErrorIs(t, err, errorsPkg.ErrInvalidToken)
+		})
+	}
+}
+
+func TestSigner_VerifyExpired(t *testing.T) {
+	signer, err := New(Config{Secret: secret})
+	require.NoError(t, err)
+
+	exp := claims
+	exp.ExpiresAt = time.Now().Add(-time.Second).Unix()
+	token, err := signer.Sign(exp)
+	require.NoError(t, err)
+
+	_, err = signer.Verify(token)
+	assert.ErrorIs(t, err, errorsPkg.ErrInvalidToken)
+}
+
+func TestSigner_VerifyOtherAlgorithm(t *testing.T) {
+	hs, err := New(Config{Algorithm: HS256, Secret: secret})
+	require.NoError(t, err)
+	ed, err := New(Config{Algorithm: EdDSA, PrivateKey: seed})
+	require.NoError(t, err)
+
+	exp := claims
+	exp.ExpiresAt = time.Now().Add(time.Minute).Unix()
+	token, err := ed.Sign(exp)
+	require.NoError(t, err)
+
+	_, err = hs.Verify(token)
+	assert.ErrorIs(t, err, errorsPkg.ErrInvalidToken)
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(Config{Algorithm: HS256, Secret: "short"})
+	assert.Error(t, err)
+
+	_, err = New(Config{Algorithm: EdDSA, PrivateKey: "AAAA"})
+	assert.Error(t, err)
+
+	_, err = New(Config{Algorithm: "none"})
+	assert.Error(t, err)
+}
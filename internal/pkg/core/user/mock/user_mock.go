@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
@@ -35,6 +36,20 @@ func (m *MockInterface) EXPECT() *MockInterfaceMockRecorder {
 	return m.recorder
 }
 
+// CheckPassword mocks base method.
+func (m *MockInterface) CheckPassword(ctx context.Context, name, password string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckPassword", ctx, name, password)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckPassword indicates an expected call of CheckPassword.
+func (mr *MockInterfaceMockRecorder) CheckPassword(ctx, name, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckPassword", reflect.TypeOf((*MockInterface)(nil).CheckPassword), ctx, name, password)
+}
+
 // Create mocks base method.
 func (m *MockInterface) Create(ctx context.Context, user models.User) error {
 	m.ctrl.T.Helper()
@@ -49,6 +64,21 @@ func (mr *MockInterfaceMockRecorder) Create(ctx, user interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInterface)(nil).Create), ctx, user)
 }
 
+// CreateBatch mocks base method.
+func (m *MockInterface) CreateBatch(ctx context.Context, users []models.User) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBatch", ctx, users)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBatch indicates an expected call of CreateBatch.
+func (mr *MockInterfaceMockRecorder) CreateBatch(ctx, users interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBatch", reflect.TypeOf((*MockInterface)(nil).CreateBatch), ctx, users)
+}
+
 // Data mocks base method.
 func (m *MockInterface) Data(ctx context.Context, uid string) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -65,59 +95,133 @@ func (mr *MockInterfaceMockRecorder) Data(ctx, uid interface{}) *gomock.Call {
 }
 
 // Delete mocks base method.
-func (m *MockInterface) Delete(ctx context.Context, name string) error {
+func (m *MockInterface) Delete(ctx context.Context, name string, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, name)
+	ret := m.ctrl.Call(m, "Delete", ctx, name, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockInterfaceMockRecorder) Delete(ctx, name interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) Delete(ctx, name, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInterface)(nil).Delete), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInterface)(nil).Delete), ctx, name, version)
 }
 
 // Get mocks base method.
-func (m *MockInterface) Get(ctx context.Context, name string) (models.User, error) {
+func (m *MockInterface) Get(ctx context.Context, name string, withDeleted bool) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", ctx, name)
+	ret := m.ctrl.Call(m, "Get", ctx, name, withDeleted)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockInterfaceMockRecorder) Get(ctx, name interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) Get(ctx, name, withDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInterface)(nil).Get), ctx, name, withDeleted)
+}
+
+// GetAsOf mocks base method.
+func (m *MockInterface) GetAsOf(ctx context.Context, name string, at time.Time) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAsOf", ctx, name, at)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAsOf indicates an expected call of GetAsOf.
+func (mr *MockInterfaceMockRecorder) GetAsOf(ctx, name, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAsOf", reflect.TypeOf((*MockInterface)(nil).GetAsOf), ctx, name, at)
+}
+
+// GetByEmail mocks base method.
+func (m *MockInterface) GetByEmail(ctx context.Context, email string) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", ctx, email)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockInterfaceMockRecorder) GetByEmail(ctx, email interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockInterface)(nil).Get), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockInterface)(nil).GetByEmail), ctx, email)
+}
+
+// History mocks base method.
+func (m *MockInterface) History(ctx context.Context, name string) ([]models.UserChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "History", ctx, name)
+	ret0, _ := ret[0].([]models.UserChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// History indicates an expected call of History.
+func (mr *MockInterfaceMockRecorder) History(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "History", reflect.TypeOf((*MockInterface)(nil).History), ctx, name)
 }
 
 // List mocks base method.
-func (m *MockInterface) List(ctx context.Context, order bool, limit, offset uint64) ([]models.User, error) {
+func (m *MockInterface) List(ctx context.Context, params models.UserListParams) (models.UserListPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx, order, limit, offset)
-	ret0, _ := ret[0].([]models.User)
+	ret := m.ctrl.Call(m, "List", ctx, params)
+	ret0, _ := ret[0].(models.UserListPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockInterfaceMockRecorder) List(ctx, order, limit, offset interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) List(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockInterface)(nil).List), ctx, params)
+}
+
+// Purge mocks base method.
+func (m *MockInterface) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, retention)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockInterfaceMockRecorder) Purge(ctx, retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockInterface)(nil).Purge), ctx, retention)
+}
+
+// Restore mocks base method.
+func (m *MockInterface) Restore(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockInterfaceMockRecorder) Restore(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockInterface)(nil).List), ctx, order, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockInterface)(nil).Restore), ctx, name)
 }
 
 // Update mocks base method.
-func (m *MockInterface) Update(ctx context.Context, user models.User) error {
+func (m *MockInterface) Update(ctx context.Context, profile models.Profile) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", ctx, user)
+	ret := m.ctrl.Call(m, "Update", ctx, profile)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockInterfaceMockRecorder) Update(ctx, user interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) Update(ctx, profile interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInterface)(nil).Update), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockInterface)(nil).Update), ctx, profile)
 }
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Operations of the user history.
+const (
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+	OperationRestore = "restore"
+)
+
+// UserChange is the record of the user history, the passwords are not recorded.
+// Old is nil for the created user, RequestID is the uid of the request which made the change.
+type UserChange struct {
+	Name      string    `json:"name"`
+	Operation string    `json:"operation"`
+	Old       *User     `json:"old,omitempty"`
+	New       User      `json:"new"`
+	RequestID string    `json:"request_id,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// NewUserChange returns the change of the user made at the update time of the new user.
+func NewUserChange(operation string, old *User, user User, requestID string) UserChange {
+	if old != nil {
+		prev := *old
+		prev.Password = ""
+		old = &prev
+	}
+	user.Password = ""
+	return UserChange{
+		Name:      user.Name,
+		Operation: operation,
+		Old:       old,
+		New:       user,
+		RequestID: requestID,
+		ChangedAt: user.UpdatedAt,
+	}
+}
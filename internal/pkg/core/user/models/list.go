@@ -0,0 +1,144 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+// Sort fields of the users list.
+const (
+	SortName      = "name"
+	SortCreatedAt = "created_at"
+	SortEmail     = "email"
+	SortFullName  = "full_name"
+)
+
+// ParseOrderBy parses the comma separated sort fields, like "created_at desc, email".
+func ParseOrderBy(orderBy string) ([]UserSort, error) {
+	sorting := make([]UserSort, 0)
+	if strings.TrimSpace(orderBy) == "" {
+		return sorting, nil
+	}
+	for _, part := range strings.Split(orderBy, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, errors.Wrapf(errorsPkg.ErrValidation, "order by: [%s]", part)
+		}
+		sort := UserSort{Field: fields[0]}
+		if len(fields) == 2 {
+			switch strings.ToLower(fields[1]) {
+			case "asc":
+			case "desc":
+				sort.Desc = true
+			default:
+				return nil, errors.Wrapf(errorsPkg.ErrValidation, "order by direction: [%s]", fields[1])
+			}
+		}
+		sorting = append(sorting, sort)
+	}
+	return sorting, nil
+}
+
+// Validate checks the sort fields and the filter.
+func (p *UserListParams) Validate() error {
+	seen := make(map[string]bool, len(p.Sort))
+	for _, sort := range p.Sort {
+		switch sort.Field {
+		case SortName, SortCreatedAt, SortEmail, SortFullName:
+		default:
+			return errors.Wrapf(errorsPkg.ErrValidation, "unknown sort field: [%s]", sort.Field)
+		}
+		if seen[sort.Field] {
+			return errors.Wrapf(errorsPkg.ErrValidation, "repeated sort field: [%s]", sort.Field)
+		}
+		seen[sort.Field] = true
+	}
+	if p.Filter.CreatedTo != 0 && p.Filter.CreatedFrom > p.Filter.CreatedTo {
+		return errors.Wrap(errorsPkg.ErrValidation, "created_from is after created_to")
+	}
+	return nil
+}
+
+// Sorting returns the sort fields ending with the name, so the order of users is total.
+func (p *UserListParams) Sorting() []UserSort {
+	sorting := make([]UserSort, 0, len(p.Sort)+1)
+	for _, sort := range p.Sort {
+		if sort.Field == SortName {
+			return append(sorting, sort)
+		}
+		sorting = append(sorting, sort)
+	}
+	return append(sorting, UserSort{Field: SortName, Desc: p.Order})
+}
+
+// Compare returns a negative number if a goes before b in the list, zero if they are equal.
+func (p *UserListParams) Compare(a, b User) int {
+	for _, sort := range p.Sorting() {
+		var res int
+		switch sort.Field {
+		case SortCreatedAt:
+			res = compareTime(a.CreatedAt, b.CreatedAt)
+		case SortEmail:
+			res = strings.Compare(a.Email, b.Email)
+		case SortFullName:
+			res = strings.Compare(a.FullName, b.FullName)
+		default:
+			res = strings.Compare(a.Name, b.Name)
+		}
+		if sort.Desc {
+			res = -res
+		}
+		if res != 0 {
+			return res
+		}
+	}
+	return 0
+}
+
+// Match returns true if the user passes the filter.
+func (f *UserFilter) Match(user User) bool {
+	if f.EmailDomain != "" && !strings.HasSuffix(strings.ToLower(user.Email), "@"+strings.ToLower(f.EmailDomain)) {
+		return false
+	}
+	if f.FullName != "" && !strings.Contains(strings.ToLower(user.FullName), strings.ToLower(f.FullName)) {
+		return false
+	}
+	if f.CreatedFrom != 0 && user.CreatedAt.Before(time.Unix(f.CreatedFrom, 0)) {
+		return false
+	}
+	// the bound is inclusive up to the end of the second
+	if f.CreatedTo != 0 && !user.CreatedAt.Before(time.Unix(f.CreatedTo+1, 0)) {
+		return false
+	}
+	return true
+}
+
+// Query identifies the listing of the params, pages of one query share it.
+func (p *UserListParams) Query() string {
+	data, _ := json.Marshal(struct {
+		Order       bool
+		WithDeleted bool
+		Sort        []UserSort `json:",omitempty"`
+		Filter      UserFilter
+	}{p.Order, p.WithDeleted, p.Sort, p.Filter})
+	h := fnv.New64a()
+	_, _ = h.Write(data)
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+func compareTime(a, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
@@ -3,25 +3,162 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 )
 
 type User struct {
-	Name      string `json:"name" db:"name"`
-	Password  string `json:"password" db:"password"`
-	Email     string `json:"email" db:"email"`
-	FullName  string `json:"full_name" db:"full_name"`
-	CreatedAt int64  `json:"created_at" db:"created_at"`
+	Name      string    `json:"name" db:"name"`
+	Password  string    `json:"password,omitempty" db:"password"`
+	Email     string    `json:"email" db:"email"`
+	FullName  string    `json:"full_name" db:"full_name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	Version   uint64    `json:"version" db:"version"`
+	DeletedAt int64     `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
 func (u *User) String() string {
-	return fmt.Sprintf("name: [%s], full_name: [%s], email: [%s], created_at: [%v]",
-		u.Name, u.FullName, u.Email, time.Unix(u.CreatedAt, 0))
+	s := fmt.Sprintf("name: [%s], full_name: [%s], email: [%s], created_at: [%v], updated_at: [%v], version: [%d]",
+		u.Name, u.FullName, u.Email, u.CreatedAt, u.UpdatedAt, u.Version)
+	if u.Deleted() {
+		s += fmt.Sprintf(", deleted_at: [%v]", time.Unix(u.DeletedAt, 0))
+	}
+	return s
 }
 
+// Now returns the current time in the precision of the stored timestamps.
+func Now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+// Deleted returns true for the soft deleted user.
+func (u *User) Deleted() bool {
+	return u.DeletedAt != 0
+}
+
+// Profile is a partial user update, only not nil fields are changed.
+// Not zero Version is the expected version of the user record.
+type Profile struct {
+	Name     string  `json:"name"`
+	Password *string `json:"password,omitempty"`
+	Email    *string `json:"email,omitempty"`
+	FullName *string `json:"full_name,omitempty"`
+	Version  uint64  `json:"version,omitempty"`
+}
+
+func (p *Profile) String() string {
+	fields := make([]string, 0, 3)
+	if p.Password != nil {
+		fields = append(fields, "password")
+	}
+	if p.Email != nil {
+		fields = append(fields, fmt.Sprintf("email: [%s]", *p.Email))
+	}
+	if p.FullName != nil {
+		fields = append(fields, fmt.Sprintf("full_name: [%s]", *p.FullName))
+	}
+	return fmt.Sprintf("name: [%s], set: {%s}, version: [%d]", p.Name, strings.Join(fields, ", "), p.Version)
+}
+
+// Empty returns true if there are no fields to update.
+func (p *Profile) Empty() bool {
+	return p.Password == nil && p.Email == nil && p.FullName == nil
+}
+
+// Apply sets the profile fields to the user.
+func (p *Profile) Apply(user User) User {
+	if p.Password != nil {
+		user.Password = *p.Password
+	}
+	if p.Email != nil {
+		user.Email = *p.Email
+	}
+	if p.FullName != nil {
+		user.FullName = *p.FullName
+	}
+	return user
+}
+
+// UserListParams is the list message payload.
+// Offset is the page number, it is used only without PageToken.
+// Order is the direction of the name sort, Sort fields are applied before the name.
+// After is the last user of the previous page, its sort fields are decoded from PageToken.
 type UserListParams struct {
-	Limit  uint64 `json:"limit"`
-	Offset uint64 `json:"offset"`
-	Order  bool   `json:"order"`
+	Limit       uint64     `json:"limit"`
+	Offset      uint64     `json:"offset"`
+	Order       bool       `json:"order"`
+	WithDeleted bool       `json:"with_deleted,omitempty"`
+	PageToken   string     `json:"page_token,omitempty"`
+	Sort        []UserSort `json:"sort,omitempty"`
+	Filter      UserFilter `json:"filter,omitempty"`
+	After       *User      `json:"-"`
+}
+
+// UserSort is the list sort field.
+type UserSort struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// UserFilter narrows the list, zero fields are not applied.
+// FullName is a case-insensitive substring, created_at range bounds are inclusive.
+type UserFilter struct {
+	EmailDomain string `json:"email_domain,omitempty"`
+	FullName    string `json:"full_name,omitempty"`
+	CreatedFrom int64  `json:"created_from,omitempty"`
+	CreatedTo   int64  `json:"created_to,omitempty"`
+}
+
+// UserListPage is the list message result, empty NextPageToken means the last page.
+type UserListPage struct {
+	Users         []User `json:"users"`
+	NextPageToken string `json:"next_page_token,omitempty"`
+}
+
+// UserBatch is the batch create message payload.
+// Errors are the validation errors of the users by index, the users with an error are not created.
+type UserBatch struct {
+	Users  []User   `json:"users"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// UserBatchResult is the outcome of the batch user, empty Error means the user is created.
+type UserBatchResult struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+// UserGetParams is the get message payload.
+type UserGetParams struct {
+	Name        string `json:"name"`
+	WithDeleted bool   `json:"with_deleted,omitempty"`
+}
+
+// UserDeleteParams is the delete message payload, zero Version skips the version check.
+type UserDeleteParams struct {
+	Name    string `json:"name"`
+	Version uint64 `json:"version,omitempty"`
+}
+
+// UnmarshalUserDeleteParams decodes the delete message payload,
+// the raw user name of the previous message format is accepted too.
+func UnmarshalUserDeleteParams(data []byte) *UserDeleteParams {
+	params := NewUserDeleteParams()
+	if err := json.Unmarshal(data, params); err != nil {
+		return params.NameSet(string(data))
+	}
+	return params
+}
+
+// UnmarshalUserGetParams decodes the get message payload,
+// the raw user name of the previous message format is accepted too.
+func UnmarshalUserGetParams(data []byte) *UserGetParams {
+	params := NewUserGetParams()
+	if err := json.Unmarshal(data, params); err != nil {
+		return params.NameSet(string(data))
+	}
+	return params
 }
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// OutboxMessage is the message about the user change published by the outbox relay.
+// RequestID is the uid of the request making the change, the request is processed once.
+type OutboxMessage struct {
+	ID        uint64            `json:"id"`
+	Topic     string            `json:"topic"`
+	Key       string            `json:"key"`
+	Value     []byte            `json:"value,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// OutboxFunc returns the message about the change, it is written to the outbox with the change.
+// Results are the errors of the batch users, nil for the change of one user.
+type OutboxFunc func(results []error) (OutboxMessage, error)
+
+type outboxKey struct{}
+
+// WithOutbox returns the context of the change which message is written to the outbox by the repository.
+func WithOutbox(ctx context.Context, fn OutboxFunc) context.Context {
+	return context.WithValue(ctx, outboxKey{}, fn)
+}
+
+// OutboxFromCtx returns the message builder of the change, nil if the change has no message.
+func OutboxFromCtx(ctx context.Context) OutboxFunc {
+	fn, _ := ctx.Value(outboxKey{}).(OutboxFunc)
+	return fn
+}
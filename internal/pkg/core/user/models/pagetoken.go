@@ -0,0 +1,77 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+// PageToken holds the sort fields of the last user of the page and the query it belongs to.
+type PageToken struct {
+	Name      string     `json:"n"`
+	Email     string     `json:"e,omitempty"`
+	FullName  string     `json:"f,omitempty"`
+	CreatedAt *time.Time `json:"c,omitempty"`
+	Query     string     `json:"q"`
+}
+
+// NewPageToken returns the token of the page which ends with the user.
+func NewPageToken(params UserListParams, last User) PageToken {
+	token := PageToken{
+		Name:  last.Name,
+		Query: params.Query(),
+	}
+	for _, sort := range params.Sorting() {
+		switch sort.Field {
+		case SortCreatedAt:
+			createdAt := last.CreatedAt
+			token.CreatedAt = &createdAt
+		case SortEmail:
+			token.Email = last.Email
+		case SortFullName:
+			token.FullName = last.FullName
+		}
+	}
+	return token
+}
+
+// Encode returns the opaque token string.
+func (t PageToken) Encode() string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Match returns true if the token is issued for the same query.
+func (t PageToken) Match(params UserListParams) bool {
+	return t.Query == params.Query()
+}
+
+// User returns the sort fields of the last user of the page.
+func (t PageToken) User() User {
+	user := User{
+		Name:     t.Name,
+		Email:    t.Email,
+		FullName: t.FullName,
+	}
+	if t.CreatedAt != nil {
+		user.CreatedAt = *t.CreatedAt
+	}
+	return user
+}
+
+// DecodePageToken parses the opaque token string.
+func DecodePageToken(token string) (PageToken, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return PageToken{}, errors.Wrap(errorsPkg.ErrInvalidPageToken, "decode")
+	}
+	var res PageToken
+	if err = json.Unmarshal(data, &res); err != nil || res.Name == "" {
+		return PageToken{}, errors.Wrap(errorsPkg.ErrInvalidPageToken, "unmarshal")
+	}
+	return res, nil
+}
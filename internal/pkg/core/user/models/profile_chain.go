@@ -0,0 +1,32 @@
+// Code generated by chaingen. DO NOT EDIT.
+
+package models
+
+func NewProfile() *Profile {
+	return &Profile{}
+}
+
+func (p *Profile) NameSet(Name string) *Profile {
+	p.Name = Name
+	return p
+}
+
+func (p *Profile) PasswordSet(Password *string) *Profile {
+	p.Password = Password
+	return p
+}
+
+func (p *Profile) EmailSet(Email *string) *Profile {
+	p.Email = Email
+	return p
+}
+
+func (p *Profile) FullNameSet(FullName *string) *Profile {
+	p.FullName = FullName
+	return p
+}
+
+func (p *Profile) VersionSet(Version uint64) *Profile {
+	p.Version = Version
+	return p
+}
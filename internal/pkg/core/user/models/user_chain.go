@@ -2,6 +2,10 @@
 
 package models
 
+import (
+	"time"
+)
+
 func NewUser() *User {
 	return &User{}
 }
@@ -26,7 +30,22 @@ func (u *User) FullNameSet(FullName string) *User {
 	return u
 }
 
-func (u *User) CreatedAtSet(CreatedAt int64) *User {
+func (u *User) CreatedAtSet(CreatedAt time.Time) *User {
 	u.CreatedAt = CreatedAt
 	return u
 }
+
+func (u *User) UpdatedAtSet(UpdatedAt time.Time) *User {
+	u.UpdatedAt = UpdatedAt
+	return u
+}
+
+func (u *User) VersionSet(Version uint64) *User {
+	u.Version = Version
+	return u
+}
+
+func (u *User) DeletedAtSet(DeletedAt int64) *User {
+	u.DeletedAt = DeletedAt
+	return u
+}
@@ -0,0 +1,17 @@
+// Code generated by chaingen. DO NOT EDIT.
+
+package models
+
+func NewUserBatch() *UserBatch {
+	return &UserBatch{}
+}
+
+func (u *UserBatch) UsersSet(Users []User) *UserBatch {
+	u.Users = Users
+	return u
+}
+
+func (u *UserBatch) ErrorsSet(Errors []string) *UserBatch {
+	u.Errors = Errors
+	return u
+}
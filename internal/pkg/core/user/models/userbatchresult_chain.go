@@ -0,0 +1,17 @@
+// Code generated by chaingen. DO NOT EDIT.
+
+package models
+
+func NewUserBatchResult() *UserBatchResult {
+	return &UserBatchResult{}
+}
+
+func (u *UserBatchResult) NameSet(Name string) *UserBatchResult {
+	u.Name = Name
+	return u
+}
+
+func (u *UserBatchResult) ErrorSet(Error string) *UserBatchResult {
+	u.Error = Error
+	return u
+}
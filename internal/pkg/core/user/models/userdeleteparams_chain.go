@@ -0,0 +1,17 @@
+// Code generated by chaingen. DO NOT EDIT.
+
+package models
+
+func NewUserDeleteParams() *UserDeleteParams {
+	return &UserDeleteParams{}
+}
+
+func (u *UserDeleteParams) NameSet(Name string) *UserDeleteParams {
+	u.Name = Name
+	return u
+}
+
+func (u *UserDeleteParams) VersionSet(Version uint64) *UserDeleteParams {
+	u.Version = Version
+	return u
+}
@@ -0,0 +1,27 @@
+// Code generated by chaingen. DO NOT EDIT.
+
+package models
+
+func NewUserFilter() *UserFilter {
+	return &UserFilter{}
+}
+
+func (u *UserFilter) EmailDomainSet(EmailDomain string) *UserFilter {
+	u.EmailDomain = EmailDomain
+	return u
+}
+
+func (u *UserFilter) FullNameSet(FullName string) *UserFilter {
+	u.FullName = FullName
+	return u
+}
+
+func (u *UserFilter) CreatedFromSet(CreatedFrom int64) *UserFilter {
+	u.CreatedFrom = CreatedFrom
+	return u
+}
+
+func (u *UserFilter) CreatedToSet(CreatedTo int64) *UserFilter {
+	u.CreatedTo = CreatedTo
+	return u
+}
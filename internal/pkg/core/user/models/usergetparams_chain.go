@@ -0,0 +1,17 @@
+// Code generated by chaingen. DO NOT EDIT.
+
+package models
+
+func NewUserGetParams() *UserGetParams {
+	return &UserGetParams{}
+}
+
+func (u *UserGetParams) NameSet(Name string) *UserGetParams {
+	u.Name = Name
+	return u
+}
+
+func (u *UserGetParams) WithDeletedSet(WithDeleted bool) *UserGetParams {
+	u.WithDeleted = WithDeleted
+	return u
+}
@@ -0,0 +1,17 @@
+// Code generated by chaingen. DO NOT EDIT.
+
+package models
+
+func NewUserListPage() *UserListPage {
+	return &UserListPage{}
+}
+
+func (u *UserListPage) UsersSet(Users []User) *UserListPage {
+	u.Users = Users
+	return u
+}
+
+func (u *UserListPage) NextPageTokenSet(NextPageToken string) *UserListPage {
+	u.NextPageToken = NextPageToken
+	return u
+}
@@ -20,3 +20,28 @@ func (u *UserListParams) OrderSet(Order bool) *UserListParams {
 	u.Order = Order
 	return u
 }
+
+func (u *UserListParams) WithDeletedSet(WithDeleted bool) *UserListParams {
+	u.WithDeleted = WithDeleted
+	return u
+}
+
+func (u *UserListParams) PageTokenSet(PageToken string) *UserListParams {
+	u.PageToken = PageToken
+	return u
+}
+
+func (u *UserListParams) SortSet(Sort []UserSort) *UserListParams {
+	u.Sort = Sort
+	return u
+}
+
+func (u *UserListParams) FilterSet(Filter UserFilter) *UserListParams {
+	u.Filter = Filter
+	return u
+}
+
+func (u *UserListParams) AfterSet(After *User) *UserListParams {
+	u.After = After
+	return u
+}
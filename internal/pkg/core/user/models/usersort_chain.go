@@ -0,0 +1,17 @@
+// Code generated by chaingen. DO NOT EDIT.
+
+package models
+
+func NewUserSort() *UserSort {
+	return &UserSort{}
+}
+
+func (u *UserSort) FieldSet(Field string) *UserSort {
+	u.Field = Field
+	return u
+}
+
+func (u *UserSort) DescSet(Desc bool) *UserSort {
+	u.Desc = Desc
+	return u
+}
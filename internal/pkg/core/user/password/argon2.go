@@ -0,0 +1,102 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+const (
+	argon2idPrefix = "$argon2id$"
+
+	defaultTime    = 3
+	defaultMemory  = 64 * 1024
+	defaultThreads = 2
+
+	saltLen = 16
+	keyLen  = 32
+)
+
+func newArgon2id(cfg Config) Hasher {
+	a := &argon2idHasher{
+		time:    cfg.Time,
+		memory:  cfg.Memory,
+		threads: cfg.Threads,
+	}
+	if a.time == 0 {
+		a.time = defaultTime
+	}
+	if a.memory == 0 {
+		a.memory = defaultMemory
+	}
+	if a.threads == 0 {
+		a.threads = defaultThreads
+	}
+	return a
+}
+
+type argon2idHasher struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+// Hash returns the hash in PHC string format:
+// $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<key>
+func (a *argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", errors.Wrap(err, "argon2id salt")
+	}
+	key := argon2.IDKey([]byte(password), salt, a.time, a.memory, a.threads, keyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version,
+		a.memory, a.time, a.threads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+func (*argon2idHasher) Compare(hash, password string) error {
+	return compare(hash, password)
+}
+
+func compareArgon2id(hash, password string) error {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return errors.Wrap(errorsPkg.ErrPasswordMismatch, "invalid argon2id hash")
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return errors.Wrap(errorsPkg.ErrPasswordMismatch, "unsupported argon2id version")
+	}
+	var (
+		memory, time uint32
+		threads      uint8
+	)
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return errors.Wrap(errorsPkg.ErrPasswordMismatch, "invalid argon2id parameters")
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return errors.Wrap(errorsPkg.ErrPasswordMismatch, "invalid argon2id salt")
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return errors.Wrap(errorsPkg.ErrPasswordMismatch, "invalid argon2id key")
+	}
+
+	actual := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, actual) != 1 {
+		return errorsPkg.ErrPasswordMismatch
+	}
+	return nil
+}
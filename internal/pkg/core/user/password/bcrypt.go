@@ -0,0 +1,48 @@
+package password
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+func newBcrypt(cfg Config) (Hasher, error) {
+	cost := cfg.Cost
+	if cost == 0 {
+		cost = bcrypt.DefaultCost
+	}
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, errors.Errorf("bcrypt cost [%d] is out of range", cost)
+	}
+	return &bcryptHasher{
+		cost: cost,
+	}, nil
+}
+
+type bcryptHasher struct {
+	cost int
+}
+
+func (b *bcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+	if err != nil {
+		return "", errors.Wrap(err, "bcrypt generate")
+	}
+	return string(hash), nil
+}
+
+func (*bcryptHasher) Compare(hash, password string) error {
+	return compare(hash, password)
+}
+
+func compareBcrypt(hash, password string) error {
+	err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return errorsPkg.ErrPasswordMismatch
+	}
+	if err != nil {
+		return errors.Wrap(err, "bcrypt compare")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package password
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+const (
+	Bcrypt   = "bcrypt"
+	Argon2id = "argon2id"
+)
+
+// Hasher makes one-way password hashes. Every hash is self-describing: the algorithm
+// and its parameters are encoded alongside the salt, so the hashes made with other
+// algorithms or parameters still can be compared after the configuration change.
+type Hasher interface {
+	Hash(password string) (string, error)
+	Compare(hash, password string) error
+}
+
+type Config struct {
+	Algorithm string `mapstructure:"algorithm"`
+	// Cost is a bcrypt cost factor.
+	Cost int `mapstructure:"cost"`
+	// Time, Memory (KiB) and Threads are argon2id parameters.
+	Time    uint32 `mapstructure:"time"`
+	Memory  uint32 `mapstructure:"memory"`
+	Threads uint8  `mapstructure:"threads"`
+}
+
+func New(cfg Config) (Hasher, error) {
+	switch cfg.Algorithm {
+	case "", Argon2id:
+		return newArgon2id(cfg), nil
+	case Bcrypt:
+		return newBcrypt(cfg)
+	default:
+		return nil, errors.Errorf("unknown hash algorithm: [%s]", cfg.Algorithm)
+	}
+}
+
+// compare finds out the hash algorithm by its prefix and compares hash with password.
+func compare(hash, password string) error {
+	switch {
+	case strings.HasPrefix(hash, argon2idPrefix):
+		return compareArgon2id(hash, password)
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		return compareBcrypt(hash, password)
+	default:
+		return errors.Wrap(errorsPkg.ErrPasswordMismatch, "unknown hash format")
+	}
+}
@@ -0,0 +1,70 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+func TestHasher_HashCompare(t *testing.T) {
+	cases := []struct {
+		name   string
+		config Config
+		prefix string
+	}{
+		{
+			name:   "argon2id",
+			config: Config{Algorithm: Argon2id, Time: 1, Memory: 1024, Threads: 1},
+			prefix: "$argon2id$v=19$m=1024,t=1,p=1$",
+		},
+		{
+			name:   "bcrypt",
+			config: Config{Algorithm: Bcrypt, Cost: 4},
+			prefix: "$2a$04$",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			hasher, err := New(c.config)
+			require.NoError(t, err)
+
+			hash, err := hasher.Hash("secret")
+			require.NoError(t, err)
+
+			assert.True(t, strings.HasPrefix(hash, c.prefix), hash)
+			assert.NotContains(t, hash, "secret")
+			assert.NoError(t, hasher.Compare(hash, "secret"))
+			assert.ErrorIs(t, hasher.Compare(hash, "wrong"), errorsPkg.ErrPasswordMismatch)
+		})
+	}
+}
+
+func TestHasher_CompareOtherAlgorithm(t *testing.T) {
+	argon, err := New(Config{Algorithm: Argon2id, Time: 1, Memory: 1024, Threads: 1})
+	require.NoError(t, err)
+	bcryptHasher, err := New(Config{Algorithm: Bcrypt, Cost: 4})
+	require.NoError(t, err)
+
+	hash, err := bcryptHasher.Hash("secret")
+	require.NoError(t, err)
+
+	assert.NoError(t, argon.Compare(hash, "secret"))
+}
+
+func TestHasher_CompareInvalidHash(t *testing.T) {
+	hasher, err := New(Config{})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, hasher.Compare("secret", "secret"), errorsPkg.ErrPasswordMismatch)
+	assert.ErrorIs(t, hasher.Compare("$argon2id$v=19$broken", "secret"), errorsPkg.ErrPasswordMismatch)
+}
+
+func TestNew_UnknownAlgorithm(t *testing.T) {
+	_, err := New(Config{Algorithm: "md5"})
+	assert.Error(t, err)
+}
@@ -6,166 +6,576 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math/rand"
+	"sync"
 	"time"
 
-	"github.com/go-redis/redis/v8"
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
 
 	"gitlab.ozon.dev/iTukaev/homework/internal/counter"
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/password"
 	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
 )
 
 const (
 	ctxTimeout     = 5 * time.Second
 	expirationTime = 1 * time.Minute
+
+	// notFoundValue is cached for absent users, so repeated lookups do not reach the repository.
+	notFoundValue          = "not_found"
+	notFoundExpirationTime = 10 * time.Second
+
+	// listGenerationKey is incremented by every change of users,
+	// list pages are cached under the keys of the current generation.
+	listGenerationKey = "users_list_generation"
+
+	// dummyPassword is hashed once to be compared for the unknown users.
+	dummyPassword = "dummy password"
 )
 
+// PurgeConfig sets how long deleted users are kept and how often they are purged.
+type PurgeConfig struct {
+	Retention time.Duration `mapstructure:"retention"`
+	Interval  time.Duration `mapstructure:"interval"`
+}
+
 type Interface interface {
 	Create(ctx context.Context, user models.User) error
-	Update(ctx context.Context, user models.User) error
-	Delete(ctx context.Context, name string) error
-	Get(ctx context.Context, name string) (models.User, error)
-	List(ctx context.Context, order bool, limit, offset uint64) ([]models.User, error)
+	CreateBatch(ctx context.Context, users []models.User) ([]error, error)
+	Update(ctx context.Context, profile models.Profile) error
+	Delete(ctx context.Context, name string, version uint64) error
+	Restore(ctx context.Context, name string) error
+	Purge(ctx context.Context, retention time.Duration) (int64, error)
+	Get(ctx context.Context, name string, withDeleted bool) (models.User, error)
+	GetByEmail(ctx context.Context, email string) (models.User, error)
+	List(ctx context.Context, params models.UserListParams) (models.UserListPage, error)
+	History(ctx context.Context, name string) ([]models.UserChange, error)
+	GetAsOf(ctx context.Context, name string, at time.Time) (models.User, error)
 	Data(ctx context.Context, uid string) ([]byte, error)
+	CheckPassword(ctx context.Context, name, password string) error
 }
 
-func New(data repoPkg.Interface, logger *zap.SugaredLogger, client *redis.Client) Interface {
+func New(data repoPkg.Interface, logger *zap.SugaredLogger, cache cachePkg.Interface, hasher password.Hasher) Interface {
 	return &core{
 		data:   data,
 		logger: logger,
-		cache:  client,
+		cache:  cache,
+		hasher: hasher,
 	}
 }
 
 type core struct {
 	data   repoPkg.Interface
 	logger *zap.SugaredLogger
-	cache  *redis.Client
+	cache  cachePkg.Interface
+	hasher password.Hasher
+	// group coalesces concurrent repository reads of the same user
+	group singleflight.Group
+	// dummyHash is compared for the unknown users, so checking them takes as long as checking the known ones
+	dummyOnce sync.Once
+	dummyHash string
 }
 
 func (c *core) Create(ctx context.Context, user models.User) error {
-	c.logger.Debugln("Create", user)
+	c.logger.Debugln("Create", user.String())
 	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
 	defer cancel()
 
-	if _, err := c.cache.Get(ctx, user.Name).Bytes(); err == nil {
+	if err := c.checkRequest(ctx); err != nil {
+		return err
+	}
+	if data, err := c.cache.Get(ctx, user.Name); err == nil && string(data) != notFoundValue {
 		counter.Hit.Inc()
 		return errorsPkg.ErrUserAlreadyExists
 	}
 
-	if _, err := c.data.UserGet(ctx, user.Name); err == nil {
+	// the name of the deleted user is reserved until the user is purged
+	if _, err := c.data.UserGet(ctx, user.Name, true); err == nil {
 		counter.Miss.Inc()
 		return errorsPkg.ErrUserAlreadyExists
 	} else if !errors.Is(err, errorsPkg.ErrUserNotFound) {
 		return err
 	}
-	if err := c.data.UserCreate(ctx, user); err != nil {
+	if err := c.checkEmail(ctx, user.Email, user.Name); err != nil {
 		return err
 	}
 
+	hash, err := c.hasher.Hash(user.Password)
+	if err != nil {
+		return errors.Wrap(err, "password hash")
+	}
+	user.Password = hash
+	if err = c.data.UserCreate(ctx, user); err != nil {
+		return err
+	}
+	c.invalidateLists(ctx)
+
+	if err = c.cache.Del(ctx, user.Name); err != nil {
+		c.logger.Errorf("remove not found mark from cache: %v", err)
+	}
+
 	return nil
 }
 
-func (c *core) Update(ctx context.Context, user models.User) error {
-	c.logger.Debugln("Update", user)
+// CreateBatch creates the users and returns the error of every user, nil for the created ones.
+// The repeated names of the batch are rejected, the first user with the name is created.
+func (c *core) CreateBatch(ctx context.Context, users []models.User) ([]error, error) {
+	c.logger.Debugln("CreateBatch", len(users))
+
+	res := make([]error, len(users))
+	batch := make([]models.User, 0, len(users))
+	index := make([]int, 0, len(users))
+	seen := make(map[string]bool, len(users))
+	for i, user := range users {
+		if seen[user.Name] {
+			res[i] = errors.Wrapf(errorsPkg.ErrUserAlreadyExists, "repeated in batch user-name: [%s]", user.Name)
+			continue
+		}
+		seen[user.Name] = true
+
+		hash, err := c.hasher.Hash(user.Password)
+		if err != nil {
+			res[i] = errors.Wrap(err, "password hash")
+			continue
+		}
+		user.Password = hash
+		batch = append(batch, user)
+		index = append(index, i)
+	}
+	if len(batch) == 0 {
+		return res, nil
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
 	defer cancel()
 
-	old, err := c.data.UserGet(ctx, user.Name)
+	// the outbox message gets the results of all the users, not only of the batch sent to the repository
+	if fn := models.OutboxFromCtx(ctx); fn != nil {
+		ctx = models.WithOutbox(ctx, func(created []error) (models.OutboxMessage, error) {
+			full := make([]error, len(res))
+			copy(full, res)
+			for j, err := range created {
+				full[index[j]] = err
+			}
+			return fn(full)
+		})
+	}
+
+	created, err := c.data.UserCreateBatch(ctx, batch)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(batch))
+	for j, err := range created {
+		res[index[j]] = err
+		if err == nil {
+			names = append(names, batch[j].Name)
+		}
+	}
+	if len(names) == 0 {
+		return res, nil
+	}
+	c.invalidateLists(ctx)
+
+	if err = c.cache.Del(ctx, names...); err != nil {
+		c.logger.Errorf("remove not found marks from cache: %v", err)
+	}
+
+	return res, nil
+}
+
+func (c *core) Update(ctx context.Context, profile models.Profile) error {
+	c.logger.Debugln("Update", profile.String())
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	if err := c.checkRequest(ctx); err != nil {
+		return err
+	}
+	old, err := c.data.UserGet(ctx, profile.Name, false)
 	if err != nil {
 		return err
 	}
-	if err = c.data.UserUpdate(ctx, user); err != nil {
+	if err = checkVersion(old, profile.Version); err != nil {
+		return err
+	}
+	if profile.Email != nil && *profile.Email != old.Email {
+		if err = c.checkEmail(ctx, *profile.Email, profile.Name); err != nil {
+			return err
+		}
+	}
+	if profile.Password != nil {
+		hash, err := c.hasher.Hash(*profile.Password)
+		if err != nil {
+			return errors.Wrap(err, "password hash")
+		}
+		profile.Password = &hash
+	}
+	if err = c.data.UserUpdate(ctx, profile); err != nil {
 		return err
 	}
+	c.invalidateLists(ctx)
 
-	user.CreatedAt = old.CreatedAt
-	if err = c.cache.Set(ctx, user.Name, &user, expirationTime).Err(); err != nil {
-		c.logger.Errorf("set to cache: %v", err)
+	// the user is cached again on the next read, the stored version may differ from old if it was changed concurrently
+	if err = c.cache.Del(ctx, profile.Name); err != nil {
+		c.logger.Errorf("remove from cache: %v", err)
 	}
 
 	return nil
 }
 
-func (c *core) Delete(ctx context.Context, name string) error {
-	c.logger.Debugln("Delete", name)
+func (c *core) Delete(ctx context.Context, name string, version uint64) error {
+	c.logger.Debugln("Delete", name, version)
 	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
 	defer cancel()
 
-	if _, err := c.data.UserGet(ctx, name); err != nil {
+	if err := c.checkRequest(ctx); err != nil {
+		return err
+	}
+	old, err := c.data.UserGet(ctx, name, false)
+	if err != nil {
 		return err
 	}
-	if err := c.data.UserDelete(ctx, name); err != nil {
+	if err = checkVersion(old, version); err != nil {
 		return err
 	}
+	if err = c.data.UserDelete(ctx, name, version); err != nil {
+		return err
+	}
+	c.invalidateLists(ctx)
 
-	if err := c.cache.Del(ctx, name).Err(); err != nil {
-		if !errors.Is(err, redis.Nil) {
-			c.logger.Errorf("remove from cache: %v", err)
-		}
+	if err = c.cache.Del(ctx, name); err != nil {
+		c.logger.Errorf("remove from cache: %v", err)
 	}
 
 	return nil
 }
 
-func (c *core) Get(ctx context.Context, name string) (models.User, error) {
-	c.logger.Debugln("Get", name)
+func (c *core) Restore(ctx context.Context, name string) error {
+	c.logger.Debugln("Restore", name)
 	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
 	defer cancel()
 
-	if data, err := c.cache.Get(ctx, name).Bytes(); err == nil {
-		counter.Hit.Inc()
-		var user models.User
-		if err = json.Unmarshal(data, &user); err == nil {
-			return user, nil
+	if err := c.data.UserRestore(ctx, name); err != nil {
+		return err
+	}
+	c.invalidateLists(ctx)
+
+	if err := c.cache.Del(ctx, name); err != nil {
+		c.logger.Errorf("remove from cache: %v", err)
+	}
+
+	return nil
+}
+
+// Purge removes the users deleted more than retention ago.
+func (c *core) Purge(ctx context.Context, retention time.Duration) (int64, error) {
+	c.logger.Debugln("Purge", retention)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	purged, err := c.data.UserPurge(ctx, retention)
+	if err != nil {
+		return 0, err
+	}
+	if purged > 0 {
+		c.invalidateLists(ctx)
+	}
+
+	return purged, nil
+}
+
+// Get returns not deleted users from cache, deleted ones are read from repository only.
+// Concurrent misses of the same user share one repository read, absent users are cached for a short time.
+func (c *core) Get(ctx context.Context, name string, withDeleted bool) (models.User, error) {
+	c.logger.Debugln("Get", name, withDeleted)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	if data, err := c.cache.Get(ctx, name); err == nil {
+		if string(data) != notFoundValue {
+			counter.Hit.Inc()
+			var user models.User
+			if err = json.Unmarshal(data, &user); err == nil {
+				return user, nil
+			}
+			c.logger.Errorf("unmarshal cached data: %v", err)
+		} else if !withDeleted {
+			counter.Hit.Inc()
+			return models.User{}, errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s]", name)
 		}
-		c.logger.Errorf("unmarshal cached data: %v", err)
 	}
 
 	counter.Miss.Inc()
-	user, err := c.data.UserGet(ctx, name)
+	loaded := c.group.DoChan(fmt.Sprintf("%s_%v", name, withDeleted), func() (interface{}, error) {
+		// the read is shared by the callers, so it is not canceled by the first one
+		ctx, cancel := context.WithTimeout(detached{ctx}, ctxTimeout)
+		defer cancel()
+		return c.load(ctx, name, withDeleted)
+	})
+	select {
+	case res := <-loaded:
+		if res.Shared {
+			counter.Coalesced.Inc()
+		}
+		if res.Err != nil {
+			return models.User{}, res.Err
+		}
+		return res.Val.(models.User), nil
+	case <-ctx.Done():
+		return models.User{}, errors.Wrapf(ctx.Err(), "user-name: [%s]", name)
+	}
+}
+
+// detached keeps the values of the context without its cancellation.
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detached) Done() <-chan struct{} {
+	return nil
+}
+
+func (detached) Err() error {
+	return nil
+}
+
+// load reads the user from repository and caches the result.
+func (c *core) load(ctx context.Context, name string, withDeleted bool) (models.User, error) {
+	user, err := c.data.UserGet(ctx, name, withDeleted)
+	if errors.Is(err, errorsPkg.ErrUserNotFound) && !withDeleted {
+		if err := c.cache.Set(ctx, name, []byte(notFoundValue), jitter(notFoundExpirationTime)); err != nil {
+			c.logger.Errorf("set not found mark to cache: %v", err)
+		}
+	}
 	if err != nil {
 		return user, err
 	}
-	if err = c.cache.Set(ctx, name, &user, expirationTime).Err(); err != nil {
+	user.Password = ""
+	if user.Deleted() {
+		return user, nil
+	}
+	if err = c.setCache(ctx, name, user); err != nil {
 		c.logger.Errorf("set user to cache: %v", err)
 	}
 
 	return user, nil
 }
 
-func (c *core) List(ctx context.Context, order bool, limit, offset uint64) ([]models.User, error) {
-	c.logger.Debugln("List", order, limit, offset)
+// GetByEmail returns the not deleted user with the email from repository.
+func (c *core) GetByEmail(ctx context.Context, email string) (models.User, error) {
+	c.logger.Debugln("GetByEmail", email)
 	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
 	defer cancel()
 
-	key := fmt.Sprintf("%v_%d_%d", order, limit, offset)
-	if data, err := c.cache.Get(ctx, key).Bytes(); err == nil {
-		counter.Hit.Inc()
-		users := make([]models.User, 0)
-		if err = json.Unmarshal(data, &users); err == nil {
-			return users, nil
+	user, err := c.data.UserGetByEmail(ctx, email, false)
+	if err != nil {
+		return models.User{}, err
+	}
+	user.Password = ""
+
+	return user, nil
+}
+
+// History returns the changes of the user in order, it is read from repository only.
+func (c *core) History(ctx context.Context, name string) ([]models.UserChange, error) {
+	c.logger.Debugln("History", name)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	return c.data.UserHistory(ctx, name)
+}
+
+// GetAsOf returns the user as it was at the time, the deleted user is returned as deleted.
+func (c *core) GetAsOf(ctx context.Context, name string, at time.Time) (models.User, error) {
+	c.logger.Debugln("GetAsOf", name, at)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	return c.data.UserGetAsOf(ctx, name, at)
+}
+
+// List returns the page of users, the next page token continues the listing after the page.
+func (c *core) List(ctx context.Context, params models.UserListParams) (models.UserListPage, error) {
+	c.logger.Debugln("List", params.Order, params.Limit, params.Offset, params.PageToken, params.WithDeleted)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	if err := params.Validate(); err != nil {
+		return models.UserListPage{}, err
+	}
+	if params.PageToken != "" {
+		token, err := models.DecodePageToken(params.PageToken)
+		if err != nil {
+			return models.UserListPage{}, err
+		}
+		if !token.Match(params) {
+			return models.UserListPage{}, errors.Wrap(errorsPkg.ErrInvalidPageToken, "token of another query")
+		}
+		after := token.User()
+		params.After = &after
+	}
+
+	// the generation is read before the repository, so a page read before a change
+	// can be cached only under the previous generation key
+	key, cached := c.listKey(ctx, fmt.Sprintf("%s_%d_%d_%s",
+		params.Query(), params.Limit, params.Offset, params.PageToken))
+	if cached {
+		if data, err := c.cache.Get(ctx, key); err == nil {
+			counter.Hit.Inc()
+			var page models.UserListPage
+			if err = json.Unmarshal(data, &page); err == nil {
+				return page, nil
+			}
+			c.logger.Errorf("unmarshal cached data: %v", err)
 		}
-		c.logger.Errorf("unmarshal cached data: %v", err)
 	}
 
 	counter.Miss.Inc()
-	users, err := c.data.UserList(ctx, order, limit, offset)
+	users, err := c.data.UserList(ctx, params)
 	if err != nil {
-		return users, err
+		return models.UserListPage{}, err
+	}
+	page := models.UserListPage{Users: users}
+	// a full page may be followed by the next one, the page after the last one is empty
+	if params.Limit > 0 && uint64(len(users)) == params.Limit {
+		page.NextPageToken = models.NewPageToken(params, users[len(users)-1]).Encode()
+	}
+	for i := range page.Users {
+		page.Users[i].Password = ""
 	}
-	if err = c.cache.Set(ctx, key, users, expirationTime).Err(); err != nil {
+	if !cached {
+		return page, nil
+	}
+	if err = c.setCache(ctx, key, page); err != nil {
 		c.logger.Errorf("set users list to cache: %v", err)
 	}
 
-	return users, nil
+	return page, nil
 }
 
 func (c *core) Data(ctx context.Context, uid string) ([]byte, error) {
 	c.logger.Debugln("Data", uid)
 
-	return c.cache.Get(ctx, uid).Bytes()
+	return c.cache.Get(ctx, uid)
+}
+
+func (c *core) CheckPassword(ctx context.Context, name, password string) error {
+	c.logger.Debugln("CheckPassword", name)
+	ctx, cancel := context.WithTimeout(ctx, ctxTimeout)
+	defer cancel()
+
+	user, err := c.data.UserGet(ctx, name, false)
+	if errors.Is(err, errorsPkg.ErrUserNotFound) {
+		c.compareDummy(password)
+		return errorsPkg.ErrInvalidCredentials
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = c.hasher.Compare(user.Password, password); errors.Is(err, errorsPkg.ErrPasswordMismatch) {
+		return errorsPkg.ErrInvalidCredentials
+	}
+	return err
+}
+
+// compareDummy compares the password with the dummy hash made by the hasher on the first call.
+func (c *core) compareDummy(password string) {
+	c.dummyOnce.Do(func() {
+		hash, err := c.hasher.Hash(dummyPassword)
+		if err != nil {
+			c.logger.Errorln("dummy hash", err)
+			return
+		}
+		c.dummyHash = hash
+	})
+	_ = c.hasher.Compare(c.dummyHash, password)
+}
+
+// setCache stores the value as JSON.
+func (c *core) setCache(ctx context.Context, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return errors.Wrap(err, "marshal")
+	}
+	return c.cache.Set(ctx, key, data, jitter(expirationTime))
+}
+
+// jitter adds up to 10% to the expiration time, so entries cached together do not expire together.
+func jitter(expiration time.Duration) time.Duration {
+	return expiration + time.Duration(rand.Int63n(int64(expiration)/10+1))
+}
+
+// listKey returns the cache key of the list page in the current generation,
+// false means the generation is unknown and the page must not be cached.
+func (c *core) listKey(ctx context.Context, page string) (string, bool) {
+	generation := []byte("0")
+	data, err := c.cache.Get(ctx, listGenerationKey)
+	if err == nil {
+		generation = data
+	} else if !errors.Is(err, cachePkg.ErrNotFound) {
+		c.logger.Errorf("get list generation: %v", err)
+		return "", false
+	}
+	return fmt.Sprintf("list_%s_%s", generation, page), true
+}
+
+// invalidateLists makes all cached list pages stale, they expire by TTL.
+func (c *core) invalidateLists(ctx context.Context) {
+	if _, err := c.cache.Incr(ctx, listGenerationKey); err != nil {
+		c.logger.Errorf("increment list generation: %v", err)
+	}
+}
+
+// checkEmail rejects the email of another user, the email of the deleted user is reserved until the user is purged.
+func (c *core) checkEmail(ctx context.Context, email, name string) error {
+	owner, err := c.data.UserGetByEmail(ctx, email, true)
+	if errors.Is(err, errorsPkg.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if owner.Name != name {
+		return errors.Wrapf(errorsPkg.ErrEmailAlreadyExists, "email: [%s]", email)
+	}
+	return nil
+}
+
+// checkVersion rejects the request early, the repository checks the version once more on write.
+// checkRequest returns customerrors.ErrRequestProcessed if the request of the change with the outbox message
+// is already processed, so the redelivered request does not fail the checks of the applied change.
+// The repository checks it again with the change.
+func (c *core) checkRequest(ctx context.Context) error {
+	uid, _ := helper.ExtractUidPubFromCtx(ctx)
+	if uid == "" || models.OutboxFromCtx(ctx) == nil {
+		return nil
+	}
+	processed, err := c.data.RequestProcessed(ctx, uid)
+	if err != nil {
+		return err
+	}
+	if processed {
+		return errors.Wrapf(errorsPkg.ErrRequestProcessed, "request: [%s]", uid)
+	}
+	return nil
+}
+
+func checkVersion(user models.User, version uint64) error {
+	if version != 0 && user.Version != version {
+		return errors.Wrapf(errorsPkg.ErrVersionConflict, "user-name: [%s], version: [%d], expected: [%d]",
+			user.Name, user.Version, version)
+	}
+	return nil
 }
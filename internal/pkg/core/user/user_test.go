@@ -2,15 +2,22 @@ package user
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
-	"github.com/go-redis/redismock/v8"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/password"
 	repoMockPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/mock"
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+	memoryPkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache/memory"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
 	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
 )
 
@@ -20,19 +27,20 @@ var (
 		Password:  "123",
 		Email:     "ivan@email.com",
 		FullName:  "Ivan the Dummy",
-		CreatedAt: 1660412940,
+		CreatedAt: time.Unix(1660412940, 0).UTC(),
 	}
+	hasher, _ = password.New(password.Config{Algorithm: password.Bcrypt, Cost: 4})
 )
 
 func Test_Create(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
-	client, _ := redismock.NewClientMock()
 
 	cases := []struct {
 		name      string
 		user      models.User
 		getErr    error
+		emailErr  error
 		createErr error
 		expErr    error
 	}{
@@ -40,6 +48,7 @@ func Test_Create(t *testing.T) {
 			name:      "success",
 			user:      user,
 			getErr:    errorsPkg.ErrUserNotFound,
+			emailErr:  errorsPkg.ErrUserNotFound,
 			createErr: nil,
 			expErr:    nil,
 		},
@@ -57,10 +66,27 @@ func Test_Create(t *testing.T) {
 			createErr: nil,
 			expErr:    errorsPkg.ErrUserAlreadyExists,
 		},
+		{
+			name:      "failed UserGetByEmail email already exists error",
+			user:      user,
+			getErr:    errorsPkg.ErrUserNotFound,
+			emailErr:  nil,
+			createErr: nil,
+			expErr:    errorsPkg.ErrEmailAlreadyExists,
+		},
+		{
+			name:      "failed UserGetByEmail unexpected error",
+			user:      user,
+			getErr:    errorsPkg.ErrUserNotFound,
+			emailErr:  errorsPkg.ErrUnexpected,
+			createErr: nil,
+			expErr:    errorsPkg.ErrUnexpected,
+		},
 		{
 			name:      "failed UserCreate unexpected error",
 			user:      user,
 			getErr:    errorsPkg.ErrUserNotFound,
+			emailErr:  errorsPkg.ErrUserNotFound,
 			createErr: errorsPkg.ErrUnexpected,
 			expErr:    errorsPkg.ErrUnexpected,
 		},
@@ -70,48 +96,244 @@ func Test_Create(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			mockRepo := repoMockPkg.NewMockInterface(ctl)
 			gomock.InOrder(
-				mockRepo.EXPECT().UserGet(gomock.Any(), c.user.Name).
+				mockRepo.EXPECT().UserGet(gomock.Any(), c.user.Name, true).
 					Return(models.User{}, c.getErr).Times(1),
-				mockRepo.EXPECT().UserCreate(gomock.Any(), c.user).
+				mockRepo.EXPECT().UserGetByEmail(gomock.Any(), c.user.Email, true).
+					Return(models.User{Name: "Boris"}, c.emailErr).MaxTimes(1),
+				mockRepo.EXPECT().UserCreate(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, u models.User) {
+						assert.NoError(t, hasher.Compare(u.Password, c.user.Password))
+					}).
 					Return(c.createErr).MaxTimes(1),
 			)
 
-			userCtl := New(mockRepo, loggerPkg.NewFatal(), client)
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
 			err := userCtl.Create(context.Background(), c.user)
 			assert.ErrorIs(t, err, c.expErr)
 		})
 	}
 }
 
+func Test_RequestProcessed(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	const uid = "uid-1"
+	ctx := models.WithOutbox(helper.InjectUidPubToCtx(context.Background(), uid, "pub"),
+		func([]error) (models.OutboxMessage, error) {
+			return models.OutboxMessage{}, nil
+		})
+	fullName := "Ivan the Great"
+
+	cases := []struct {
+		name         string
+		change       func(userCtl Interface) error
+		processed    bool
+		processedErr error
+		expErr       error
+	}{
+		{
+			name: "skipped, create processed",
+			change: func(userCtl Interface) error {
+				return userCtl.Create(ctx, user)
+			},
+			processed: true,
+			expErr:    errorsPkg.ErrRequestProcessed,
+		},
+		{
+			name: "skipped, update processed",
+			change: func(userCtl Interface) error {
+				return userCtl.Update(ctx, models.Profile{Name: user.Name, FullName: &fullName})
+			},
+			processed: true,
+			expErr:    errorsPkg.ErrRequestProcessed,
+		},
+		{
+			name: "skipped, delete processed",
+			change: func(userCtl Interface) error {
+				return userCtl.Delete(ctx, user.Name, 0)
+			},
+			processed: true,
+			expErr:    errorsPkg.ErrRequestProcessed,
+		},
+		{
+			name: "failed RequestProcessed unexpected error",
+			change: func(userCtl Interface) error {
+				return userCtl.Delete(ctx, user.Name, 0)
+			},
+			processedErr: errorsPkg.ErrUnexpected,
+			expErr:       errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockRepo := repoMockPkg.NewMockInterface(ctl)
+			mockRepo.EXPECT().RequestProcessed(gomock.Any(), uid).Return(c.processed, c.processedErr).Times(1)
+
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+			assert.ErrorIs(t, c.change(userCtl), c.expErr)
+		})
+	}
+}
+
+func Test_CreateBatch(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	other := user
+	other.Name = "Boris"
+
+	cases := []struct {
+		name      string
+		users     []models.User
+		batch     []string
+		createRes []error
+		createErr error
+		expRes    []error
+		expErr    error
+	}{
+		{
+			name:      "success",
+			users:     []models.User{user, other},
+			batch:     []string{user.Name, other.Name},
+			createRes: []error{nil, errorsPkg.ErrUserAlreadyExists},
+			expRes:    []error{nil, errorsPkg.ErrUserAlreadyExists},
+		},
+		{
+			name:      "success, repeated name",
+			users:     []models.User{user, other, user},
+			batch:     []string{user.Name, other.Name},
+			createRes: []error{nil, nil},
+			expRes:    []error{nil, nil, errorsPkg.ErrUserAlreadyExists},
+		},
+		{
+			name:      "failed UserCreateBatch unexpected error",
+			users:     []models.User{user},
+			batch:     []string{user.Name},
+			createErr: errorsPkg.ErrUnexpected,
+			expErr:    errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockRepo := repoMockPkg.NewMockInterface(ctl)
+			mockRepo.EXPECT().UserCreateBatch(gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, users []models.User) {
+					names := make([]string, 0, len(users))
+					for _, u := range users {
+						assert.NoError(t, hasher.Compare(u.Password, user.Password))
+						names = append(names, u.Name)
+					}
+					assert.Equal(t, c.batch, names)
+				}).
+				Return(c.createRes, c.createErr).Times(1)
+
+			cache := memoryPkg.New()
+			ctx := context.Background()
+			assert.NoError(t, cache.Set(ctx, user.Name, []byte(notFoundValue), time.Minute))
+
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), cache, hasher)
+			res, err := userCtl.CreateBatch(ctx, c.users)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Len(t, res, len(c.expRes))
+			for i := range c.expRes {
+				assert.ErrorIs(t, res[i], c.expRes[i])
+			}
+
+			exists, err := cache.Exists(ctx, user.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, c.expErr != nil, exists)
+		})
+	}
+}
+
+func Test_CreateBatchOutbox(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	other := user
+	other.Name = "Boris"
+
+	mockRepo := repoMockPkg.NewMockInterface(ctl)
+	mockRepo.EXPECT().UserCreateBatch(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, users []models.User) ([]error, error) {
+			res := []error{nil, errorsPkg.ErrUserAlreadyExists}
+			_, err := models.OutboxFromCtx(ctx)(res)
+			return res, err
+		}).Times(1)
+
+	var results []error
+	ctx := models.WithOutbox(context.Background(), func(res []error) (models.OutboxMessage, error) {
+		results = res
+		return models.OutboxMessage{}, nil
+	})
+
+	userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+	_, err := userCtl.CreateBatch(ctx, []models.User{user, user, other})
+	assert.NoError(t, err)
+	if assert.Len(t, results, 3) {
+		assert.NoError(t, results[0])
+		assert.ErrorIs(t, results[1], errorsPkg.ErrUserAlreadyExists)
+		assert.ErrorIs(t, results[2], errorsPkg.ErrUserAlreadyExists)
+	}
+}
+
 func Test_Update(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
-	client, _ := redismock.NewClientMock()
+
+	profile := models.Profile{
+		Name:     user.Name,
+		Password: &user.Password,
+	}
+	versioned := profile
+	versioned.Version = 2
 
 	cases := []struct {
 		name      string
-		user      models.User
+		user      models.Profile
+		stored    uint64
 		getErr    error
 		updateErr error
 		expErr    error
 	}{
 		{
 			name:      "success",
-			user:      user,
+			user:      profile,
+			stored:    1,
+			getErr:    nil,
+			updateErr: nil,
+			expErr:    nil,
+		},
+		{
+			name:      "success with expected version",
+			user:      versioned,
+			stored:    2,
 			getErr:    nil,
 			updateErr: nil,
 			expErr:    nil,
 		},
+		{
+			name:      "failed version conflict",
+			user:      versioned,
+			stored:    3,
+			getErr:    nil,
+			updateErr: nil,
+			expErr:    errorsPkg.ErrVersionConflict,
+		},
 		{
 			name:      "failed UserGet unexpected error",
-			user:      user,
+			user:      profile,
 			getErr:    errorsPkg.ErrUnexpected,
 			updateErr: nil,
 			expErr:    errorsPkg.ErrUnexpected,
 		},
 		{
 			name:      "failed UserUpdate unexpected error",
-			user:      user,
+			user:      profile,
+			stored:    1,
 			getErr:    nil,
 			updateErr: errorsPkg.ErrUnexpected,
 			expErr:    errorsPkg.ErrUnexpected,
@@ -122,27 +344,85 @@ func Test_Update(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			mockRepo := repoMockPkg.NewMockInterface(ctl)
 			gomock.InOrder(
-				mockRepo.EXPECT().UserGet(gomock.Any(), c.user.Name).
-					Return(models.User{}, c.getErr).Times(1),
-				mockRepo.EXPECT().UserUpdate(gomock.Any(), c.user).
+				mockRepo.EXPECT().UserGet(gomock.Any(), c.user.Name, false).
+					Return(models.User{Version: c.stored}, c.getErr).Times(1),
+				mockRepo.EXPECT().UserUpdate(gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, p models.Profile) {
+						assert.Nil(t, p.Email)
+						assert.Equal(t, c.user.Version, p.Version)
+						assert.NoError(t, hasher.Compare(*p.Password, *c.user.Password))
+					}).
 					Return(c.updateErr).MaxTimes(1),
 			)
 
-			userCtl := New(mockRepo, loggerPkg.NewFatal(), client)
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
 			err := userCtl.Update(context.Background(), c.user)
 			assert.ErrorIs(t, err, c.expErr)
 		})
 	}
 }
 
+func Test_UpdateEmail(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	email := "boris@email.com"
+	profile := models.Profile{
+		Name:  user.Name,
+		Email: &email,
+	}
+
+	cases := []struct {
+		name     string
+		owner    string
+		emailErr error
+		expErr   error
+	}{
+		{
+			name:     "success, email is free",
+			emailErr: errorsPkg.ErrUserNotFound,
+			expErr:   nil,
+		},
+		{
+			name:   "success, own email",
+			owner:  user.Name,
+			expErr: nil,
+		},
+		{
+			name:   "failed, email of another user",
+			owner:  "Boris",
+			expErr: errorsPkg.ErrEmailAlreadyExists,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockRepo := repoMockPkg.NewMockInterface(ctl)
+			gomock.InOrder(
+				mockRepo.EXPECT().UserGet(gomock.Any(), user.Name, false).
+					Return(user, nil).Times(1),
+				mockRepo.EXPECT().UserGetByEmail(gomock.Any(), email, true).
+					Return(models.User{Name: c.owner}, c.emailErr).Times(1),
+				mockRepo.EXPECT().UserUpdate(gomock.Any(), profile).
+					Return(nil).MaxTimes(1),
+			)
+
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+			err := userCtl.Update(context.Background(), profile)
+			assert.ErrorIs(t, err, c.expErr)
+		})
+	}
+}
+
 func Test_Delete(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
-	client, _ := redismock.NewClientMock()
 
 	cases := []struct {
 		name      string
 		user      string
+		version   uint64
+		stored    uint64
 		getErr    error
 		deleteErr error
 		expErr    error
@@ -150,10 +430,29 @@ func Test_Delete(t *testing.T) {
 		{
 			name:      "success",
 			user:      user.Name,
+			stored:    1,
+			getErr:    nil,
+			deleteErr: nil,
+			expErr:    nil,
+		},
+		{
+			name:      "success with expected version",
+			user:      user.Name,
+			version:   2,
+			stored:    2,
 			getErr:    nil,
 			deleteErr: nil,
 			expErr:    nil,
 		},
+		{
+			name:      "failed version conflict",
+			user:      user.Name,
+			version:   2,
+			stored:    3,
+			getErr:    nil,
+			deleteErr: nil,
+			expErr:    errorsPkg.ErrVersionConflict,
+		},
 		{
 			name:      "failed UserGet unexpected error",
 			user:      user.Name,
@@ -174,14 +473,51 @@ func Test_Delete(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			mockRepo := repoMockPkg.NewMockInterface(ctl)
 			gomock.InOrder(
-				mockRepo.EXPECT().UserGet(gomock.Any(), c.user).
-					Return(models.User{}, c.getErr).Times(1),
-				mockRepo.EXPECT().UserDelete(gomock.Any(), c.user).
+				mockRepo.EXPECT().UserGet(gomock.Any(), c.user, false).
+					Return(models.User{Version: c.stored}, c.getErr).Times(1),
+				mockRepo.EXPECT().UserDelete(gomock.Any(), c.user, c.version).
 					Return(c.deleteErr).MaxTimes(1),
 			)
 
-			userCtl := New(mockRepo, loggerPkg.NewFatal(), client)
-			err := userCtl.Delete(context.Background(), c.user)
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+			err := userCtl.Delete(context.Background(), c.user, c.version)
+			assert.ErrorIs(t, err, c.expErr)
+		})
+	}
+}
+
+func Test_Restore(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cases := []struct {
+		name       string
+		user       string
+		restoreErr error
+		expErr     error
+	}{
+		{
+			name:       "success",
+			user:       user.Name,
+			restoreErr: nil,
+			expErr:     nil,
+		},
+		{
+			name:       "failed UserRestore not found error",
+			user:       user.Name,
+			restoreErr: errorsPkg.ErrUserNotFound,
+			expErr:     errorsPkg.ErrUserNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockRepo := repoMockPkg.NewMockInterface(ctl)
+			mockRepo.EXPECT().UserRestore(gomock.Any(), c.user).
+				Return(c.restoreErr).Times(1)
+
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+			err := userCtl.Restore(context.Background(), c.user)
 			assert.ErrorIs(t, err, c.expErr)
 		})
 	}
@@ -190,28 +526,49 @@ func Test_Delete(t *testing.T) {
 func Test_Get(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
-	client, _ := redismock.NewClientMock()
+
+	public := user
+	public.Password = ""
+	deleted := user
+	deleted.DeletedAt = 1660413940
+	publicDeleted := deleted
+	publicDeleted.Password = ""
 
 	cases := []struct {
-		name    string
-		user    string
-		getErr  error
-		expErr  error
-		expUser models.User
+		name        string
+		user        string
+		withDeleted bool
+		getUser     models.User
+		getErr      error
+		expErr      error
+		expUser     models.User
 	}{
 		{
-			name:    "success",
-			user:    user.Name,
-			getErr:  nil,
-			expErr:  nil,
-			expUser: user,
+			name:        "success, password is not returned",
+			user:        user.Name,
+			withDeleted: false,
+			getUser:     user,
+			getErr:      nil,
+			expErr:      nil,
+			expUser:     public,
+		},
+		{
+			name:        "success, deleted user",
+			user:        user.Name,
+			withDeleted: true,
+			getUser:     deleted,
+			getErr:      nil,
+			expErr:      nil,
+			expUser:     publicDeleted,
 		},
 		{
-			name:    "failed UserGet unexpected error",
-			user:    user.Name,
-			getErr:  errorsPkg.ErrUnexpected,
-			expErr:  errorsPkg.ErrUnexpected,
-			expUser: models.User{},
+			name:        "failed UserGet unexpected error",
+			user:        user.Name,
+			withDeleted: false,
+			getUser:     models.User{},
+			getErr:      errorsPkg.ErrUnexpected,
+			expErr:      errorsPkg.ErrUnexpected,
+			expUser:     models.User{},
 		},
 	}
 
@@ -219,12 +576,12 @@ func Test_Get(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			mockRepo := repoMockPkg.NewMockInterface(ctl)
 			gomock.InOrder(
-				mockRepo.EXPECT().UserGet(gomock.Any(), c.user).
-					Return(c.expUser, c.getErr).Times(1),
+				mockRepo.EXPECT().UserGet(gomock.Any(), c.user, c.withDeleted).
+					Return(c.getUser, c.getErr).Times(1),
 			)
 
-			userCtl := New(mockRepo, loggerPkg.NewFatal(), client)
-			expUser, err := userCtl.Get(context.Background(), c.user)
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+			expUser, err := userCtl.Get(context.Background(), c.user, c.withDeleted)
 			assert.ErrorIs(t, err, c.expErr)
 			assert.Equal(t, expUser, c.expUser)
 		})
@@ -234,40 +591,394 @@ func Test_Get(t *testing.T) {
 func Test_List(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
-	client, _ := redismock.NewClientMock()
+
+	public := user
+	public.Password = ""
+	params := models.UserListParams{Limit: 1, Order: true}
+	token := models.NewPageToken(params, public).Encode()
+	next := params
+	next.PageToken = token
+	foreign := params
+	foreign.Order = false
+	foreign.PageToken = token
 
 	cases := []struct {
-		name    string
-		listErr error
-		expErr  error
-		expList []models.User
+		name      string
+		params    models.UserListParams
+		listTimes int
+		expAfter  *models.User
+		list      []models.User
+		listErr   error
+		expErr    error
+		expPage   models.UserListPage
 	}{
 		{
-			name:    "success",
-			listErr: nil,
-			expErr:  nil,
-			expList: []models.User{user},
+			name:      "success, passwords are not returned, full page has next page token",
+			params:    params,
+			listTimes: 1,
+			list:      []models.User{user},
+			listErr:   nil,
+			expErr:    nil,
+			expPage:   models.UserListPage{Users: []models.User{public}, NextPageToken: token},
+		},
+		{
+			name:      "success, page token is decoded, last page has no next page token",
+			params:    next,
+			listTimes: 1,
+			expAfter:  &models.User{Name: user.Name},
+			list:      []models.User{},
+			listErr:   nil,
+			expErr:    nil,
+			expPage:   models.UserListPage{Users: []models.User{}},
+		},
+		{
+			name:      "failed, malformed page token",
+			params:    models.UserListParams{Limit: 1, PageToken: "!"},
+			listTimes: 0,
+			expErr:    errorsPkg.ErrInvalidPageToken,
+			expPage:   models.UserListPage{},
 		},
 		{
-			name:    "failed UserList unexpected error",
-			listErr: errorsPkg.ErrUnexpected,
-			expErr:  errorsPkg.ErrUnexpected,
-			expList: nil,
+			name:      "failed, page token of another query",
+			params:    foreign,
+			listTimes: 0,
+			expErr:    errorsPkg.ErrInvalidPageToken,
+			expPage:   models.UserListPage{},
+		},
+		{
+			name:      "failed, unknown sort field",
+			params:    models.UserListParams{Limit: 1, Sort: []models.UserSort{{Field: "password"}}},
+			listTimes: 0,
+			expErr:    errorsPkg.ErrValidation,
+			expPage:   models.UserListPage{},
+		},
+		{
+			name:      "failed UserList unexpected error",
+			params:    params,
+			listTimes: 1,
+			list:      nil,
+			listErr:   errorsPkg.ErrUnexpected,
+			expErr:    errorsPkg.ErrUnexpected,
+			expPage:   models.UserListPage{},
 		},
 	}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			mockRepo := repoMockPkg.NewMockInterface(ctl)
-			gomock.InOrder(
-				mockRepo.EXPECT().UserList(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(c.expList, c.listErr).Times(1),
-			)
+			mockRepo.EXPECT().UserList(gomock.Any(), gomock.Any()).
+				Do(func(_ context.Context, p models.UserListParams) {
+					assert.Equal(t, c.expAfter, p.After)
+				}).
+				Return(c.list, c.listErr).Times(c.listTimes)
+
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+			page, err := userCtl.List(context.Background(), c.params)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expPage, page)
+		})
+	}
+}
+
+func Test_ListGeneration(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	public := user
+	public.Password = ""
+	params := models.UserListParams{Limit: 1, Offset: 1, Order: true}
+	key := func(generation int) string {
+		return fmt.Sprintf("list_%d_%s_1_1_", generation, params.Query())
+	}
+	page, _ := json.Marshal(models.UserListPage{
+		Users:         []models.User{public},
+		NextPageToken: models.NewPageToken(models.UserListParams{Limit: 1, Order: true}, public).Encode(),
+	})
+
+	cases := []struct {
+		name      string
+		prepare   func(cache cachePkg.Interface)
+		listTimes int
+		expKey    string
+	}{
+		{
+			name: "success, page of the current generation is cached",
+			prepare: func(cache cachePkg.Interface) {
+				_ = cache.Set(context.Background(), listGenerationKey, []byte("3"), 0)
+				_ = cache.Set(context.Background(), key(3), page, 0)
+			},
+			listTimes: 0,
+			expKey:    key(3),
+		},
+		{
+			name: "success, page of the previous generation is not used",
+			prepare: func(cache cachePkg.Interface) {
+				_ = cache.Set(context.Background(), listGenerationKey, []byte("3"), 0)
+				_ = cache.Set(context.Background(), key(2), []byte(`{"users":[]}`), 0)
+			},
+			listTimes: 1,
+			expKey:    key(3),
+		},
+		{
+			name:      "success, page is cached under the initial generation",
+			prepare:   func(cache cachePkg.Interface) {},
+			listTimes: 1,
+			expKey:    key(0),
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cache := memoryPkg.New()
+			c.prepare(cache)
+			mockRepo := repoMockPkg.NewMockInterface(ctl)
+			mockRepo.EXPECT().UserList(gomock.Any(), params).
+				Return([]models.User{user}, nil).Times(c.listTimes)
+
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), cache, hasher)
+			list, err := userCtl.List(context.Background(), params)
+			assert.NoError(t, err)
+			assert.Equal(t, []models.User{public}, list.Users)
+
+			cached, err := cache.Get(context.Background(), c.expKey)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(page), string(cached))
+		})
+	}
+}
+
+func Test_MutationInvalidatesLists(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	t.Run("success, restore increments list generation", func(t *testing.T) {
+		cache := memoryPkg.New()
+		_ = cache.Set(context.Background(), listGenerationKey, []byte("3"), 0)
+		_ = cache.Set(context.Background(), user.Name, []byte(notFoundValue), 0)
+		mockRepo := repoMockPkg.NewMockInterface(ctl)
+		mockRepo.EXPECT().UserRestore(gomock.Any(), user.Name).Return(nil).Times(1)
+
+		userCtl := New(mockRepo, loggerPkg.NewFatal(), cache, hasher)
+		err := userCtl.Restore(context.Background(), user.Name)
+		assert.NoError(t, err)
+
+		generation, err := cache.Get(context.Background(), listGenerationKey)
+		assert.NoError(t, err)
+		assert.Equal(t, "4", string(generation))
+		_, err = cache.Get(context.Background(), user.Name)
+		assert.ErrorIs(t, err, cachePkg.ErrNotFound)
+	})
+
+	t.Run("success, update removes the cached user", func(t *testing.T) {
+		cache := memoryPkg.New()
+		_ = cache.Set(context.Background(), listGenerationKey, []byte("3"), 0)
+		data, _ := json.Marshal(models.User{Name: user.Name, Version: 1})
+		_ = cache.Set(context.Background(), user.Name, data, 0)
+		fullName := "Ivan the Great"
+		mockRepo := repoMockPkg.NewMockInterface(ctl)
+		mockRepo.EXPECT().UserGet(gomock.Any(), user.Name, false).Return(models.User{Name: user.Name, Version: 1}, nil)
+		mockRepo.EXPECT().UserUpdate(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+		userCtl := New(mockRepo, loggerPkg.NewFatal(), cache, hasher)
+		err := userCtl.Update(context.Background(), models.Profile{Name: user.Name, FullName: &fullName})
+		assert.NoError(t, err)
+
+		generation, err := cache.Get(context.Background(), listGenerationKey)
+		assert.NoError(t, err)
+		assert.Equal(t, "4", string(generation))
+		_, err = cache.Get(context.Background(), user.Name)
+		assert.ErrorIs(t, err, cachePkg.ErrNotFound)
+	})
+}
+
+func Test_GetNotFoundCache(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	cases := []struct {
+		name     string
+		prepare  func(cache cachePkg.Interface)
+		getTimes int
+	}{
+		{
+			name:     "success, not found mark is cached",
+			prepare:  func(cache cachePkg.Interface) {},
+			getTimes: 1,
+		},
+		{
+			name: "success, not found mark is read from cache",
+			prepare: func(cache cachePkg.Interface) {
+				_ = cache.Set(context.Background(), user.Name, []byte(notFoundValue), 0)
+			},
+			getTimes: 0,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cache := memoryPkg.New()
+			c.prepare(cache)
+			mockRepo := repoMockPkg.NewMockInterface(ctl)
+			mockRepo.EXPECT().UserGet(gomock.Any(), user.Name, false).
+				Return(models.User{}, errorsPkg.ErrUserNotFound).Times(c.getTimes)
+
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), cache, hasher)
+			_, err := userCtl.Get(context.Background(), user.Name, false)
+			assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+
+			mark, err := cache.Get(context.Background(), user.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, notFoundValue, string(mark))
+		})
+	}
+}
+
+func Test_GetCoalesced(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	const callers = 10
+	release := make(chan struct{})
+	mockRepo := repoMockPkg.NewMockInterface(ctl)
+	mockRepo.EXPECT().UserGet(gomock.Any(), user.Name, false).
+		DoAndReturn(func(_ context.Context, _ string, _ bool) (models.User, error) {
+			<-release
+			return user, nil
+		}).Times(1)
+
+	userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+
+	t.Run("success, one repository read for concurrent misses", func(t *testing.T) {
+		var wg sync.WaitGroup
+		wg.Add(callers)
+		for i := 0; i < callers; i++ {
+			go func() {
+				defer wg.Done()
+				got, err := userCtl.Get(context.Background(), user.Name, false)
+				assert.NoError(t, err)
+				assert.Equal(t, user.Name, got.Name)
+				assert.Empty(t, got.Password)
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+	})
+}
+
+func Test_GetCoalescedCanceled(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	release := make(chan struct{})
+	mockRepo := repoMockPkg.NewMockInterface(ctl)
+	mockRepo.EXPECT().UserGet(gomock.Any(), user.Name, false).
+		DoAndReturn(func(ctx context.Context, _ string, _ bool) (models.User, error) {
+			<-release
+			return user, ctx.Err()
+		}).Times(1)
+
+	userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), hasher)
+
+	t.Run("success, the first caller canceled, the others get the user", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		first := make(chan error, 1)
+		go func() {
+			_, err := userCtl.Get(ctx, user.Name, false)
+			first <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		second := make(chan error, 1)
+		go func() {
+			got, err := userCtl.Get(context.Background(), user.Name, false)
+			assert.Equal(t, user.Name, got.Name)
+			second <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		cancel()
+		assert.ErrorIs(t, <-first, context.Canceled)
+		close(release)
+		assert.NoError(t, <-second)
+	})
+}
+
+// spyHasher records the compared hashes, the dummy password is hashed as "dummy".
+type spyHasher struct {
+	password.Hasher
+	compared []string
+}
+
+func (h *spyHasher) Hash(pass string) (string, error) {
+	if pass == dummyPassword {
+		return "dummy", nil
+	}
+	return h.Hasher.Hash(pass)
+}
+
+func (h *spyHasher) Compare(hash, pass string) error {
+	h.compared = append(h.compared, hash)
+	return h.Hasher.Compare(hash, pass)
+}
+
+func Test_CheckPassword(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	hash, _ := hasher.Hash(user.Password)
+	dummy := "dummy"
+	stored := user
+	stored.Password = hash
+
+	cases := []struct {
+		name        string
+		password    string
+		getErr      error
+		expErr      error
+		expCompared []string
+	}{
+		{
+			name:        "success",
+			password:    user.Password,
+			getErr:      nil,
+			expErr:      nil,
+			expCompared: []string{hash},
+		},
+		{
+			name:        "failed, wrong password",
+			password:    "wrong",
+			getErr:      nil,
+			expErr:      errorsPkg.ErrInvalidCredentials,
+			expCompared: []string{hash},
+		},
+		{
+			name:        "failed, user not found",
+			password:    user.Password,
+			getErr:      errorsPkg.ErrUserNotFound,
+			expErr:      errorsPkg.ErrInvalidCredentials,
+			expCompared: []string{dummy},
+		},
+		{
+			name:     "failed UserGet unexpected error",
+			password: user.Password,
+			getErr:   errorsPkg.ErrUnexpected,
+			expErr:   errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mockRepo := repoMockPkg.NewMockInterface(ctl)
+			mockRepo.EXPECT().UserGet(gomock.Any(), user.Name, false).
+				Return(stored, c.getErr).Times(1)
 
-			userCtl := New(mockRepo, loggerPkg.NewFatal(), client)
-			expList, err := userCtl.List(context.Background(), true, 1, 1)
+			spy := &spyHasher{Hasher: hasher}
+			userCtl := New(mockRepo, loggerPkg.NewFatal(), memoryPkg.New(), spy)
+			err := userCtl.CheckPassword(context.Background(), user.Name, c.password)
 			assert.ErrorIs(t, err, c.expErr)
-			assert.Equal(t, expList, c.expList)
+			// the unknown user is compared with the dummy hash, so it is not told apart by timing
+			assert.Equal(t, c.expCompared, spy.compared)
 		})
 	}
 }
@@ -0,0 +1,718 @@
+package embedded
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+)
+
+const defaultTimeout = time.Second
+
+var (
+	// usersBucket keeps the users by name
+	usersBucket = []byte("users")
+	// emailsBucket indexes the user names by email, deleted users keep their emails until purged
+	emailsBucket = []byte("emails")
+	// historyBucket keeps the bucket of the changes of every user by sequence, it is kept after the user is purged
+	historyBucket = []byte("history")
+	// outboxBucket keeps the not published messages by sequence
+	outboxBucket = []byte("outbox")
+	// requestsBucket keeps the time the requests with the outbox messages were processed by their uids
+	requestsBucket = []byte("requests")
+)
+
+// Config of the embedded storage. Timeout is the wait for the file lock held by another process,
+// with NoSync the file is not synced on commit, it is faster, but not durable.
+type Config struct {
+	Path    string        `mapstructure:"path"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	NoSync  bool          `mapstructure:"no_sync"`
+}
+
+func New(cfg Config, logger *zap.SugaredLogger) (repoPkg.Interface, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o700); err != nil {
+		return nil, errors.Wrap(err, "create dir")
+	}
+	db, err := bbolt.Open(cfg.Path, 0o600, &bbolt.Options{Timeout: cfg.Timeout, NoSync: cfg.NoSync})
+	if err != nil {
+		return nil, errors.Wrap(err, "open embedded storage")
+	}
+	if err = db.Update(func(tx *bbolt.Tx) error {
+		for _, bucket := range [][]byte{usersBucket, emailsBucket, historyBucket, outboxBucket, requestsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		_ = db.Close()
+		return nil, errors.Wrap(err, "create buckets")
+	}
+
+	logger.Infoln("With embedded storage started", cfg.Path)
+	return &repo{
+		db:     db,
+		logger: logger,
+	}, nil
+}
+
+type repo struct {
+	db     *bbolt.DB
+	logger *zap.SugaredLogger
+}
+
+func (r *repo) UserCreate(ctx context.Context, user models.User) error {
+	r.logger.Debugln("UserCreate, embedded func", user.String())
+	if ctx.Err() != nil {
+		return errorsPkg.ErrTimeout
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		if err := checkRequest(ctx, tx); err != nil {
+			return err
+		}
+		if err := create(tx, user, requestID(ctx)); err != nil {
+			return err
+		}
+		return putOutbox(ctx, tx, nil)
+	})
+}
+
+func (r *repo) UserCreateBatch(ctx context.Context, users []models.User) ([]error, error) {
+	r.logger.Debugln("UserCreateBatch, embedded func", len(users))
+	if ctx.Err() != nil {
+		return nil, errorsPkg.ErrTimeout
+	}
+
+	res := make([]error, len(users))
+	if err := r.db.Update(func(tx *bbolt.Tx) error {
+		if err := checkRequest(ctx, tx); err != nil {
+			return err
+		}
+		for i, user := range users {
+			err := create(tx, user, requestID(ctx))
+			if errors.Is(err, errorsPkg.ErrUserAlreadyExists) || errors.Is(err, errorsPkg.ErrEmailAlreadyExists) {
+				res[i] = err
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return putOutbox(ctx, tx, res)
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *repo) UserUpdate(ctx context.Context, profile models.Profile) error {
+	r.logger.Debugln("UserUpdate, embedded func", profile.String())
+	if profile.Empty() {
+		return errors.Wrap(errorsPkg.ErrValidation, "nothing to update")
+	}
+	if ctx.Err() != nil {
+		return errorsPkg.ErrTimeout
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		if err := checkRequest(ctx, tx); err != nil {
+			return err
+		}
+		user, err := checkVersion(tx, profile.Name, profile.Version)
+		if err != nil {
+			return err
+		}
+		if profile.Email != nil && *profile.Email != user.Email {
+			if emailTaken(tx, *profile.Email, profile.Name) {
+				return errors.Wrapf(errorsPkg.ErrEmailAlreadyExists, "email: [%s]", *profile.Email)
+			}
+			if err = tx.Bucket(emailsBucket).Delete([]byte(user.Email)); err != nil {
+				return errors.Wrap(err, "delete email")
+			}
+		}
+
+		old := user
+		user = profile.Apply(user)
+		user.Version++
+		user.UpdatedAt = models.Now()
+		if err = change(tx, models.OperationUpdate, &old, user, requestID(ctx)); err != nil {
+			return err
+		}
+		return putOutbox(ctx, tx, nil)
+	})
+}
+
+func (r *repo) UserDelete(ctx context.Context, name string, version uint64) error {
+	r.logger.Debugln("UserDelete, embedded func", name, version)
+	if ctx.Err() != nil {
+		return errorsPkg.ErrTimeout
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		if err := checkRequest(ctx, tx); err != nil {
+			return err
+		}
+		user, err := checkVersion(tx, name, version)
+		if err != nil {
+			return err
+		}
+
+		old := user
+		user.DeletedAt = time.Now().Unix()
+		user.Version++
+		user.UpdatedAt = models.Now()
+		if err = change(tx, models.OperationDelete, &old, user, requestID(ctx)); err != nil {
+			return err
+		}
+		return putOutbox(ctx, tx, nil)
+	})
+}
+
+func (r *repo) UserRestore(ctx context.Context, name string) error {
+	r.logger.Debugln("UserRestore, embedded func", name)
+	if ctx.Err() != nil {
+		return errorsPkg.ErrTimeout
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		if err := checkRequest(ctx, tx); err != nil {
+			return err
+		}
+		user, ok, err := get(tx, name)
+		if err != nil {
+			return err
+		}
+		if !ok || !user.Deleted() {
+			return errors.Wrapf(errorsPkg.ErrUserNotFound, "deleted user-name: [%s]", name)
+		}
+
+		old := user
+		user.DeletedAt = 0
+		user.Version++
+		user.UpdatedAt = models.Now()
+		if err = change(tx, models.OperationRestore, &old, user, requestID(ctx)); err != nil {
+			return err
+		}
+		return putOutbox(ctx, tx, nil)
+	})
+}
+
+func (r *repo) UserPurge(ctx context.Context, retention time.Duration) (int64, error) {
+	r.logger.Debugln("UserPurge, embedded func", retention)
+	if ctx.Err() != nil {
+		return 0, errorsPkg.ErrTimeout
+	}
+
+	before := time.Now().Add(-retention).Unix()
+	var purged int64
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		expired := make([]models.User, 0)
+		if err := tx.Bucket(usersBucket).ForEach(func(_, value []byte) error {
+			var user models.User
+			if err := json.Unmarshal(value, &user); err != nil {
+				return errors.Wrap(err, "unmarshal user")
+			}
+			if user.Deleted() && user.DeletedAt < before {
+				expired = append(expired, user)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		users, emails := tx.Bucket(usersBucket), tx.Bucket(emailsBucket)
+		for _, user := range expired {
+			if err := users.Delete([]byte(user.Name)); err != nil {
+				return errors.Wrap(err, "delete user")
+			}
+			if bytes.Equal(emails.Get([]byte(user.Email)), []byte(user.Name)) {
+				if err := emails.Delete([]byte(user.Email)); err != nil {
+					return errors.Wrap(err, "delete email")
+				}
+			}
+		}
+		purged = int64(len(expired))
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+func (r *repo) UserGet(ctx context.Context, name string, withDeleted bool) (models.User, error) {
+	r.logger.Debugln("UserGet, embedded func", name, withDeleted)
+	if ctx.Err() != nil {
+		return models.User{}, errorsPkg.ErrTimeout
+	}
+
+	var user models.User
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		var (
+			ok  bool
+			err error
+		)
+		if user, ok, err = get(tx, name); err != nil {
+			return err
+		}
+		if !ok || (user.Deleted() && !withDeleted) {
+			return errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s]", name)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (r *repo) UserGetByEmail(ctx context.Context, email string, withDeleted bool) (models.User, error) {
+	r.logger.Debugln("UserGetByEmail, embedded func", email, withDeleted)
+	if ctx.Err() != nil {
+		return models.User{}, errorsPkg.ErrTimeout
+	}
+
+	var user models.User
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		name := tx.Bucket(emailsBucket).Get([]byte(email))
+		if name == nil {
+			return errors.Wrapf(errorsPkg.ErrUserNotFound, "email: [%s]", email)
+		}
+		var (
+			ok  bool
+			err error
+		)
+		if user, ok, err = get(tx, string(name)); err != nil {
+			return err
+		}
+		if !ok || (user.Deleted() && !withDeleted) {
+			return errors.Wrapf(errorsPkg.ErrUserNotFound, "email: [%s]", email)
+		}
+		return nil
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (r *repo) UserList(ctx context.Context, params models.UserListParams) ([]models.User, error) {
+	r.logger.Debugln("UserList, embedded func", params.Order, params.Limit, params.Offset, params.After, params.WithDeleted)
+	if ctx.Err() != nil {
+		return nil, errorsPkg.ErrTimeout
+	}
+
+	var list []models.User
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		if sorting := params.Sorting(); sorting[0].Field == models.SortName {
+			list, err = listByName(tx, params, sorting[0].Desc)
+		} else {
+			list, err = listSorted(tx, params)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *repo) UserHistory(ctx context.Context, name string) ([]models.UserChange, error) {
+	r.logger.Debugln("UserHistory, embedded func", name)
+	if ctx.Err() != nil {
+		return nil, errorsPkg.ErrTimeout
+	}
+
+	changes := make([]models.UserChange, 0)
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(historyBucket).Bucket([]byte(name))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(_, value []byte) error {
+			var change models.UserChange
+			if err := json.Unmarshal(value, &change); err != nil {
+				return errors.Wrap(err, "unmarshal change")
+			}
+			changes = append(changes, change)
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (r *repo) UserGetAsOf(ctx context.Context, name string, at time.Time) (models.User, error) {
+	r.logger.Debugln("UserGetAsOf, embedded func", name, at)
+	if ctx.Err() != nil {
+		return models.User{}, errorsPkg.ErrTimeout
+	}
+
+	var user models.User
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		if bucket := tx.Bucket(historyBucket).Bucket([]byte(name)); bucket != nil {
+			cursor := bucket.Cursor()
+			for key, value := cursor.Last(); key != nil; key, value = cursor.Prev() {
+				var change models.UserChange
+				if err := json.Unmarshal(value, &change); err != nil {
+					return errors.Wrap(err, "unmarshal change")
+				}
+				if !change.ChangedAt.After(at) {
+					user = change.New
+					return nil
+				}
+			}
+		}
+		return errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s], as of: [%v]", name, at)
+	})
+	if err != nil {
+		return models.User{}, err
+	}
+	return user, nil
+}
+
+func (r *repo) OutboxList(ctx context.Context, limit uint64) ([]models.OutboxMessage, error) {
+	r.logger.Debugln("OutboxList, embedded func", limit)
+	if ctx.Err() != nil {
+		return nil, errorsPkg.ErrTimeout
+	}
+
+	messages := make([]models.OutboxMessage, 0)
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		cursor := tx.Bucket(outboxBucket).Cursor()
+		for key, value := cursor.First(); key != nil && uint64(len(messages)) < limit; key, value = cursor.Next() {
+			var message models.OutboxMessage
+			if err := json.Unmarshal(value, &message); err != nil {
+				return errors.Wrap(err, "unmarshal message")
+			}
+			messages = append(messages, message)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *repo) OutboxMarkPublished(ctx context.Context, ids []uint64) error {
+	r.logger.Debugln("OutboxMarkPublished, embedded func", ids)
+	if ctx.Err() != nil {
+		return errorsPkg.ErrTimeout
+	}
+
+	return r.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(outboxBucket)
+		for _, id := range ids {
+			if err := bucket.Delete(sequenceKey(id)); err != nil {
+				return errors.Wrap(err, "delete message")
+			}
+		}
+		return nil
+	})
+}
+
+// OutboxPurge forgets the requests processed before the time, the published messages are removed
+// from the outbox when marked.
+func (r *repo) OutboxPurge(ctx context.Context, before time.Time) (int64, error) {
+	r.logger.Debugln("OutboxPurge, embedded func", before)
+	if ctx.Err() != nil {
+		return 0, errorsPkg.ErrTimeout
+	}
+
+	var purged int64
+	err := r.db.Update(func(tx *bbolt.Tx) error {
+		cursor := tx.Bucket(requestsBucket).Cursor()
+		for key, value := cursor.First(); key != nil; {
+			var processed time.Time
+			if err := processed.UnmarshalText(value); err != nil {
+				return errors.Wrap(err, "unmarshal request")
+			}
+			if !processed.Before(before) {
+				key, value = cursor.Next()
+				continue
+			}
+			if err := cursor.Delete(); err != nil {
+				return errors.Wrap(err, "delete request")
+			}
+			purged++
+			// the cursor moves to the next key on delete
+			key, value = cursor.Seek(key)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+func (r *repo) RequestProcessed(ctx context.Context, requestID string) (bool, error) {
+	r.logger.Debugln("RequestProcessed, embedded func", requestID)
+	if ctx.Err() != nil {
+		return false, errorsPkg.ErrTimeout
+	}
+
+	var processed bool
+	err := r.db.View(func(tx *bbolt.Tx) error {
+		processed = tx.Bucket(requestsBucket).Get([]byte(requestID)) != nil
+		return nil
+	})
+	return processed, err
+}
+
+func (r *repo) Close() {
+	if err := r.db.Close(); err != nil {
+		r.logger.Errorln("Embedded storage close", err)
+		return
+	}
+	r.logger.Infoln("Embedded storage closed")
+}
+
+// listByName walks the users in the key order and stops at the page end.
+func listByName(tx *bbolt.Tx, params models.UserListParams, desc bool) ([]models.User, error) {
+	list := make([]models.User, 0)
+	if params.Limit == 0 {
+		return list, nil
+	}
+
+	cursor := tx.Bucket(usersBucket).Cursor()
+	next := cursor.Next
+	if desc {
+		next = cursor.Prev
+	}
+	var key, value []byte
+	switch {
+	case params.After == nil && desc:
+		key, value = cursor.Last()
+	case params.After == nil:
+		key, value = cursor.First()
+	default:
+		after := []byte(params.After.Name)
+		// Seek stops at the first key not less than after
+		key, value = cursor.Seek(after)
+		switch {
+		case desc && key == nil:
+			key, value = cursor.Last()
+		case desc || bytes.Equal(key, after):
+			key, value = next()
+		}
+	}
+
+	var skip uint64
+	if params.After == nil {
+		skip = params.Limit * params.Offset
+	}
+	for ; key != nil && uint64(len(list)) < params.Limit; key, value = next() {
+		var user models.User
+		if err := json.Unmarshal(value, &user); err != nil {
+			return nil, errors.Wrap(err, "unmarshal user")
+		}
+		if user.Deleted() && !params.WithDeleted || !params.Filter.Match(user) {
+			continue
+		}
+		if skip != 0 {
+			skip--
+			continue
+		}
+		list = append(list, user)
+	}
+	return list, nil
+}
+
+// listSorted reads the matching users and sorts them by the params sort fields.
+func listSorted(tx *bbolt.Tx, params models.UserListParams) ([]models.User, error) {
+	list := make([]models.User, 0)
+	if err := tx.Bucket(usersBucket).ForEach(func(_, value []byte) error {
+		var user models.User
+		if err := json.Unmarshal(value, &user); err != nil {
+			return errors.Wrap(err, "unmarshal user")
+		}
+		if user.Deleted() && !params.WithDeleted || !params.Filter.Match(user) {
+			return nil
+		}
+		if params.After != nil && params.Compare(user, *params.After) <= 0 {
+			return nil
+		}
+		list = append(list, user)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return params.Compare(list[i], list[j]) < 0
+	})
+
+	min := uint64(0)
+	if params.After == nil {
+		min = params.Limit * params.Offset
+	}
+	if uint64(len(list)) <= min {
+		return make([]models.User, 0), nil
+	}
+	max := min + params.Limit
+	if uint64(len(list)) < max {
+		return list[min:], nil
+	}
+	return list[min:max], nil
+}
+
+func create(tx *bbolt.Tx, user models.User, requestID string) error {
+	if tx.Bucket(usersBucket).Get([]byte(user.Name)) != nil {
+		return errors.Wrapf(errorsPkg.ErrUserAlreadyExists, "user-name: [%s]", user.Name)
+	}
+	if emailTaken(tx, user.Email, user.Name) {
+		return errors.Wrapf(errorsPkg.ErrEmailAlreadyExists, "email: [%s]", user.Email)
+	}
+
+	user.Version = 1
+	user.CreatedAt = models.Now()
+	user.UpdatedAt = user.CreatedAt
+	return change(tx, models.OperationCreate, nil, user, requestID)
+}
+
+// change stores the user and records the change of the old one in its history.
+func change(tx *bbolt.Tx, operation string, old *models.User, user models.User, requestID string) error {
+	if err := put(tx, user); err != nil {
+		return err
+	}
+	bucket, err := tx.Bucket(historyBucket).CreateBucketIfNotExists([]byte(user.Name))
+	if err != nil {
+		return errors.Wrap(err, "create history bucket")
+	}
+	seq, err := bucket.NextSequence()
+	if err != nil {
+		return errors.Wrap(err, "history sequence")
+	}
+	value, err := json.Marshal(models.NewUserChange(operation, old, user, requestID))
+	if err != nil {
+		return errors.Wrap(err, "marshal change")
+	}
+	return errors.Wrap(bucket.Put(sequenceKey(seq), value), "put change")
+}
+
+// putOutbox adds the message of the change from the context to the outbox, if it is set.
+func putOutbox(ctx context.Context, tx *bbolt.Tx, results []error) error {
+	fn := models.OutboxFromCtx(ctx)
+	if fn == nil {
+		return nil
+	}
+	message, err := fn(results)
+	if err != nil {
+		return errors.Wrap(err, "outbox message")
+	}
+	bucket := tx.Bucket(outboxBucket)
+	if message.ID, err = bucket.NextSequence(); err != nil {
+		return errors.Wrap(err, "outbox sequence")
+	}
+	message.CreatedAt = models.Now()
+	message.RequestID = requestID(ctx)
+	value, err := json.Marshal(message)
+	if err != nil {
+		return errors.Wrap(err, "marshal message")
+	}
+	if err = bucket.Put(sequenceKey(message.ID), value); err != nil {
+		return errors.Wrap(err, "put message")
+	}
+	if message.RequestID == "" {
+		return nil
+	}
+	processed, err := message.CreatedAt.MarshalText()
+	if err != nil {
+		return errors.Wrap(err, "marshal request")
+	}
+	return errors.Wrap(tx.Bucket(requestsBucket).Put([]byte(message.RequestID), processed), "put request")
+}
+
+// checkRequest returns customerrors.ErrRequestProcessed if the request of the change with the outbox message
+// is already processed.
+func checkRequest(ctx context.Context, tx *bbolt.Tx) error {
+	uid := requestID(ctx)
+	if uid == "" || models.OutboxFromCtx(ctx) == nil {
+		return nil
+	}
+	if tx.Bucket(requestsBucket).Get([]byte(uid)) != nil {
+		return errors.Wrapf(errorsPkg.ErrRequestProcessed, "request: [%s]", uid)
+	}
+	return nil
+}
+
+// sequenceKey keeps the keys of the bucket in the sequence order.
+func sequenceKey(seq uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, seq)
+	return key
+}
+
+// put stores the user and indexes its email.
+func put(tx *bbolt.Tx, user models.User) error {
+	value, err := json.Marshal(user)
+	if err != nil {
+		return errors.Wrap(err, "marshal user")
+	}
+	if err = tx.Bucket(usersBucket).Put([]byte(user.Name), value); err != nil {
+		return errors.Wrap(err, "put user")
+	}
+	return errors.Wrap(tx.Bucket(emailsBucket).Put([]byte(user.Email), []byte(user.Name)), "put email")
+}
+
+func get(tx *bbolt.Tx, name string) (models.User, bool, error) {
+	value := tx.Bucket(usersBucket).Get([]byte(name))
+	if value == nil {
+		return models.User{}, false, nil
+	}
+	var user models.User
+	if err := json.Unmarshal(value, &user); err != nil {
+		return models.User{}, false, errors.Wrap(err, "unmarshal user")
+	}
+	return user, true, nil
+}
+
+// requestID is the uid of the request making the change.
+func requestID(ctx context.Context) string {
+	uid, _ := helper.ExtractUidPubFromCtx(ctx)
+	return uid
+}
+
+// emailTaken returns true if the email belongs to another user.
+func emailTaken(tx *bbolt.Tx, email, name string) bool {
+	owner := tx.Bucket(emailsBucket).Get([]byte(email))
+	return owner != nil && string(owner) != name
+}
+
+func checkVersion(tx *bbolt.Tx, name string, version uint64) (models.User, error) {
+	user, ok, err := get(tx, name)
+	if err != nil {
+		return user, err
+	}
+	if !ok || user.Deleted() {
+		return user, errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s]", name)
+	}
+	if version != 0 && user.Version != version {
+		return user, errors.Wrapf(errorsPkg.ErrVersionConflict, "user-name: [%s], version: [%d], expected: [%d]",
+			name, user.Version, version)
+	}
+	return user, nil
+}
@@ -0,0 +1,21 @@
+package embedded
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo/repotest"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+)
+
+func TestEmbedded_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repoPkg.Interface {
+		repo, err := New(Config{Path: filepath.Join(t.TempDir(), "users.db"), NoSync: true}, loggerPkg.NewFatal())
+		require.NoError(t, err)
+		t.Cleanup(repo.Close)
+		return repo
+	})
+}
@@ -0,0 +1,164 @@
+package local
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+)
+
+// Fsync policies of the journal.
+const (
+	SyncAlways   = "always"
+	SyncPeriodic = "periodic"
+	SyncNever    = "never"
+)
+
+const (
+	defaultSyncPeriod       = time.Second
+	defaultSnapshotInterval = 10 * time.Minute
+)
+
+// Config of the durable local storage. The mutations are appended to the journal in Dir
+// and compacted into a snapshot every SnapshotInterval. With SyncAlways the mutation returns
+// after the journal is synced, with SyncPeriodic the journal is synced every SyncPeriod,
+// with SyncNever syncing is left to the OS.
+type Config struct {
+	Dir              string        `mapstructure:"dir"`
+	Sync             string        `mapstructure:"sync"`
+	SyncPeriod       time.Duration `mapstructure:"sync_period"`
+	SnapshotInterval time.Duration `mapstructure:"snapshot_interval"`
+}
+
+// NewDurable restores the storage from the snapshot and the journal in cfg.Dir
+// and keeps them up to date.
+func NewDurable(workersCount int, cfg Config, logger *zap.SugaredLogger) (repoPkg.Interface, error) {
+	switch cfg.Sync {
+	case "":
+		cfg.Sync = SyncAlways
+	case SyncAlways, SyncPeriodic, SyncNever:
+	default:
+		return nil, errors.Errorf("unknown sync policy: [%s]", cfg.Sync)
+	}
+	if cfg.SyncPeriod <= 0 {
+		cfg.SyncPeriod = defaultSyncPeriod
+	}
+	if cfg.SnapshotInterval <= 0 {
+		cfg.SnapshotInterval = defaultSnapshotInterval
+	}
+
+	c := &cache{
+		mu:        sync.RWMutex{},
+		data:      make(map[string]models.User),
+		emails:    make(map[string]string),
+		history:   make(map[string][]models.UserChange),
+		outbox:    make([]models.OutboxMessage, 0),
+		processed: make(map[string]time.Time),
+		poolCh:    make(chan struct{}, workersCount),
+		logger:    logger,
+		stop:      make(chan struct{}),
+	}
+
+	var err error
+	c.journal, err = openJournal(cfg.Dir, cfg.Sync, c.apply)
+	if err != nil {
+		return nil, errors.WithMessage(err, "journal recovery")
+	}
+	logger.Infoln("With durable local storage started, users recovered:", len(c.data))
+
+	c.wg.Add(1)
+	go c.snapshotLoop(cfg.SnapshotInterval)
+	if cfg.Sync == SyncPeriodic {
+		c.wg.Add(1)
+		go c.syncLoop(cfg.SyncPeriod)
+	}
+	return c, nil
+}
+
+// apply restores the logged mutation.
+func (c *cache) apply(rec record) {
+	for _, batched := range rec.Batch {
+		c.apply(batched)
+	}
+	if rec.Change != nil {
+		c.history[rec.Name] = append(c.history[rec.Name], *rec.Change)
+	}
+	if rec.Outbox != nil {
+		c.addOutbox(*rec.Outbox)
+	}
+	if len(rec.Published) != 0 {
+		c.removeOutbox(rec.Published)
+	}
+	for uid, processed := range rec.Processed {
+		c.processed[uid] = processed
+	}
+	switch {
+	case rec.User != nil:
+		c.setUser(*rec.User)
+	case rec.Name != "" && rec.Change == nil:
+		c.deleteUser(rec.Name)
+	}
+}
+
+func (c *cache) snapshotLoop(interval time.Duration) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			if err := c.compact(); err != nil {
+				c.logger.Errorln("Snapshot", err)
+			}
+		}
+	}
+}
+
+func (c *cache) syncLoop(period time.Duration) {
+	defer c.wg.Done()
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+			if err := c.journal.flush(); err != nil {
+				c.logger.Errorln("Journal sync", err)
+			}
+		}
+	}
+}
+
+// compact starts the next journal generation and stores the snapshot of the storage for it,
+// the mutations are blocked only while the users, their history and the outbox are copied.
+func (c *cache) compact() error {
+	c.mu.Lock()
+	snap := snapshot{
+		Users:     make([]models.User, 0, len(c.data)),
+		History:   make([]models.UserChange, 0),
+		Outbox:    append([]models.OutboxMessage(nil), c.outbox...),
+		Processed: make(map[string]time.Time, len(c.processed)),
+	}
+	for uid, processed := range c.processed {
+		snap.Processed[uid] = processed
+	}
+	for _, user := range c.data {
+		snap.Users = append(snap.Users, user)
+	}
+	for _, changes := range c.history {
+		snap.History = append(snap.History, changes...)
+	}
+	gen, err := c.journal.rotate()
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.journal.compact(gen, snap)
+}
@@ -0,0 +1,335 @@
+package local
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+)
+
+var (
+	user5 = models.User{
+		Name:      "Clara",
+		Password:  "456",
+		Email:     "clara@email.com",
+		FullName:  "Clara the Last",
+		CreatedAt: time.Unix(1660412990, 0),
+	}
+	user6 = models.User{
+		Name:      "Dora",
+		Password:  "654",
+		Email:     "dora@email.com",
+		FullName:  "Dora the Explorer",
+		CreatedAt: time.Unix(1660412990, 0),
+	}
+)
+
+func newDurable(t *testing.T, cfg Config) repoPkg.Interface {
+	t.Helper()
+	repo, err := NewDurable(1, cfg, loggerPkg.NewFatal())
+	require.NoError(t, err)
+	return repo
+}
+
+// crash stops the background loops and drops the storage without the final snapshot.
+func crash(repo repoPkg.Interface) {
+	c := repo.(*cache)
+	close(c.stop)
+	c.wg.Wait()
+	_ = c.journal.file.Close()
+}
+
+// withOutbox returns the context of the change writing the message keyed by name to the outbox.
+func withOutbox(name string) context.Context {
+	return models.WithOutbox(context.Background(), func([]error) (models.OutboxMessage, error) {
+		return models.OutboxMessage{Topic: "mailing", Key: name}, nil
+	})
+}
+
+// fill creates user1 and user3, updates user1 email, deletes user3 and creates user4.
+// The message of user1 is published, the message of user4 is pending.
+func fill(t *testing.T, repo repoPkg.Interface) {
+	t.Helper()
+	ctx := context.Background()
+	require.NoError(t, repo.UserCreate(withOutbox(user1.Name), user1))
+	require.NoError(t, repo.OutboxMarkPublished(ctx, []uint64{1}))
+	require.NoError(t, repo.UserCreate(ctx, user3))
+	email := user2.Email
+	require.NoError(t, repo.UserUpdate(ctx, models.Profile{Name: user1.Name, Email: &email}))
+	require.NoError(t, repo.UserDelete(ctx, user3.Name, 0))
+	require.NoError(t, repo.UserCreate(withOutbox(user4.Name), user4))
+}
+
+func assertFilled(t *testing.T, repo repoPkg.Interface) {
+	t.Helper()
+	ctx := context.Background()
+
+	user, err := repo.UserGetByEmail(ctx, user2.Email, false)
+	require.NoError(t, err)
+	assert.Equal(t, user1.Name, user.Name)
+	assert.Equal(t, uint64(2), user.Version)
+
+	_, err = repo.UserGetByEmail(ctx, user1.Email, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+
+	_, err = repo.UserGet(ctx, user3.Name, false)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+	deleted, err := repo.UserGet(ctx, user3.Name, true)
+	require.NoError(t, err)
+	assert.True(t, deleted.Deleted())
+
+	_, err = repo.UserGet(ctx, user4.Name, false)
+	assert.NoError(t, err)
+
+	changes, err := repo.UserHistory(ctx, user1.Name)
+	require.NoError(t, err)
+	require.Len(t, changes, 2)
+	assert.Equal(t, models.OperationUpdate, changes[1].Operation)
+	assert.Equal(t, user1.Email, changes[1].Old.Email)
+	changes, err = repo.UserHistory(ctx, user3.Name)
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+
+	messages, err := repo.OutboxList(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, uint64(2), messages[0].ID)
+	assert.Equal(t, user4.Name, messages[0].Key)
+}
+
+func TestDurable_Recovery(t *testing.T) {
+	t.Run("after close", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := newDurable(t, Config{Dir: dir})
+		fill(t, repo)
+		repo.Close()
+
+		repo = newDurable(t, Config{Dir: dir})
+		defer repo.Close()
+		assertFilled(t, repo)
+	})
+	t.Run("after crash", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := newDurable(t, Config{Dir: dir})
+		fill(t, repo)
+		crash(repo)
+
+		repo = newDurable(t, Config{Dir: dir})
+		defer repo.Close()
+		assertFilled(t, repo)
+	})
+	t.Run("crash during compaction", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := newDurable(t, Config{Dir: dir})
+		ctx := context.Background()
+		require.NoError(t, repo.UserCreate(withOutbox(user1.Name), user1))
+		require.NoError(t, repo.UserCreate(ctx, user3))
+		// the journal is rotated, but the snapshot is not stored
+		_, err := repo.(*cache).journal.rotate()
+		require.NoError(t, err)
+		email := user2.Email
+		require.NoError(t, repo.UserUpdate(ctx, models.Profile{Name: user1.Name, Email: &email}))
+		require.NoError(t, repo.OutboxMarkPublished(ctx, []uint64{1}))
+		require.NoError(t, repo.UserDelete(ctx, user3.Name, 0))
+		require.NoError(t, repo.UserCreate(withOutbox(user4.Name), user4))
+		crash(repo)
+
+		repo = newDurable(t, Config{Dir: dir})
+		defer repo.Close()
+		assertFilled(t, repo)
+	})
+	t.Run("torn record", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := newDurable(t, Config{Dir: dir})
+		fill(t, repo)
+		require.NoError(t, repo.UserCreate(context.Background(), user5))
+		crash(repo)
+
+		name := filepath.Join(dir, fileName(walPrefix, 0, walSuffix))
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(name, info.Size()-3))
+
+		repo = newDurable(t, Config{Dir: dir})
+		assertFilled(t, repo)
+		_, err = repo.UserGet(context.Background(), user5.Name, true)
+		assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+
+		// the torn record is dropped, so the journal is appendable
+		require.NoError(t, repo.UserCreate(context.Background(), user5))
+		crash(repo)
+		repo = newDurable(t, Config{Dir: dir})
+		defer repo.Close()
+		_, err = repo.UserGet(context.Background(), user5.Name, false)
+		assert.NoError(t, err)
+	})
+	t.Run("torn batch", func(t *testing.T) {
+		dir := t.TempDir()
+		repo := newDurable(t, Config{Dir: dir})
+		fill(t, repo)
+		res, err := repo.UserCreateBatch(withOutbox(user5.Name), []models.User{user5, user6})
+		require.NoError(t, err)
+		assert.Equal(t, []error{nil, nil}, res)
+		crash(repo)
+
+		name := filepath.Join(dir, fileName(walPrefix, 0, walSuffix))
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		require.NoError(t, os.Truncate(name, info.Size()-3))
+
+		// the batch is one record, so none of its users and not its message are recovered
+		repo = newDurable(t, Config{Dir: dir})
+		defer repo.Close()
+		assertFilled(t, repo)
+		for _, user := range []models.User{user5, user6} {
+			_, err = repo.UserGet(context.Background(), user.Name, true)
+			assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+		}
+	})
+}
+
+// appendRaw appends the record with the payload and the length to the log.
+func appendRaw(t *testing.T, name string, size uint32, payload []byte) {
+	t.Helper()
+	buf := make([]byte, recordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:4], size)
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(payload))
+	copy(buf[recordHeaderSize:], payload)
+	file, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = file.Write(buf)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
+
+func TestDurable_DamagedLog(t *testing.T) {
+	older := func(dir string) string { return filepath.Join(dir, fileName(walPrefix, 0, walSuffix)) }
+	last := func(dir string) string { return filepath.Join(dir, fileName(walPrefix, 1, walSuffix)) }
+
+	cases := []struct {
+		name   string
+		damage func(t *testing.T, dir string)
+	}{
+		{
+			name: "checksum mismatch",
+			damage: func(t *testing.T, dir string) {
+				data, err := os.ReadFile(last(dir))
+				require.NoError(t, err)
+				data[len(data)-2] ^= 0xff
+				require.NoError(t, os.WriteFile(last(dir), data, 0o600))
+			},
+		},
+		{
+			name: "record of unknown format",
+			damage: func(t *testing.T, dir string) {
+				payload := []byte(`{"name":"Clara","user":{"name":"Clara","created_at":1660412990}}`)
+				appendRaw(t, last(dir), uint32(len(payload)), payload)
+			},
+		},
+		{
+			name: "size over the limit",
+			damage: func(t *testing.T, dir string) {
+				appendRaw(t, last(dir), maxRecordSize+1, []byte("{}"))
+			},
+		},
+		{
+			name: "incomplete record of the older log",
+			damage: func(t *testing.T, dir string) {
+				info, err := os.Stat(older(dir))
+				require.NoError(t, err)
+				require.NoError(t, os.Truncate(older(dir), info.Size()-3))
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			dir := t.TempDir()
+			repo := newDurable(t, Config{Dir: dir})
+			fill(t, repo)
+			_, err := repo.(*cache).journal.rotate()
+			require.NoError(t, err)
+			require.NoError(t, repo.UserCreate(context.Background(), user5))
+			crash(repo)
+
+			c.damage(t, dir)
+
+			_, err = NewDurable(1, Config{Dir: dir}, loggerPkg.NewFatal())
+			assert.ErrorIs(t, err, errCorruptLog)
+		})
+	}
+}
+
+func TestDurable_FailedWrite(t *testing.T) {
+	repo := newDurable(t, Config{Dir: t.TempDir()})
+	defer crash(repo)
+	ctx := context.Background()
+	j := repo.(*cache).journal
+
+	// neither written nor repaired, so the journal is not written after it
+	require.NoError(t, j.file.Close())
+	assert.Error(t, repo.UserCreate(ctx, user1))
+	assert.Error(t, j.failed)
+
+	file, size, err := openLog(j.dir, j.gen)
+	require.NoError(t, err)
+	j.file = file
+	assert.Equal(t, j.size, size)
+	assert.Error(t, repo.UserCreate(ctx, user3))
+	_, err = j.rotate()
+	assert.Error(t, err)
+}
+
+func TestDurable_Snapshot(t *testing.T) {
+	dir := t.TempDir()
+	repo := newDurable(t, Config{Dir: dir, Sync: SyncPeriodic, SyncPeriod: time.Millisecond, SnapshotInterval: 10 * time.Millisecond})
+	fill(t, repo)
+
+	assert.Eventually(t, func() bool {
+		snapshots, wals, err := listGenerations(dir)
+		return err == nil && len(snapshots) == 1 && snapshots[0] > 0 && len(wals) == 1 && wals[0] == snapshots[0]
+	}, time.Second, 5*time.Millisecond)
+	crash(repo)
+
+	repo = newDurable(t, Config{Dir: dir})
+	defer repo.Close()
+	assertFilled(t, repo)
+}
+
+func TestDurable_ProcessedRequests(t *testing.T) {
+	dir := t.TempDir()
+	repo := newDurable(t, Config{Dir: dir})
+	ctx := context.Background()
+	request := models.WithOutbox(helper.InjectUidPubToCtx(ctx, "uid-1", "pub"),
+		func([]error) (models.OutboxMessage, error) {
+			return models.OutboxMessage{Topic: "mailing", Key: user1.Name}, nil
+		})
+	require.NoError(t, repo.UserCreate(request, user1))
+	require.NoError(t, repo.OutboxMarkPublished(ctx, []uint64{1}))
+	// the request of the published message is kept by the snapshot
+	repo.Close()
+
+	repo = newDurable(t, Config{Dir: dir})
+	defer repo.Close()
+	processed, err := repo.RequestProcessed(ctx, "uid-1")
+	require.NoError(t, err)
+	assert.True(t, processed)
+	assert.ErrorIs(t, repo.UserCreate(request, user1), errorsPkg.ErrRequestProcessed)
+}
+
+func TestNewDurable(t *testing.T) {
+	_, err := NewDurable(1, Config{Dir: t.TempDir(), Sync: "sometimes"}, loggerPkg.NewFatal())
+	assert.Error(t, err)
+}
@@ -0,0 +1,426 @@
+package local
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+)
+
+const (
+	walPrefix      = "wal-"
+	walSuffix      = ".log"
+	snapshotPrefix = "snapshot-"
+	snapshotSuffix = ".json"
+	tmpSuffix      = ".tmp"
+
+	// recordHeaderSize is the payload length and its CRC32
+	recordHeaderSize = 8
+	// maxRecordSize limits the payload, the larger length is read from a damaged header
+	maxRecordSize = 16 << 20
+)
+
+// errCorruptLog is returned when the journal is damaged other than by the torn write at its end.
+var errCorruptLog = errors.New("corrupt log")
+
+// record is the logged mutation, the user is stored as a whole with the change recorded in its history.
+// The record with Name only removes the user, the history is kept. Outbox is the message added to the outbox,
+// Published are the ids of the messages removed from it. Batch are the records applied together.
+type record struct {
+	Name      string                `json:"name,omitempty"`
+	User      *models.User          `json:"user,omitempty"`
+	Change    *models.UserChange    `json:"change,omitempty"`
+	Outbox    *models.OutboxMessage `json:"outbox,omitempty"`
+	Published []uint64              `json:"published,omitempty"`
+	// Processed are the requests restored from the snapshot
+	Processed map[string]time.Time `json:"processed,omitempty"`
+	Batch     []record             `json:"batch,omitempty"`
+}
+
+// snapshot is the state of the storage at the start of the log generation.
+type snapshot struct {
+	Users   []models.User          `json:"users"`
+	History []models.UserChange    `json:"history,omitempty"`
+	Outbox  []models.OutboxMessage `json:"outbox,omitempty"`
+	// Processed are the requests of the published messages, the pending ones are restored with the outbox
+	Processed map[string]time.Time `json:"processed,omitempty"`
+}
+
+// journal is the write-ahead log of the storage split into generations:
+// the log of the generation holds the mutations made after the snapshot of the generation.
+type journal struct {
+	mu    sync.Mutex
+	dir   string
+	sync  string
+	gen   uint64
+	file  *os.File
+	size  int64
+	dirty bool
+	// failed is the error of the write the log was not repaired after, the log is not written after it
+	failed error
+}
+
+// openJournal loads the latest snapshot and replays the logs written after it,
+// the torn record at the end of the last log is dropped. The data is passed to apply in order.
+func openJournal(dir, syncPolicy string, apply func(record)) (*journal, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrap(err, "create dir")
+	}
+	if err := removeTemporary(dir); err != nil {
+		return nil, err
+	}
+	snapshots, wals, err := listGenerations(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var gen uint64
+	if len(snapshots) != 0 {
+		gen = snapshots[len(snapshots)-1]
+		if err = readSnapshot(filepath.Join(dir, fileName(snapshotPrefix, gen, snapshotSuffix)), apply); err != nil {
+			return nil, err
+		}
+	}
+	for i, walGen := range wals {
+		if walGen < gen {
+			continue
+		}
+		last := i == len(wals)-1
+		if err = replay(filepath.Join(dir, fileName(walPrefix, walGen, walSuffix)), last, apply); err != nil {
+			return nil, err
+		}
+		gen = walGen
+	}
+
+	j := &journal{
+		dir:  dir,
+		sync: syncPolicy,
+		gen:  gen,
+	}
+	if j.file, j.size, err = openLog(dir, gen); err != nil {
+		return nil, err
+	}
+	if err = j.removeBefore(gen); err != nil {
+		_ = j.file.Close()
+		return nil, err
+	}
+	return j, nil
+}
+
+// append writes the record, with SyncAlways the record is on disk when append returns.
+func (j *journal) append(rec record) error {
+	payload, err := json.Marshal(rec)
+	if err != nil {
+		return errors.Wrap(err, "marshal record")
+	}
+	buf := make([]byte, recordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(payload))
+	copy(buf[recordHeaderSize:], payload)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.failed != nil {
+		return errors.WithMessage(j.failed, "journal unusable")
+	}
+	if _, err = j.file.Write(buf); err != nil {
+		// the partial record is cut off, so the next record follows the last whole one
+		if truncErr := j.file.Truncate(j.size); truncErr != nil {
+			j.failed = errors.Wrap(truncErr, "truncate partial record")
+		}
+		return errors.Wrap(err, "write record")
+	}
+	j.size += int64(len(buf))
+	if j.sync == SyncAlways {
+		return j.syncLocked()
+	}
+	j.dirty = true
+	return nil
+}
+
+// syncLocked syncs the log, the written records are not known to be on disk after the failed sync,
+// so the log is not written after it.
+func (j *journal) syncLocked() error {
+	if err := j.file.Sync(); err != nil {
+		j.failed = errors.Wrap(err, "sync log")
+		return j.failed
+	}
+	return nil
+}
+
+// flush syncs the records written after the previous flush.
+func (j *journal) flush() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.flushLocked()
+}
+
+func (j *journal) flushLocked() error {
+	if !j.dirty || j.sync == SyncNever {
+		return nil
+	}
+	j.dirty = false
+	return j.syncLocked()
+}
+
+// rotate starts the log of the next generation and returns the generation.
+func (j *journal) rotate() (uint64, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.flushLocked(); err != nil {
+		return 0, err
+	}
+	if j.failed != nil {
+		return 0, errors.WithMessage(j.failed, "journal unusable")
+	}
+	file, size, err := openLog(j.dir, j.gen+1)
+	if err != nil {
+		return 0, err
+	}
+	if err = j.file.Close(); err != nil {
+		_ = file.Close()
+		return 0, errors.Wrap(err, "close log")
+	}
+	j.file = file
+	j.size = size
+	j.gen++
+	return j.gen, nil
+}
+
+// compact stores the snapshot of the generation and removes the files of the previous generations.
+func (j *journal) compact(gen uint64, snap snapshot) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return errors.Wrap(err, "marshal snapshot")
+	}
+	name := filepath.Join(j.dir, fileName(snapshotPrefix, gen, snapshotSuffix))
+	if err = writeFileSync(name, data); err != nil {
+		return err
+	}
+	return j.removeBefore(gen)
+}
+
+func (j *journal) close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := j.flushLocked(); err != nil {
+		return err
+	}
+	return errors.Wrap(j.file.Close(), "close log")
+}
+
+// removeBefore removes the snapshots and the logs older than the generation.
+func (j *journal) removeBefore(gen uint64) error {
+	snapshots, wals, err := listGenerations(j.dir)
+	if err != nil {
+		return err
+	}
+	for _, old := range snapshots {
+		if old < gen {
+			if err = os.Remove(filepath.Join(j.dir, fileName(snapshotPrefix, old, snapshotSuffix))); err != nil {
+				return errors.Wrap(err, "remove snapshot")
+			}
+		}
+	}
+	for _, old := range wals {
+		if old < gen {
+			if err = os.Remove(filepath.Join(j.dir, fileName(walPrefix, old, walSuffix))); err != nil {
+				return errors.Wrap(err, "remove log")
+			}
+		}
+	}
+	return nil
+}
+
+// replay passes the records of the log to apply. The incomplete record at the end of the last log
+// was not written completely before the crash, so it is truncated, any other damage fails the replay.
+func replay(name string, last bool, apply func(record)) error {
+	file, err := os.OpenFile(name, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Wrap(err, "open log")
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := bufio.NewReader(file)
+	header := make([]byte, recordHeaderSize)
+	var offset int64
+	for {
+		if _, err = io.ReadFull(reader, header); err != nil {
+			break
+		}
+		size := binary.LittleEndian.Uint32(header[0:4])
+		if size > maxRecordSize {
+			return errors.Wrapf(errCorruptLog, "[%s] record at [%d]: size [%d] exceeds the limit", name, offset, size)
+		}
+		payload := make([]byte, size)
+		if _, err = io.ReadFull(reader, payload); err != nil {
+			break
+		}
+		if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header[4:8]) {
+			return errors.Wrapf(errCorruptLog, "[%s] record at [%d]: checksum mismatch", name, offset)
+		}
+		var rec record
+		if err = json.Unmarshal(payload, &rec); err != nil {
+			return errors.Wrapf(errCorruptLog, "[%s] record at [%d]: %v", name, offset, err)
+		}
+		apply(rec)
+		offset += int64(recordHeaderSize + len(payload))
+	}
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		return errors.Wrap(err, "read log")
+	}
+	if !last {
+		return errors.Wrapf(errCorruptLog, "[%s] record at [%d]: incomplete", name, offset)
+	}
+
+	if err = file.Truncate(offset); err != nil {
+		return errors.Wrap(err, "truncate torn log")
+	}
+	return errors.Wrap(file.Sync(), "sync truncated log")
+}
+
+func readSnapshot(name string, apply func(record)) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return errors.Wrap(err, "read snapshot")
+	}
+	var snap snapshot
+	if err = json.Unmarshal(data, &snap); err != nil {
+		return errors.Wrap(err, "unmarshal snapshot")
+	}
+	for i := range snap.Users {
+		apply(record{Name: snap.Users[i].Name, User: &snap.Users[i]})
+	}
+	for i := range snap.History {
+		apply(record{Name: snap.History[i].Name, Change: &snap.History[i]})
+	}
+	apply(record{Processed: snap.Processed})
+	for i := range snap.Outbox {
+		apply(record{Outbox: &snap.Outbox[i]})
+	}
+	return nil
+}
+
+// listGenerations returns the sorted generations of the snapshots and the logs in the dir.
+func listGenerations(dir string) ([]uint64, []uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "read dir")
+	}
+	snapshots := make([]uint64, 0)
+	wals := make([]uint64, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		var gen uint64
+		switch {
+		case parseFileName(name, snapshotPrefix, snapshotSuffix, &gen):
+			snapshots = append(snapshots, gen)
+		case parseFileName(name, walPrefix, walSuffix, &gen):
+			wals = append(wals, gen)
+		}
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i] < snapshots[j] })
+	sort.Slice(wals, func(i, j int) bool { return wals[i] < wals[j] })
+	return snapshots, wals, nil
+}
+
+// removeTemporary removes the snapshots left incomplete by a crash.
+func removeTemporary(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return errors.Wrap(err, "read dir")
+	}
+	for _, entry := range entries {
+		if strings.HasSuffix(entry.Name(), tmpSuffix) {
+			if err = os.Remove(filepath.Join(dir, entry.Name())); err != nil {
+				return errors.Wrap(err, "remove temporary file")
+			}
+		}
+	}
+	return nil
+}
+
+func fileName(prefix string, gen uint64, suffix string) string {
+	return fmt.Sprintf("%s%020d%s", prefix, gen, suffix)
+}
+
+func parseFileName(name, prefix, suffix string, gen *uint64) bool {
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return false
+	}
+	_, err := fmt.Sscanf(strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix), "%d", gen)
+	return err == nil
+}
+
+// openLog opens the log of the generation for appending and returns its size.
+func openLog(dir string, gen uint64) (*os.File, int64, error) {
+	file, err := os.OpenFile(filepath.Join(dir, fileName(walPrefix, gen, walSuffix)),
+		os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "open log")
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, 0, errors.Wrap(err, "stat log")
+	}
+	if err = syncDir(dir); err != nil {
+		_ = file.Close()
+		return nil, 0, err
+	}
+	return file, info.Size(), nil
+}
+
+// writeFileSync replaces the file atomically, the data is on disk when it returns.
+func writeFileSync(name string, data []byte) error {
+	tmp := name + tmpSuffix
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return errors.Wrap(err, "create temporary file")
+	}
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "write temporary file")
+	}
+	if err = file.Sync(); err != nil {
+		_ = file.Close()
+		return errors.Wrap(err, "sync temporary file")
+	}
+	if err = file.Close(); err != nil {
+		return errors.Wrap(err, "close temporary file")
+	}
+	if err = os.Rename(tmp, name); err != nil {
+		return errors.Wrap(err, "rename temporary file")
+	}
+	return syncDir(filepath.Dir(name))
+}
+
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return errors.Wrap(err, "open dir")
+	}
+	defer func() {
+		_ = d.Close()
+	}()
+	return errors.Wrap(d.Sync(), "sync dir")
+}
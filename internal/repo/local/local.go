@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
@@ -11,23 +12,42 @@ import (
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
 	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
 )
 
 func New(workersCount int, logger *zap.SugaredLogger) repoPkg.Interface {
 	logger.Infoln("With local storage started")
 	return &cache{
-		mu:     sync.RWMutex{},
-		data:   make(map[string]models.User),
-		poolCh: make(chan struct{}, workersCount),
-		logger: logger,
+		mu:        sync.RWMutex{},
+		data:      make(map[string]models.User),
+		emails:    make(map[string]string),
+		history:   make(map[string][]models.UserChange),
+		outbox:    make([]models.OutboxMessage, 0),
+		processed: make(map[string]time.Time),
+		poolCh:    make(chan struct{}, workersCount),
+		logger:    logger,
 	}
 }
 
 type cache struct {
-	mu     sync.RWMutex
-	data   map[string]models.User
-	poolCh chan struct{}
-	logger *zap.SugaredLogger
+	mu   sync.RWMutex
+	data map[string]models.User
+	// emails indexes the user names by email, deleted users keep their emails until purged
+	emails map[string]string
+	// history holds the changes of every user in order, it is kept after the user is purged
+	history map[string][]models.UserChange
+	// outbox holds the not published messages in order, outboxSeq is the id of the last one
+	outbox    []models.OutboxMessage
+	outboxSeq uint64
+	// processed holds the time the requests with the outbox messages were processed by their uids
+	processed map[string]time.Time
+	poolCh    chan struct{}
+	logger    *zap.SugaredLogger
+
+	// journal is nil for the in-memory storage
+	journal *journal
+	stop    chan struct{}
+	wg      sync.WaitGroup
 }
 
 func (c *cache) UserCreate(ctx context.Context, user models.User) error {
@@ -42,13 +62,87 @@ func (c *cache) UserCreate(ctx context.Context, user models.User) error {
 			<-c.poolCh
 		}()
 
-		c.data[user.Name] = user
-		return nil
+		if err := c.checkRequest(ctx); err != nil {
+			return err
+		}
+
+		if _, ok := c.data[user.Name]; ok {
+			return errors.Wrapf(errorsPkg.ErrUserAlreadyExists, "user-name: [%s]", user.Name)
+		}
+		if c.emailTaken(user.Email, user.Name) {
+			return errors.Wrapf(errorsPkg.ErrEmailAlreadyExists, "email: [%s]", user.Email)
+		}
+
+		message, err := c.outboxMessage(ctx, nil)
+		if err != nil {
+			return err
+		}
+		user.Version = 1
+		user.CreatedAt = models.Now()
+		user.UpdatedAt = user.CreatedAt
+		return c.putUser(ctx, models.OperationCreate, nil, user, message)
+	}
+}
+
+func (c *cache) UserCreateBatch(ctx context.Context, users []models.User) ([]error, error) {
+	c.logger.Debugln("UserCreateBatch, cached func", len(users))
+	select {
+	case <-ctx.Done():
+		return nil, errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.Lock()
+		defer func() {
+			c.mu.Unlock()
+			<-c.poolCh
+		}()
+
+		if err := c.checkRequest(ctx); err != nil {
+			return nil, err
+		}
+
+		res := make([]error, len(users))
+		created := make([]models.User, 0, len(users))
+		names, emails := make(map[string]bool), make(map[string]bool)
+		for i, user := range users {
+			if _, ok := c.data[user.Name]; ok || names[user.Name] {
+				res[i] = errors.Wrapf(errorsPkg.ErrUserAlreadyExists, "user-name: [%s]", user.Name)
+				continue
+			}
+			if c.emailTaken(user.Email, user.Name) || emails[user.Email] {
+				res[i] = errors.Wrapf(errorsPkg.ErrEmailAlreadyExists, "email: [%s]", user.Email)
+				continue
+			}
+			names[user.Name], emails[user.Email] = true, true
+			created = append(created, user)
+		}
+		message, err := c.outboxMessage(ctx, res)
+		if err != nil {
+			return nil, err
+		}
+
+		// the users and the message are logged as one record, so a partial batch is never recovered
+		batch := make([]record, 0, len(created)+1)
+		for _, user := range created {
+			user.Version = 1
+			user.CreatedAt = models.Now()
+			user.UpdatedAt = user.CreatedAt
+			batch = append(batch, userRecord(ctx, models.OperationCreate, nil, user))
+		}
+		if message != nil {
+			batch = append(batch, record{Outbox: message})
+		}
+		if err = c.commit(record{Batch: batch}); err != nil {
+			return nil, err
+		}
+		return res, nil
 	}
 }
 
-func (c *cache) UserUpdate(ctx context.Context, user models.User) error {
-	c.logger.Debugln("UserUpdate, cached func", user.String())
+func (c *cache) UserUpdate(ctx context.Context, profile models.Profile) error {
+	c.logger.Debugln("UserUpdate, cached func", profile.String())
+	if profile.Empty() {
+		return errors.Wrap(errorsPkg.ErrValidation, "nothing to update")
+	}
 	select {
 	case <-ctx.Done():
 		return errorsPkg.ErrTimeout
@@ -59,24 +153,32 @@ func (c *cache) UserUpdate(ctx context.Context, user models.User) error {
 			<-c.poolCh
 		}()
 
-		u := c.data[user.Name]
-		if user.Email != "" {
-			u.Email = user.Email
+		if err := c.checkRequest(ctx); err != nil {
+			return err
 		}
-		if user.Password != "" {
-			u.Password = user.Password
+
+		user, err := c.checkVersion(profile.Name, profile.Version)
+		if err != nil {
+			return err
 		}
-		if user.FullName != "" {
-			u.FullName = user.FullName
+		if profile.Email != nil && c.emailTaken(*profile.Email, profile.Name) {
+			return errors.Wrapf(errorsPkg.ErrEmailAlreadyExists, "email: [%s]", *profile.Email)
 		}
 
-		c.data[user.Name] = u
-		return nil
+		message, err := c.outboxMessage(ctx, nil)
+		if err != nil {
+			return err
+		}
+		old := user
+		user = profile.Apply(user)
+		user.Version++
+		user.UpdatedAt = models.Now()
+		return c.putUser(ctx, models.OperationUpdate, &old, user, message)
 	}
 }
 
-func (c *cache) UserDelete(ctx context.Context, name string) error {
-	c.logger.Debugln("UserDelete, cached func", name)
+func (c *cache) UserDelete(ctx context.Context, name string, version uint64) error {
+	c.logger.Debugln("UserDelete, cached func", name, version)
 	select {
 	case <-ctx.Done():
 		return errorsPkg.ErrTimeout
@@ -87,13 +189,88 @@ func (c *cache) UserDelete(ctx context.Context, name string) error {
 			<-c.poolCh
 		}()
 
-		delete(c.data, name)
-		return nil
+		if err := c.checkRequest(ctx); err != nil {
+			return err
+		}
+
+		user, err := c.checkVersion(name, version)
+		if err != nil {
+			return err
+		}
+
+		message, err := c.outboxMessage(ctx, nil)
+		if err != nil {
+			return err
+		}
+		old := user
+		user.DeletedAt = time.Now().Unix()
+		user.Version++
+		user.UpdatedAt = models.Now()
+		return c.putUser(ctx, models.OperationDelete, &old, user, message)
+	}
+}
+
+func (c *cache) UserRestore(ctx context.Context, name string) error {
+	c.logger.Debugln("UserRestore, cached func", name)
+	select {
+	case <-ctx.Done():
+		return errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.Lock()
+		defer func() {
+			c.mu.Unlock()
+			<-c.poolCh
+		}()
+
+		if err := c.checkRequest(ctx); err != nil {
+			return err
+		}
+
+		user, ok := c.data[name]
+		if !ok || !user.Deleted() {
+			return errors.Wrapf(errorsPkg.ErrUserNotFound, "deleted user-name: [%s]", name)
+		}
+
+		message, err := c.outboxMessage(ctx, nil)
+		if err != nil {
+			return err
+		}
+		old := user
+		user.DeletedAt = 0
+		user.Version++
+		user.UpdatedAt = models.Now()
+		return c.putUser(ctx, models.OperationRestore, &old, user, message)
+	}
+}
+
+func (c *cache) UserPurge(ctx context.Context, retention time.Duration) (int64, error) {
+	c.logger.Debugln("UserPurge, cached func", retention)
+	select {
+	case <-ctx.Done():
+		return 0, errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.Lock()
+		defer func() {
+			c.mu.Unlock()
+			<-c.poolCh
+		}()
+
+		before := time.Now().Add(-retention).Unix()
+		var purged int64
+		for name, user := range c.data {
+			if user.Deleted() && user.DeletedAt < before {
+				if err := c.removeUser(name); err != nil {
+					return purged, err
+				}
+				purged++
+			}
+		}
+		return purged, nil
 	}
 }
 
-func (c *cache) UserGet(ctx context.Context, name string) (models.User, error) {
-	c.logger.Debugln("UserGet, cached func", name)
+func (c *cache) UserGet(ctx context.Context, name string, withDeleted bool) (models.User, error) {
+	c.logger.Debugln("UserGet, cached func", name, withDeleted)
 	select {
 	case <-ctx.Done():
 		return models.User{}, errorsPkg.ErrTimeout
@@ -104,19 +281,19 @@ func (c *cache) UserGet(ctx context.Context, name string) (models.User, error) {
 			<-c.poolCh
 		}()
 
-		if user, ok := c.data[name]; !ok {
-			return user, errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s]", name)
+		if user, ok := c.data[name]; !ok || (user.Deleted() && !withDeleted) {
+			return models.User{}, errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s]", name)
 		} else {
 			return user, nil
 		}
 	}
 }
 
-func (c *cache) UserList(ctx context.Context, order bool, limit, offset uint64) ([]models.User, error) {
-	c.logger.Debugln("UserList, cached func", order, limit, offset)
+func (c *cache) UserGetByEmail(ctx context.Context, email string, withDeleted bool) (models.User, error) {
+	c.logger.Debugln("UserGetByEmail, cached func", email, withDeleted)
 	select {
 	case <-ctx.Done():
-		return nil, errorsPkg.ErrTimeout
+		return models.User{}, errorsPkg.ErrTimeout
 	case c.poolCh <- struct{}{}:
 		c.mu.RLock()
 		defer func() {
@@ -124,36 +301,343 @@ func (c *cache) UserList(ctx context.Context, order bool, limit, offset uint64)
 			<-c.poolCh
 		}()
 
-		if len(c.data) < int(limit*offset) {
-			return make([]models.User, 0), nil
+		name, ok := c.emails[email]
+		if !ok {
+			return models.User{}, errors.Wrapf(errorsPkg.ErrUserNotFound, "email: [%s]", email)
 		}
+		if user := c.data[name]; !user.Deleted() || withDeleted {
+			return user, nil
+		}
+		return models.User{}, errors.Wrapf(errorsPkg.ErrUserNotFound, "email: [%s]", email)
+	}
+}
+
+func (c *cache) UserList(ctx context.Context, params models.UserListParams) ([]models.User, error) {
+	c.logger.Debugln("UserList, cached func", params.Order, params.Limit, params.Offset, params.After, params.WithDeleted)
+	select {
+	case <-ctx.Done():
+		return nil, errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.RLock()
+		defer func() {
+			c.mu.RUnlock()
+			<-c.poolCh
+		}()
 
 		list := make([]models.User, 0, len(c.data))
 		for _, user := range c.data {
+			if user.Deleted() && !params.WithDeleted {
+				continue
+			}
+			if !params.Filter.Match(user) {
+				continue
+			}
+			if params.After != nil && params.Compare(user, *params.After) <= 0 {
+				continue
+			}
 			list = append(list, user)
 		}
 
 		sort.Slice(list, func(i, j int) bool {
-			if order {
-				return list[i].Name > list[j].Name
-			}
-			return list[i].Name < list[j].Name
+			return params.Compare(list[i], list[j]) < 0
 		})
 
-		min := limit * offset
-		if len(list) < int(limit*(offset+1)) {
+		min := uint64(0)
+		if params.After == nil {
+			min = params.Limit * params.Offset
+		}
+		if uint64(len(list)) <= min {
+			return make([]models.User, 0), nil
+		}
+		max := min + params.Limit
+		if uint64(len(list)) < max {
 			return list[min:], nil
-		} else {
-			max := limit * (offset + 1)
-			return list[min:max], nil
 		}
+		return list[min:max], nil
 	}
 }
 
+func (c *cache) UserHistory(ctx context.Context, name string) ([]models.UserChange, error) {
+	c.logger.Debugln("UserHistory, cached func", name)
+	select {
+	case <-ctx.Done():
+		return nil, errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.RLock()
+		defer func() {
+			c.mu.RUnlock()
+			<-c.poolCh
+		}()
+
+		changes := make([]models.UserChange, len(c.history[name]))
+		copy(changes, c.history[name])
+		return changes, nil
+	}
+}
+
+func (c *cache) UserGetAsOf(ctx context.Context, name string, at time.Time) (models.User, error) {
+	c.logger.Debugln("UserGetAsOf, cached func", name, at)
+	select {
+	case <-ctx.Done():
+		return models.User{}, errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.RLock()
+		defer func() {
+			c.mu.RUnlock()
+			<-c.poolCh
+		}()
+
+		changes := c.history[name]
+		for i := len(changes) - 1; i >= 0; i-- {
+			if !changes[i].ChangedAt.After(at) {
+				return changes[i].New, nil
+			}
+		}
+		return models.User{}, errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s], as of: [%v]", name, at)
+	}
+}
+
+func (c *cache) OutboxList(ctx context.Context, limit uint64) ([]models.OutboxMessage, error) {
+	c.logger.Debugln("OutboxList, cached func", limit)
+	select {
+	case <-ctx.Done():
+		return nil, errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.RLock()
+		defer func() {
+			c.mu.RUnlock()
+			<-c.poolCh
+		}()
+
+		if uint64(len(c.outbox)) < limit {
+			limit = uint64(len(c.outbox))
+		}
+		messages := make([]models.OutboxMessage, limit)
+		copy(messages, c.outbox)
+		return messages, nil
+	}
+}
+
+func (c *cache) OutboxMarkPublished(ctx context.Context, ids []uint64) error {
+	c.logger.Debugln("OutboxMarkPublished, cached func", ids)
+	if len(ids) == 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.Lock()
+		defer func() {
+			c.mu.Unlock()
+			<-c.poolCh
+		}()
+
+		if c.journal != nil {
+			if err := c.journal.append(record{Published: ids}); err != nil {
+				return errors.WithMessage(err, "journal")
+			}
+		}
+		c.removeOutbox(ids)
+		return nil
+	}
+}
+
+// OutboxPurge forgets the requests processed before the time, the published messages are removed
+// from the outbox when marked.
+func (c *cache) OutboxPurge(ctx context.Context, before time.Time) (int64, error) {
+	c.logger.Debugln("OutboxPurge, cached func", before)
+	select {
+	case <-ctx.Done():
+		return 0, errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.Lock()
+		defer func() {
+			c.mu.Unlock()
+			<-c.poolCh
+		}()
+
+		pending := make(map[string]bool, len(c.outbox))
+		for _, message := range c.outbox {
+			pending[message.RequestID] = true
+		}
+		var purged int64
+		for uid, processed := range c.processed {
+			if processed.Before(before) && !pending[uid] {
+				delete(c.processed, uid)
+				purged++
+			}
+		}
+		return purged, nil
+	}
+}
+
+func (c *cache) RequestProcessed(ctx context.Context, requestID string) (bool, error) {
+	c.logger.Debugln("RequestProcessed, cached func", requestID)
+	select {
+	case <-ctx.Done():
+		return false, errorsPkg.ErrTimeout
+	case c.poolCh <- struct{}{}:
+		c.mu.RLock()
+		defer func() {
+			c.mu.RUnlock()
+			<-c.poolCh
+		}()
+
+		_, ok := c.processed[requestID]
+		return ok, nil
+	}
+}
+
+// emailTaken returns true if the email belongs to another user, must be called under the lock.
+func (c *cache) emailTaken(email, name string) bool {
+	owner, ok := c.emails[email]
+	return ok && owner != name
+}
+
+// putUser logs and stores the user with the change of the old one in its history
+// and the outbox message of the change if it is set, must be called under the lock.
+func (c *cache) putUser(ctx context.Context, operation string, old *models.User, user models.User,
+	message *models.OutboxMessage) error {
+	rec := userRecord(ctx, operation, old, user)
+	rec.Outbox = message
+	return c.commit(rec)
+}
+
+// userRecord is the record storing the user with the change of the old one.
+func userRecord(ctx context.Context, operation string, old *models.User, user models.User) record {
+	uid, _ := helper.ExtractUidPubFromCtx(ctx)
+	change := models.NewUserChange(operation, old, user, uid)
+	return record{Name: user.Name, User: &user, Change: &change}
+}
+
+// commit logs the record and applies it after it is logged, must be called under the lock.
+func (c *cache) commit(rec record) error {
+	if c.journal != nil {
+		if err := c.journal.append(rec); err != nil {
+			return errors.WithMessage(err, "journal")
+		}
+	}
+	c.apply(rec)
+	return nil
+}
+
+// outboxMessage returns the outbox message of the change from the context, nil if it is not set,
+// must be called under the lock.
+func (c *cache) outboxMessage(ctx context.Context, results []error) (*models.OutboxMessage, error) {
+	fn := models.OutboxFromCtx(ctx)
+	if fn == nil {
+		return nil, nil
+	}
+	message, err := fn(results)
+	if err != nil {
+		return nil, errors.Wrap(err, "outbox message")
+	}
+	message.ID = c.outboxSeq + 1
+	message.CreatedAt = models.Now()
+	message.RequestID, _ = helper.ExtractUidPubFromCtx(ctx)
+	return &message, nil
+}
+
+// checkRequest returns customerrors.ErrRequestProcessed if the request of the change with the outbox message
+// is already processed, must be called under the lock.
+func (c *cache) checkRequest(ctx context.Context) error {
+	uid, _ := helper.ExtractUidPubFromCtx(ctx)
+	if uid == "" || models.OutboxFromCtx(ctx) == nil {
+		return nil
+	}
+	if _, ok := c.processed[uid]; ok {
+		return errors.Wrapf(errorsPkg.ErrRequestProcessed, "request: [%s]", uid)
+	}
+	return nil
+}
+
+// addOutbox adds the message to the outbox, must be called under the lock.
+func (c *cache) addOutbox(message models.OutboxMessage) {
+	c.outbox = append(c.outbox, message)
+	if message.RequestID != "" {
+		c.processed[message.RequestID] = message.CreatedAt
+	}
+	if message.ID > c.outboxSeq {
+		c.outboxSeq = message.ID
+	}
+}
+
+// removeOutbox removes the published messages from the outbox, must be called under the lock.
+func (c *cache) removeOutbox(ids []uint64) {
+	published := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		published[id] = true
+	}
+	pending := c.outbox[:0]
+	for _, message := range c.outbox {
+		if !published[message.ID] {
+			pending = append(pending, message)
+		}
+	}
+	c.outbox = pending
+}
+
+// removeUser logs and removes the user, must be called under the lock.
+func (c *cache) removeUser(name string) error {
+	if c.journal != nil {
+		if err := c.journal.append(record{Name: name}); err != nil {
+			return errors.WithMessage(err, "journal")
+		}
+	}
+	c.deleteUser(name)
+	return nil
+}
+
+// setUser stores the user and moves its email in the index, must be called under the lock.
+func (c *cache) setUser(user models.User) {
+	if old, ok := c.data[user.Name]; ok && c.emails[old.Email] == user.Name {
+		delete(c.emails, old.Email)
+	}
+	c.data[user.Name] = user
+	c.emails[user.Email] = user.Name
+}
+
+// deleteUser removes the user and its email from the index, must be called under the lock.
+func (c *cache) deleteUser(name string) {
+	if user, ok := c.data[name]; ok && c.emails[user.Email] == name {
+		delete(c.emails, user.Email)
+	}
+	delete(c.data, name)
+}
+
+// checkVersion must be called under the lock.
+func (c *cache) checkVersion(name string, version uint64) (models.User, error) {
+	user, ok := c.data[name]
+	if !ok || user.Deleted() {
+		return user, errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s]", name)
+	}
+	if version != 0 && user.Version != version {
+		return user, errors.Wrapf(errorsPkg.ErrVersionConflict, "user-name: [%s], version: [%d], expected: [%d]",
+			name, user.Version, version)
+	}
+	return user, nil
+}
+
 func (c *cache) Close() {
+	if c.journal != nil {
+		close(c.stop)
+		c.wg.Wait()
+		if err := c.compact(); err != nil {
+			c.logger.Errorln("Snapshot on close", err)
+		}
+		if err := c.journal.close(); err != nil {
+			c.logger.Errorln("Journal close", err)
+		}
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.data = nil
+	c.emails = nil
+	c.history = nil
+	c.outbox = nil
+	c.processed = nil
 	close(c.poolCh)
 	c.logger.Infoln("Cache cleaned")
 }
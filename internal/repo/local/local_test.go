@@ -19,41 +19,47 @@ var (
 		Password:  "123",
 		Email:     "ivan@email.com",
 		FullName:  "Ivan the Dummy",
-		CreatedAt: 1660412940,
+		CreatedAt: time.Unix(1660412940, 0),
 	}
 	user2 = models.User{
 		Name:      "Ivan",
 		Password:  "123456",
 		Email:     "ivanivan@email.com",
 		FullName:  "Ivan the Smart guy",
-		CreatedAt: 1660412940,
+		CreatedAt: time.Unix(1660412940, 0),
 	}
 	user3 = models.User{
 		Name:      "Boris",
 		Password:  "321",
 		Email:     "boris@email.com",
 		FullName:  "Boris The Blade",
-		CreatedAt: 1660412960,
+		CreatedAt: time.Unix(1660412960, 0),
 	}
 	user4 = models.User{
 		Name:      "Arnold",
 		Password:  "321",
 		Email:     "arnold@email.com",
 		FullName:  "Arnold Schwarzenegger",
-		CreatedAt: 1660412960,
+		CreatedAt: time.Unix(1660412960, 0),
 	}
 )
 
 func TestCache_UserCreate(t *testing.T) {
 	testCache := cache{
-		mu:     sync.RWMutex{},
-		data:   make(map[string]models.User),
-		poolCh: make(chan struct{}, 1),
-		logger: loggerPkg.NewFatal(),
+		mu:      sync.RWMutex{},
+		data:    make(map[string]models.User),
+		emails:  make(map[string]string),
+		history: make(map[string][]models.UserChange),
+		poolCh:  make(chan struct{}, 1),
+		logger:  loggerPkg.NewFatal(),
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
+	created := user1
+	created.Version = 1
+	created.CreatedAt = time.Time{}
+
 	cases := []struct {
 		name    string
 		user    models.User
@@ -65,7 +71,7 @@ func TestCache_UserCreate(t *testing.T) {
 			name:    "success",
 			user:    user1,
 			expErr:  nil,
-			expUser: user1,
+			expUser: created,
 			poolCh:  func(_ chan struct{}) {},
 		},
 		{
@@ -87,43 +93,179 @@ func TestCache_UserCreate(t *testing.T) {
 			delete(testCache.data, c.user.Name)
 
 			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expErr == nil, !actualUser.CreatedAt.IsZero())
+			assert.Equal(t, actualUser.CreatedAt, actualUser.UpdatedAt)
+			actualUser.CreatedAt, actualUser.UpdatedAt = time.Time{}, time.Time{}
 			assert.Equal(t, c.expUser, actualUser)
 		})
 	}
 }
 
+func TestCache_UserCreateBatch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	sameEmail := user4
+	sameEmail.Email = user3.Email
+
+	cases := []struct {
+		name   string
+		users  []models.User
+		expRes []error
+		expErr error
+		poolCh func(chan struct{})
+	}{
+		{
+			name:   "success",
+			users:  []models.User{user3, user4},
+			expRes: []error{nil, nil},
+			poolCh: func(_ chan struct{}) {},
+		},
+		{
+			name:   "success, existing name and email",
+			users:  []models.User{user2, user3, sameEmail},
+			expRes: []error{errorsPkg.ErrUserAlreadyExists, nil, errorsPkg.ErrEmailAlreadyExists},
+			poolCh: func(_ chan struct{}) {},
+		},
+		{
+			name:   "failed, deadline exceeded",
+			users:  []models.User{user3},
+			expErr: errorsPkg.ErrTimeout,
+			poolCh: func(ch chan struct{}) {
+				ch <- struct{}{}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			testCache := cache{
+				mu:      sync.RWMutex{},
+				data:    map[string]models.User{user1.Name: user1},
+				emails:  map[string]string{user1.Email: user1.Name},
+				history: make(map[string][]models.UserChange),
+				poolCh:  make(chan struct{}, 1),
+				logger:  loggerPkg.NewFatal(),
+			}
+			c.poolCh(testCache.poolCh)
+			res, err := testCache.UserCreateBatch(ctx, c.users)
+
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Len(t, res, len(c.expRes))
+			for i := range c.expRes {
+				assert.ErrorIs(t, res[i], c.expRes[i])
+				if c.expRes[i] == nil {
+					assert.Equal(t, uint64(1), testCache.data[c.users[i].Name].Version)
+				}
+			}
+		})
+	}
+}
+
 func TestCache_UserUpdate(t *testing.T) {
 	testCache := cache{
-		mu:     sync.RWMutex{},
-		data:   make(map[string]models.User),
-		poolCh: make(chan struct{}, 1),
-		logger: loggerPkg.NewFatal(),
+		mu:      sync.RWMutex{},
+		data:    make(map[string]models.User),
+		emails:  make(map[string]string),
+		history: make(map[string][]models.UserChange),
+		poolCh:  make(chan struct{}, 1),
+		logger:  loggerPkg.NewFatal(),
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
+	stored := user1
+	stored.Version = 1
+	updated := user2
+	updated.Version = 2
+	emailOnly := stored
+	emailOnly.Email = user2.Email
+	emailOnly.Version = 2
+
 	cases := []struct {
 		name    string
 		user    models.User
-		newUser models.User
+		profile models.Profile
 		expErr  error
 		expUser models.User
 		poolCh  func(chan struct{})
 	}{
 		{
-			name:    "success",
-			user:    user1,
-			newUser: user2,
+			name: "success, all fields",
+			user: stored,
+			profile: models.Profile{
+				Name:     user2.Name,
+				Password: &user2.Password,
+				Email:    &user2.Email,
+				FullName: &user2.FullName,
+			},
 			expErr:  nil,
-			expUser: user2,
+			expUser: updated,
 			poolCh:  func(_ chan struct{}) {},
 		},
 		{
-			name:    "failed, deadline exceeded",
-			user:    user1,
-			newUser: user2,
+			name: "success, only email",
+			user: stored,
+			profile: models.Profile{
+				Name:  user2.Name,
+				Email: &user2.Email,
+			},
+			expErr:  nil,
+			expUser: emailOnly,
+			poolCh:  func(_ chan struct{}) {},
+		},
+		{
+			name: "failed, user not found",
+			user: user3,
+			profile: models.Profile{
+				Name:  user1.Name,
+				Email: &user2.Email,
+			},
+			expErr:  errorsPkg.ErrUserNotFound,
+			expUser: models.User{},
+			poolCh:  func(_ chan struct{}) {},
+		},
+		{
+			name: "success, expected version",
+			user: stored,
+			profile: models.Profile{
+				Name:    user2.Name,
+				Email:   &user2.Email,
+				Version: 1,
+			},
+			expErr:  nil,
+			expUser: emailOnly,
+			poolCh:  func(_ chan struct{}) {},
+		},
+		{
+			name: "failed, version conflict",
+			user: stored,
+			profile: models.Profile{
+				Name:    user2.Name,
+				Email:   &user2.Email,
+				Version: 2,
+			},
+			expErr:  errorsPkg.ErrVersionConflict,
+			expUser: stored,
+			poolCh:  func(_ chan struct{}) {},
+		},
+		{
+			name:    "failed, nothing to update",
+			user:    stored,
+			profile: models.Profile{Name: user1.Name},
+			expErr:  errorsPkg.ErrValidation,
+			expUser: stored,
+			poolCh:  func(_ chan struct{}) {},
+		},
+		{
+			name: "failed, deadline exceeded",
+			user: stored,
+			profile: models.Profile{
+				Name:  user2.Name,
+				Email: &user2.Email,
+			},
 			expErr:  errorsPkg.ErrTimeout,
-			expUser: user1,
+			expUser: stored,
 			poolCh: func(ch chan struct{}) {
 				ch <- struct{}{}
 			},
@@ -134,11 +276,14 @@ func TestCache_UserUpdate(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			testCache.data[c.user.Name] = c.user
 			c.poolCh(testCache.poolCh)
-			err := testCache.UserUpdate(ctx, c.newUser)
-			actualUser := testCache.data[c.newUser.Name]
-			delete(testCache.data, c.newUser.Name)
+			err := testCache.UserUpdate(ctx, c.profile)
+			actualUser := testCache.data[c.profile.Name]
+			delete(testCache.data, c.user.Name)
+			delete(testCache.data, c.profile.Name)
 
 			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expErr == nil, !actualUser.UpdatedAt.IsZero())
+			actualUser.UpdatedAt = time.Time{}
 			assert.Equal(t, c.expUser, actualUser)
 		})
 	}
@@ -146,14 +291,118 @@ func TestCache_UserUpdate(t *testing.T) {
 
 func TestCache_UserDelete(t *testing.T) {
 	testCache := cache{
-		mu:     sync.RWMutex{},
-		data:   make(map[string]models.User),
-		poolCh: make(chan struct{}, 1),
-		logger: loggerPkg.NewFatal(),
+		mu:      sync.RWMutex{},
+		data:    make(map[string]models.User),
+		emails:  make(map[string]string),
+		history: make(map[string][]models.UserChange),
+		poolCh:  make(chan struct{}, 1),
+		logger:  loggerPkg.NewFatal(),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	stored := user1
+	stored.Version = 1
+	deleted := stored
+	deleted.Version = 2
+	tombstone := stored
+	tombstone.DeletedAt = 1660413940
+
+	cases := []struct {
+		name       string
+		user       models.User
+		version    uint64
+		expErr     error
+		expUser    models.User
+		expDeleted bool
+		poolCh     func(chan struct{})
+	}{
+		{
+			name:       "success",
+			user:       stored,
+			version:    0,
+			expErr:     nil,
+			expUser:    deleted,
+			expDeleted: true,
+			poolCh:     func(_ chan struct{}) {},
+		},
+		{
+			name:       "success, expected version",
+			user:       stored,
+			version:    1,
+			expErr:     nil,
+			expUser:    deleted,
+			expDeleted: true,
+			poolCh:     func(_ chan struct{}) {},
+		},
+		{
+			name:       "failed, version conflict",
+			user:       stored,
+			version:    2,
+			expErr:     errorsPkg.ErrVersionConflict,
+			expUser:    stored,
+			expDeleted: false,
+			poolCh:     func(_ chan struct{}) {},
+		},
+		{
+			name:       "failed, already deleted",
+			user:       tombstone,
+			version:    0,
+			expErr:     errorsPkg.ErrUserNotFound,
+			expUser:    stored,
+			expDeleted: true,
+			poolCh:     func(_ chan struct{}) {},
+		},
+		{
+			name:       "failed, deadline exceeded",
+			user:       stored,
+			version:    0,
+			expErr:     errorsPkg.ErrTimeout,
+			expUser:    stored,
+			expDeleted: false,
+			poolCh: func(ch chan struct{}) {
+				ch <- struct{}{}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			testCache.data[c.user.Name] = c.user
+			c.poolCh(testCache.poolCh)
+			err := testCache.UserDelete(ctx, c.user.Name, c.version)
+			actualUser := testCache.data[c.user.Name]
+			delete(testCache.data, c.user.Name)
+
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expErr == nil, !actualUser.UpdatedAt.IsZero())
+			actualUser.UpdatedAt = time.Time{}
+			assert.Equal(t, c.expDeleted, actualUser.Deleted())
+			actualUser.DeletedAt = 0
+			assert.Equal(t, c.expUser, actualUser)
+		})
+	}
+}
+
+func TestCache_UserRestore(t *testing.T) {
+	testCache := cache{
+		mu:      sync.RWMutex{},
+		data:    make(map[string]models.User),
+		emails:  make(map[string]string),
+		history: make(map[string][]models.UserChange),
+		poolCh:  make(chan struct{}, 1),
+		logger:  loggerPkg.NewFatal(),
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
+	stored := user1
+	stored.Version = 1
+	tombstone := stored
+	tombstone.DeletedAt = 1660413940
+	restored := stored
+	restored.Version = 2
+
 	cases := []struct {
 		name    string
 		user    models.User
@@ -163,16 +412,23 @@ func TestCache_UserDelete(t *testing.T) {
 	}{
 		{
 			name:    "success",
-			user:    user1,
+			user:    tombstone,
 			expErr:  nil,
-			expUser: models.User{},
+			expUser: restored,
+			poolCh:  func(_ chan struct{}) {},
+		},
+		{
+			name:    "failed, user is not deleted",
+			user:    stored,
+			expErr:  errorsPkg.ErrUserNotFound,
+			expUser: stored,
 			poolCh:  func(_ chan struct{}) {},
 		},
 		{
 			name:    "failed, deadline exceeded",
-			user:    user1,
+			user:    tombstone,
 			expErr:  errorsPkg.ErrTimeout,
-			expUser: user1,
+			expUser: tombstone,
 			poolCh: func(ch chan struct{}) {
 				ch <- struct{}{}
 			},
@@ -183,32 +439,69 @@ func TestCache_UserDelete(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			testCache.data[c.user.Name] = c.user
 			c.poolCh(testCache.poolCh)
-			err := testCache.UserDelete(ctx, c.user.Name)
+			err := testCache.UserRestore(ctx, c.user.Name)
 			actualUser := testCache.data[c.user.Name]
 			delete(testCache.data, c.user.Name)
 
 			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expErr == nil, !actualUser.UpdatedAt.IsZero())
+			actualUser.UpdatedAt = time.Time{}
 			assert.Equal(t, c.expUser, actualUser)
 		})
 	}
 }
 
+func TestCache_UserPurge(t *testing.T) {
+	testCache := cache{
+		mu:      sync.RWMutex{},
+		data:    make(map[string]models.User),
+		emails:  make(map[string]string),
+		history: make(map[string][]models.UserChange),
+		poolCh:  make(chan struct{}, 1),
+		logger:  loggerPkg.NewFatal(),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	old := user1
+	old.DeletedAt = time.Now().Add(-2 * time.Hour).Unix()
+	recent := user3
+	recent.DeletedAt = time.Now().Add(-30 * time.Minute).Unix()
+	testCache.data[old.Name] = old
+	testCache.data[recent.Name] = recent
+	testCache.data[user4.Name] = user4
+
+	t.Run("success", func(t *testing.T) {
+		purged, err := testCache.UserPurge(ctx, time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), purged)
+		assert.Equal(t, map[string]models.User{recent.Name: recent, user4.Name: user4}, testCache.data)
+	})
+}
+
 func TestCache_UserGet(t *testing.T) {
 	testCache := cache{
-		mu:     sync.RWMutex{},
-		data:   make(map[string]models.User),
-		poolCh: make(chan struct{}, 1),
-		logger: loggerPkg.NewFatal(),
+		mu:      sync.RWMutex{},
+		data:    make(map[string]models.User),
+		emails:  make(map[string]string),
+		history: make(map[string][]models.UserChange),
+		poolCh:  make(chan struct{}, 1),
+		logger:  loggerPkg.NewFatal(),
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 
+	tombstone := user1
+	tombstone.DeletedAt = 1660413940
+
 	cases := []struct {
-		name    string
-		user    models.User
-		expErr  error
-		expUser models.User
-		poolCh  func(chan struct{})
+		name        string
+		user        models.User
+		withDeleted bool
+		expErr      error
+		expUser     models.User
+		poolCh      func(chan struct{})
 	}{
 		{
 			name:    "success",
@@ -217,6 +510,21 @@ func TestCache_UserGet(t *testing.T) {
 			expUser: user1,
 			poolCh:  func(_ chan struct{}) {},
 		},
+		{
+			name:        "success, deleted user",
+			user:        tombstone,
+			withDeleted: true,
+			expErr:      nil,
+			expUser:     tombstone,
+			poolCh:      func(_ chan struct{}) {},
+		},
+		{
+			name:    "failed, deleted user is hidden",
+			user:    tombstone,
+			expErr:  errorsPkg.ErrUserNotFound,
+			expUser: models.User{},
+			poolCh:  func(_ chan struct{}) {},
+		},
 		{
 			name:    "failed, deadline exceeded",
 			user:    user1,
@@ -232,7 +540,7 @@ func TestCache_UserGet(t *testing.T) {
 		t.Run(c.name, func(t *testing.T) {
 			testCache.data[c.user.Name] = c.user
 			c.poolCh(testCache.poolCh)
-			actualUser, err := testCache.UserGet(ctx, c.user.Name)
+			actualUser, err := testCache.UserGet(ctx, c.user.Name, c.withDeleted)
 			delete(testCache.data, c.user.Name)
 
 			assert.ErrorIs(t, err, c.expErr)
@@ -241,28 +549,74 @@ func TestCache_UserGet(t *testing.T) {
 	}
 }
 
+func TestCache_UserGetByEmail(t *testing.T) {
+	testCache := New(1, loggerPkg.NewFatal())
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	assert.NoError(t, testCache.UserCreate(ctx, user1))
+	assert.NoError(t, testCache.UserCreate(ctx, user3))
+
+	sameEmail := user4
+	sameEmail.Email = user1.Email
+	assert.ErrorIs(t, testCache.UserCreate(ctx, sameEmail), errorsPkg.ErrEmailAlreadyExists)
+	assert.ErrorIs(t, testCache.UserUpdate(ctx, models.Profile{Name: user3.Name, Email: &user1.Email}),
+		errorsPkg.ErrEmailAlreadyExists)
+
+	// the old email is released by the update
+	assert.NoError(t, testCache.UserUpdate(ctx, models.Profile{Name: user1.Name, Email: &user2.Email}))
+	_, err := testCache.UserGetByEmail(ctx, user1.Email, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+	user, err := testCache.UserGetByEmail(ctx, user2.Email, false)
+	assert.NoError(t, err)
+	assert.Equal(t, user1.Name, user.Name)
+
+	// the deleted user keeps the email until purged
+	assert.NoError(t, testCache.UserDelete(ctx, user3.Name, 0))
+	_, err = testCache.UserGetByEmail(ctx, user3.Email, false)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+	user, err = testCache.UserGetByEmail(ctx, user3.Email, true)
+	assert.NoError(t, err)
+	assert.Equal(t, user3.Name, user.Name)
+
+	_, err = testCache.UserPurge(ctx, -time.Second)
+	assert.NoError(t, err)
+	_, err = testCache.UserGetByEmail(ctx, user3.Email, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+}
+
 func TestCache_UserList(t *testing.T) {
 	testCache := cache{
-		mu:     sync.RWMutex{},
-		data:   make(map[string]models.User),
-		poolCh: make(chan struct{}, 1),
-		logger: loggerPkg.NewFatal(),
+		mu:      sync.RWMutex{},
+		data:    make(map[string]models.User),
+		emails:  make(map[string]string),
+		history: make(map[string][]models.UserChange),
+		poolCh:  make(chan struct{}, 1),
+		logger:  loggerPkg.NewFatal(),
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
 	defer cancel()
 	testCache.data[user1.Name] = user1
 	testCache.data[user3.Name] = user3
 	testCache.data[user4.Name] = user4
+	tombstone := user2
+	tombstone.Name = "Zed"
+	tombstone.DeletedAt = 1660413940
+	testCache.data[tombstone.Name] = tombstone
 
 	cases := []struct {
-		name    string
-		list    []models.User
-		expErr  error
-		expList []models.User
-		poolCh  func(chan struct{})
-		order   bool
-		limit   uint64
-		offset  uint64
+		name        string
+		list        []models.User
+		expErr      error
+		expList     []models.User
+		poolCh      func(chan struct{})
+		order       bool
+		limit       uint64
+		offset      uint64
+		after       *models.User
+		sort        []models.UserSort
+		filter      models.UserFilter
+		withDeleted bool
 	}{
 		{
 			name:    "success, asc, all users",
@@ -284,6 +638,17 @@ func TestCache_UserList(t *testing.T) {
 			limit:   3,
 			offset:  0,
 		},
+		{
+			name:        "success, with deleted users",
+			list:        []models.User{user1, user3, user4},
+			expErr:      nil,
+			expList:     []models.User{tombstone, user1, user3, user4},
+			poolCh:      func(_ chan struct{}) {},
+			order:       true,
+			limit:       4,
+			offset:      0,
+			withDeleted: true,
+		},
 		{
 			name:    "success, very big offset",
 			list:    []models.User{user1, user3, user4},
@@ -304,6 +669,74 @@ func TestCache_UserList(t *testing.T) {
 			limit:   2,
 			offset:  1,
 		},
+		{
+			name:    "success, asc, after cursor",
+			list:    []models.User{user1, user3, user4},
+			expErr:  nil,
+			expList: []models.User{user3},
+			poolCh:  func(_ chan struct{}) {},
+			order:   false,
+			limit:   1,
+			after:   &user4,
+		},
+		{
+			name:    "success, desc, after cursor",
+			list:    []models.User{user1, user3, user4},
+			expErr:  nil,
+			expList: []models.User{user3, user4},
+			poolCh:  func(_ chan struct{}) {},
+			order:   true,
+			limit:   3,
+			after:   &user1,
+		},
+		{
+			name:    "success, offset is ignored with cursor",
+			list:    []models.User{user1, user3, user4},
+			expErr:  nil,
+			expList: []models.User{user1},
+			poolCh:  func(_ chan struct{}) {},
+			order:   false,
+			limit:   2,
+			offset:  5,
+			after:   &user3,
+		},
+		{
+			name:    "success, sort by created_at desc and name",
+			list:    []models.User{user1, user3, user4},
+			expErr:  nil,
+			expList: []models.User{user4, user3, user1},
+			poolCh:  func(_ chan struct{}) {},
+			limit:   3,
+			sort:    []models.UserSort{{Field: models.SortCreatedAt, Desc: true}},
+		},
+		{
+			name:    "success, sort by created_at desc after cursor",
+			list:    []models.User{user1, user3, user4},
+			expErr:  nil,
+			expList: []models.User{user3, user1},
+			poolCh:  func(_ chan struct{}) {},
+			limit:   3,
+			sort:    []models.UserSort{{Field: models.SortCreatedAt, Desc: true}},
+			after:   &user4,
+		},
+		{
+			name:    "success, filter by full name and created_at",
+			list:    []models.User{user1, user3, user4},
+			expErr:  nil,
+			expList: []models.User{user1},
+			poolCh:  func(_ chan struct{}) {},
+			limit:   3,
+			filter:  models.UserFilter{FullName: "the", CreatedTo: 1660412950},
+		},
+		{
+			name:    "success, filter by email domain",
+			list:    []models.User{user1, user3, user4},
+			expErr:  nil,
+			expList: make([]models.User, 0),
+			poolCh:  func(_ chan struct{}) {},
+			limit:   3,
+			filter:  models.UserFilter{EmailDomain: "mail.com"},
+		},
 		{
 			name:    "failed, deadline exceeded",
 			list:    []models.User{user1, user3, user4},
@@ -321,7 +754,15 @@ func TestCache_UserList(t *testing.T) {
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			c.poolCh(testCache.poolCh)
-			actuaList, err := testCache.UserList(ctx, c.order, c.limit, c.offset)
+			actuaList, err := testCache.UserList(ctx, models.UserListParams{
+				Limit:       c.limit,
+				Offset:      c.offset,
+				Order:       c.order,
+				WithDeleted: c.withDeleted,
+				Sort:        c.sort,
+				Filter:      c.filter,
+				After:       c.after,
+			})
 
 			assert.ErrorIs(t, err, c.expErr)
 			assert.Equal(t, c.expList, actuaList)
@@ -331,10 +772,12 @@ func TestCache_UserList(t *testing.T) {
 
 func TestCache_Close(t *testing.T) {
 	testCache := cache{
-		mu:     sync.RWMutex{},
-		data:   make(map[string]models.User),
-		poolCh: make(chan struct{}, 1),
-		logger: loggerPkg.NewFatal(),
+		mu:      sync.RWMutex{},
+		data:    make(map[string]models.User),
+		emails:  make(map[string]string),
+		history: make(map[string][]models.UserChange),
+		poolCh:  make(chan struct{}, 1),
+		logger:  loggerPkg.NewFatal(),
 	}
 
 	t.Run("success memory clear", func(t *testing.T) {
@@ -0,0 +1,26 @@
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo/repotest"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+)
+
+func TestCache_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repoPkg.Interface {
+		return New(1, loggerPkg.NewFatal())
+	})
+}
+
+func TestDurable_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repoPkg.Interface {
+		repo, err := NewDurable(1, Config{Dir: t.TempDir()}, loggerPkg.NewFatal())
+		require.NoError(t, err)
+		t.Cleanup(repo.Close)
+		return repo
+	})
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	models "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
@@ -47,6 +48,65 @@ func (mr *MockInterfaceMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockInterface)(nil).Close))
 }
 
+// OutboxList mocks base method.
+func (m *MockInterface) OutboxList(ctx context.Context, limit uint64) ([]models.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxList", ctx, limit)
+	ret0, _ := ret[0].([]models.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutboxList indicates an expected call of OutboxList.
+func (mr *MockInterfaceMockRecorder) OutboxList(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxList", reflect.TypeOf((*MockInterface)(nil).OutboxList), ctx, limit)
+}
+
+// OutboxMarkPublished mocks base method.
+func (m *MockInterface) OutboxMarkPublished(ctx context.Context, ids []uint64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxMarkPublished", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OutboxMarkPublished indicates an expected call of OutboxMarkPublished.
+func (mr *MockInterfaceMockRecorder) OutboxMarkPublished(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxMarkPublished", reflect.TypeOf((*MockInterface)(nil).OutboxMarkPublished), ctx, ids)
+}
+
+// OutboxPurge mocks base method.
+func (m *MockInterface) OutboxPurge(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OutboxPurge", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OutboxPurge indicates an expected call of OutboxPurge.
+func (mr *MockInterfaceMockRecorder) OutboxPurge(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OutboxPurge", reflect.TypeOf((*MockInterface)(nil).OutboxPurge), ctx, before)
+}
+
+// RequestProcessed mocks base method.
+func (m *MockInterface) RequestProcessed(ctx context.Context, requestID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestProcessed", ctx, requestID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestProcessed indicates an expected call of RequestProcessed.
+func (mr *MockInterfaceMockRecorder) RequestProcessed(ctx, requestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestProcessed", reflect.TypeOf((*MockInterface)(nil).RequestProcessed), ctx, requestID)
+}
+
 // UserCreate mocks base method.
 func (m *MockInterface) UserCreate(ctx context.Context, user models.User) error {
 	m.ctrl.T.Helper()
@@ -61,60 +121,149 @@ func (mr *MockInterfaceMockRecorder) UserCreate(ctx, user interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCreate", reflect.TypeOf((*MockInterface)(nil).UserCreate), ctx, user)
 }
 
+// UserCreateBatch mocks base method.
+func (m *MockInterface) UserCreateBatch(ctx context.Context, users []models.User) ([]error, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserCreateBatch", ctx, users)
+	ret0, _ := ret[0].([]error)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserCreateBatch indicates an expected call of UserCreateBatch.
+func (mr *MockInterfaceMockRecorder) UserCreateBatch(ctx, users interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserCreateBatch", reflect.TypeOf((*MockInterface)(nil).UserCreateBatch), ctx, users)
+}
+
 // UserDelete mocks base method.
-func (m *MockInterface) UserDelete(ctx context.Context, name string) error {
+func (m *MockInterface) UserDelete(ctx context.Context, name string, version uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserDelete", ctx, name)
+	ret := m.ctrl.Call(m, "UserDelete", ctx, name, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UserDelete indicates an expected call of UserDelete.
-func (mr *MockInterfaceMockRecorder) UserDelete(ctx, name interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) UserDelete(ctx, name, version interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDelete", reflect.TypeOf((*MockInterface)(nil).UserDelete), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDelete", reflect.TypeOf((*MockInterface)(nil).UserDelete), ctx, name, version)
 }
 
 // UserGet mocks base method.
-func (m *MockInterface) UserGet(ctx context.Context, name string) (models.User, error) {
+func (m *MockInterface) UserGet(ctx context.Context, name string, withDeleted bool) (models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserGet", ctx, name)
+	ret := m.ctrl.Call(m, "UserGet", ctx, name, withDeleted)
 	ret0, _ := ret[0].(models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UserGet indicates an expected call of UserGet.
-func (mr *MockInterfaceMockRecorder) UserGet(ctx, name interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) UserGet(ctx, name, withDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGet", reflect.TypeOf((*MockInterface)(nil).UserGet), ctx, name, withDeleted)
+}
+
+// UserGetAsOf mocks base method.
+func (m *MockInterface) UserGetAsOf(ctx context.Context, name string, at time.Time) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserGetAsOf", ctx, name, at)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetAsOf indicates an expected call of UserGetAsOf.
+func (mr *MockInterfaceMockRecorder) UserGetAsOf(ctx, name, at interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGet", reflect.TypeOf((*MockInterface)(nil).UserGet), ctx, name)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetAsOf", reflect.TypeOf((*MockInterface)(nil).UserGetAsOf), ctx, name, at)
+}
+
+// UserGetByEmail mocks base method.
+func (m *MockInterface) UserGetByEmail(ctx context.Context, email string, withDeleted bool) (models.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserGetByEmail", ctx, email, withDeleted)
+	ret0, _ := ret[0].(models.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetByEmail indicates an expected call of UserGetByEmail.
+func (mr *MockInterfaceMockRecorder) UserGetByEmail(ctx, email, withDeleted interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetByEmail", reflect.TypeOf((*MockInterface)(nil).UserGetByEmail), ctx, email, withDeleted)
+}
+
+// UserHistory mocks base method.
+func (m *MockInterface) UserHistory(ctx context.Context, name string) ([]models.UserChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserHistory", ctx, name)
+	ret0, _ := ret[0].([]models.UserChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserHistory indicates an expected call of UserHistory.
+func (mr *MockInterfaceMockRecorder) UserHistory(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserHistory", reflect.TypeOf((*MockInterface)(nil).UserHistory), ctx, name)
 }
 
 // UserList mocks base method.
-func (m *MockInterface) UserList(ctx context.Context, order bool, limit, offset uint64) ([]models.User, error) {
+func (m *MockInterface) UserList(ctx context.Context, params models.UserListParams) ([]models.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserList", ctx, order, limit, offset)
+	ret := m.ctrl.Call(m, "UserList", ctx, params)
 	ret0, _ := ret[0].([]models.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UserList indicates an expected call of UserList.
-func (mr *MockInterfaceMockRecorder) UserList(ctx, order, limit, offset interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) UserList(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserList", reflect.TypeOf((*MockInterface)(nil).UserList), ctx, params)
+}
+
+// UserPurge mocks base method.
+func (m *MockInterface) UserPurge(ctx context.Context, retention time.Duration) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserPurge", ctx, retention)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserPurge indicates an expected call of UserPurge.
+func (mr *MockInterfaceMockRecorder) UserPurge(ctx, retention interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserPurge", reflect.TypeOf((*MockInterface)(nil).UserPurge), ctx, retention)
+}
+
+// UserRestore mocks base method.
+func (m *MockInterface) UserRestore(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserRestore", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserRestore indicates an expected call of UserRestore.
+func (mr *MockInterfaceMockRecorder) UserRestore(ctx, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserList", reflect.TypeOf((*MockInterface)(nil).UserList), ctx, order, limit, offset)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRestore", reflect.TypeOf((*MockInterface)(nil).UserRestore), ctx, name)
 }
 
 // UserUpdate mocks base method.
-func (m *MockInterface) UserUpdate(ctx context.Context, user models.User) error {
+func (m *MockInterface) UserUpdate(ctx context.Context, profile models.Profile) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UserUpdate", ctx, user)
+	ret := m.ctrl.Call(m, "UserUpdate", ctx, profile)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UserUpdate indicates an expected call of UserUpdate.
-func (mr *MockInterfaceMockRecorder) UserUpdate(ctx, user interface{}) *gomock.Call {
+func (mr *MockInterfaceMockRecorder) UserUpdate(ctx, profile interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserUpdate", reflect.TypeOf((*MockInterface)(nil).UserUpdate), ctx, user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserUpdate", reflect.TypeOf((*MockInterface)(nil).UserUpdate), ctx, profile)
 }
@@ -0,0 +1,234 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+)
+
+const (
+	historyTable = "user_history"
+
+	idField        = "id"
+	operationField = "operation"
+	oldValuesField = "old_values"
+	newValuesField = "new_values"
+	requestIDField = "request_id"
+	changedAtField = "changed_at"
+)
+
+// historyColumns are written by insertHistory and scanned by scanChange.
+var historyColumns = []string{nameField, operationField, oldValuesField, newValuesField, requestIDField, changedAtField}
+
+// returningUser returns the written user row in the userColumns order.
+var returningUser = "RETURNING " + strings.Join(userColumns, ", ")
+
+func (r *repo) UserHistory(ctx context.Context, name string) ([]models.UserChange, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	query, args, err := squirrel.Select(historyColumns...).
+		From(historyTable).
+		Where(squirrel.Eq{nameField: name}).
+		OrderBy(changedAtField, idField).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres UserHistory: to sql")
+	}
+	r.logger.Debugln("UserHistory", query, args)
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres UserHistory: select")
+	}
+	defer rows.Close()
+
+	changes := make([]models.UserChange, 0)
+	for rows.Next() {
+		change, err := scanChange(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "postgres UserHistory: row scan")
+		}
+		changes = append(changes, change)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "postgres UserHistory: rows")
+	}
+
+	return changes, nil
+}
+
+func (r *repo) UserGetAsOf(ctx context.Context, name string, at time.Time) (models.User, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	query, args, err := squirrel.Select(historyColumns...).
+		From(historyTable).
+		Where(squirrel.And{
+			squirrel.Eq{nameField: name},
+			squirrel.LtOrEq{changedAtField: at},
+		}).
+		OrderBy(changedAtField+desc, idField+desc).
+		Limit(1).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return models.User{}, errors.Wrap(err, "postgres UserGetAsOf: to sql")
+	}
+	r.logger.Debugln("UserGetAsOf", query, args)
+
+	change, err := scanChange(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return models.User{}, errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s], as of: [%v]", name, at)
+		}
+		return models.User{}, errors.Wrap(err, "postgres UserGetAsOf: select")
+	}
+
+	return change.New, nil
+}
+
+// inTx runs fn in a transaction committed when fn succeeds.
+func (r *repo) inTx(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.Wrap(err, "begin")
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return errors.Wrap(err, "commit")
+	}
+	return nil
+}
+
+// change locks the user row, checks it by check, runs the update query returning the changed user
+// and records the change with its outbox message in the same transaction, unless the request is processed.
+// mapErr maps the update error, if set.
+func (r *repo) change(ctx context.Context, operation, name, query string, args []interface{},
+	check func(old *models.User) error, mapErr func(error) error) error {
+	return r.inTx(ctx, func(tx pgx.Tx) error {
+		if err := checkRequest(ctx, tx); err != nil {
+			return err
+		}
+		old, err := lockUser(ctx, tx, name)
+		if err != nil {
+			return err
+		}
+		if err = check(old); err != nil {
+			return err
+		}
+
+		user, err := scanUser(tx.QueryRow(ctx, query, args...))
+		if err != nil {
+			if mapErr != nil {
+				err = mapErr(err)
+			}
+			return errors.Wrap(err, "update")
+		}
+		if err = insertHistory(ctx, tx, models.NewUserChange(operation, old, user, requestID(ctx))); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, nil)
+	})
+}
+
+// lockUser selects the user row for update, the user is nil when the row is absent.
+func lockUser(ctx context.Context, tx pgx.Tx, name string) (*models.User, error) {
+	query, args, err := squirrel.Select(userColumns...).
+		From(usersTable).
+		Where(squirrel.Eq{nameField: name}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "to sql")
+	}
+
+	user, err := scanUser(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "lock")
+	}
+	return &user, nil
+}
+
+// insertHistory records the changes in the transaction of the changes.
+func insertHistory(ctx context.Context, tx pgx.Tx, changes ...models.UserChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	insert := squirrel.Insert(historyTable).Columns(historyColumns...)
+	for _, change := range changes {
+		var old []byte
+		if change.Old != nil {
+			var err error
+			if old, err = json.Marshal(change.Old); err != nil {
+				return errors.Wrap(err, "history: marshal")
+			}
+		}
+		user, err := json.Marshal(change.New)
+		if err != nil {
+			return errors.Wrap(err, "history: marshal")
+		}
+		insert = insert.Values(change.Name, change.Operation, old, user, change.RequestID, change.ChangedAt)
+	}
+
+	query, args, err := insert.PlaceholderFormat(squirrel.Dollar).ToSql()
+	if err != nil {
+		return errors.Wrap(err, "history: to sql")
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "history: insert")
+	}
+	return nil
+}
+
+func scanChange(row scanner) (models.UserChange, error) {
+	var (
+		change   models.UserChange
+		old, cur []byte
+	)
+	if err := row.Scan(&change.Name, &change.Operation, &old, &cur, &change.RequestID, &change.ChangedAt); err != nil {
+		return models.UserChange{}, err
+	}
+	if old != nil {
+		change.Old = &models.User{}
+		if err := json.Unmarshal(old, change.Old); err != nil {
+			return models.UserChange{}, errors.Wrap(err, "unmarshal old")
+		}
+	}
+	if err := json.Unmarshal(cur, &change.New); err != nil {
+		return models.UserChange{}, errors.Wrap(err, "unmarshal new")
+	}
+	return change, nil
+}
+
+// requestID is the uid of the request making the change.
+func requestID(ctx context.Context) string {
+	uid, _ := helper.ExtractUidPubFromCtx(ctx)
+	return uid
+}
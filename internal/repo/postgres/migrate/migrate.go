@@ -0,0 +1,91 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/jackc/pgx/v4/stdlib"
+	"github.com/pkg/errors"
+	"github.com/pressly/goose/v3"
+	"go.uber.org/zap"
+
+	pgModels "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres/models"
+	"gitlab.ozon.dev/iTukaev/homework/migrations"
+)
+
+// Commands of the migrations.
+const (
+	Up     = "up"
+	Down   = "down"
+	Status = "status"
+	Redo   = "redo"
+)
+
+// lockID is the advisory lock key of the migrations, the replicas wait for each other on it,
+// it is held by the transaction open while the migrations run.
+const lockID = 2022080218
+
+// Run applies the command to the database, the embedded migrations are used.
+func Run(ctx context.Context, cfg pgModels.Config, command string, logger *zap.SugaredLogger) error {
+	db, err := sql.Open("pgx", cfg.DSN())
+	if err != nil {
+		return errors.Wrap(err, "open database")
+	}
+	defer func() {
+		_ = db.Close()
+	}()
+
+	// the lock of the transaction holds behind pgbouncer in the transaction pooling mode,
+	// the session lock would be taken on the pooled connection and released with another one
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "migrations lock transaction")
+	}
+	defer func() {
+		if err := tx.Rollback(); err != nil {
+			logger.Errorln("Migrations unlock", err)
+		}
+	}()
+
+	logger.Infoln("Waiting for the migrations lock")
+	if _, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", lockID); err != nil {
+		return errors.Wrap(err, "migrations lock")
+	}
+
+	goose.SetBaseFS(migrations.FS)
+	goose.SetLogger(gooseLogger{logger})
+	if err = goose.SetDialect("postgres"); err != nil {
+		return errors.Wrap(err, "goose dialect")
+	}
+
+	switch command {
+	case Up:
+		err = goose.Up(db, ".")
+	case Down:
+		err = goose.Down(db, ".")
+	case Status:
+		err = goose.Status(db, ".")
+	case Redo:
+		err = goose.Redo(db, ".")
+	default:
+		return errors.Errorf("unknown migrate command [%s]", command)
+	}
+	return errors.Wrapf(err, "migrate %s", command)
+}
+
+// gooseLogger prints the goose messages with the service logger.
+type gooseLogger struct {
+	*zap.SugaredLogger
+}
+
+func (l gooseLogger) Print(v ...interface{}) {
+	l.Info(v...)
+}
+
+func (l gooseLogger) Println(v ...interface{}) {
+	l.Infoln(v...)
+}
+
+func (l gooseLogger) Printf(format string, v ...interface{}) {
+	l.Infof(format, v...)
+}
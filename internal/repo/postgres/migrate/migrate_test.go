@@ -0,0 +1,23 @@
+package migrate
+
+import (
+	"io/fs"
+	"testing"
+
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.ozon.dev/iTukaev/homework/migrations"
+)
+
+func TestMigrations(t *testing.T) {
+	files, err := fs.Glob(migrations.FS, "*.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	goose.SetBaseFS(migrations.FS)
+	collected, err := goose.CollectMigrations(".", 0, goose.MaxVersion)
+	require.NoError(t, err)
+	assert.Len(t, collected, len(files))
+}
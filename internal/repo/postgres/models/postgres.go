@@ -1,9 +1,28 @@
 package models
 
+import (
+	"net"
+	"net/url"
+)
+
+// Config of the PostgreSQL connection, with AutoMigrate the data service applies the migrations on start.
 type Config struct {
-	Host     string `mapstructure:"host"`
-	Port     string `mapstructure:"port"`
-	User     string `mapstructure:"user"`
-	Password string `mapstructure:"password"`
-	DBName   string `mapstructure:"db_name"`
+	Host        string `mapstructure:"host"`
+	Port        string `mapstructure:"port"`
+	User        string `mapstructure:"user"`
+	Password    string `mapstructure:"password"`
+	DBName      string `mapstructure:"db_name"`
+	AutoMigrate bool   `mapstructure:"auto_migrate"`
+}
+
+// DSN is the connection URL of the database, the values are escaped.
+func (c Config) DSN() string {
+	dsn := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(c.User, c.Password),
+		Host:     net.JoinHostPort(c.Host, c.Port),
+		Path:     "/" + c.DBName,
+		RawQuery: url.Values{"sslmode": {"disable"}}.Encode(),
+	}
+	return dsn.String()
 }
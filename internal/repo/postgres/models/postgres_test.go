@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_DSN(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  Config
+	}{
+		{
+			name: "plain values",
+			cfg:  Config{Host: "localhost", Port: "6432", User: "user", Password: "password", DBName: "candy_shop"},
+		},
+		{
+			name: "values with separators and quotes",
+			cfg:  Config{Host: "db.local", Port: "5432", User: "us er", Password: `p@ss w'o=r/d?#`, DBName: "candy shop"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			parsed, err := pgx.ParseConfig(c.cfg.DSN())
+			require.NoError(t, err)
+			assert.Equal(t, c.cfg.Host, parsed.Host)
+			assert.Equal(t, c.cfg.Port, fmt.Sprint(parsed.Port))
+			assert.Equal(t, c.cfg.User, parsed.User)
+			assert.Equal(t, c.cfg.Password, parsed.Password)
+			assert.Equal(t, c.cfg.DBName, parsed.Database)
+		})
+	}
+}
@@ -0,0 +1,284 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgtype/pgxtype"
+	"github.com/jackc/pgx/v4"
+	"github.com/pkg/errors"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+)
+
+const (
+	outboxTable = "outbox"
+
+	topicField       = "topic"
+	keyField         = "key"
+	valueField       = "value"
+	headersField     = "headers"
+	publishedAtField = "published_at"
+	claimedAtField   = "claimed_at"
+
+	// outboxRequestIndex keeps one message of the request
+	outboxRequestIndex = "outbox_request_id_idx"
+
+	// outboxClaimTimeout is how long the listed messages are skipped by the other relays,
+	// the messages not published by the relay are listed again after it
+	outboxClaimTimeout = 30 * time.Second
+)
+
+// outboxColumns are scanned by scanOutbox.
+var outboxColumns = []string{idField, topicField, keyField, valueField, headersField, createdAtField}
+
+// OutboxList claims the messages in the transaction, the rows locked by the concurrent relays are skipped.
+func (r *repo) OutboxList(ctx context.Context, limit uint64) ([]models.OutboxMessage, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	query, args, err := squirrel.Select(outboxColumns...).
+		From(outboxTable).
+		Where(squirrel.And{
+			squirrel.Eq{publishedAtField: nil},
+			squirrel.Or{
+				squirrel.Eq{claimedAtField: nil},
+				squirrel.Expr(claimedAtField+" < now() - ?::interval", outboxClaimTimeout),
+			},
+		}).
+		OrderBy(idField).
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres OutboxList: to sql")
+	}
+	r.logger.Debugln("OutboxList", query, args)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres OutboxList: begin")
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	messages, err := selectOutbox(ctx, tx, query, args)
+	if err != nil {
+		return nil, errors.WithMessage(err, "postgres OutboxList")
+	}
+	if len(messages) == 0 {
+		return messages, nil
+	}
+
+	ids := make([]uint64, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	query, args, err = squirrel.Update(outboxTable).
+		Set(claimedAtField, now).
+		Where(squirrel.Eq{idField: ids}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres OutboxList: to sql")
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		return nil, errors.Wrap(err, "postgres OutboxList: claim")
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "postgres OutboxList: commit")
+	}
+
+	return messages, nil
+}
+
+func (r *repo) OutboxMarkPublished(ctx context.Context, ids []uint64) error {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	if len(ids) == 0 {
+		return nil
+	}
+	query, args, err := squirrel.Update(outboxTable).
+		Set(publishedAtField, now).
+		Where(squirrel.Eq{idField: ids}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "postgres OutboxMarkPublished: to sql")
+	}
+	r.logger.Debugln("OutboxMarkPublished", query, args)
+
+	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
+		return errors.Wrap(err, "postgres OutboxMarkPublished: update")
+	}
+
+	return nil
+}
+
+func (r *repo) RequestProcessed(ctx context.Context, requestID string) (bool, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	processed, err := requestProcessed(ctx, r.pool, requestID)
+	if err != nil {
+		return false, errors.WithMessage(err, "postgres RequestProcessed")
+	}
+
+	return processed, nil
+}
+
+func (r *repo) OutboxPurge(ctx context.Context, before time.Time) (int64, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	query, args, err := squirrel.Delete(outboxTable).
+		Where(squirrel.Lt{publishedAtField: before}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres OutboxPurge: to sql")
+	}
+	r.logger.Debugln("OutboxPurge", query, args)
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres OutboxPurge: delete")
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+// selectOutbox returns the messages selected in the transaction.
+func selectOutbox(ctx context.Context, tx pgx.Tx, query string, args []interface{}) ([]models.OutboxMessage, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "select")
+	}
+	defer rows.Close()
+
+	messages := make([]models.OutboxMessage, 0)
+	for rows.Next() {
+		message, err := scanOutbox(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "row scan")
+		}
+		messages = append(messages, message)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "rows")
+	}
+	return messages, nil
+}
+
+// requestProcessed returns true if the message of the request is in the outbox.
+func requestProcessed(ctx context.Context, q pgxtype.Querier, requestID string) (bool, error) {
+	query, args, err := squirrel.Select("1").
+		Prefix("SELECT EXISTS (").
+		From(outboxTable).
+		Where(squirrel.Eq{requestIDField: requestID}).
+		Suffix(")").
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "to sql")
+	}
+
+	var processed bool
+	if err = q.QueryRow(ctx, query, args...).Scan(&processed); err != nil {
+		return false, errors.Wrap(err, "select")
+	}
+	return processed, nil
+}
+
+// checkRequest returns customerrors.ErrRequestProcessed if the request of the change with the outbox message
+// is already processed. The transactions of the request wait for each other on the lock of its uid.
+func checkRequest(ctx context.Context, tx pgx.Tx) error {
+	uid := requestID(ctx)
+	if uid == "" || models.OutboxFromCtx(ctx) == nil {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", uid); err != nil {
+		return errors.Wrap(err, "request: lock")
+	}
+	processed, err := requestProcessed(ctx, tx, uid)
+	if err != nil {
+		return errors.WithMessage(err, "request")
+	}
+	if processed {
+		return errors.Wrapf(errorsPkg.ErrRequestProcessed, "request: [%s]", uid)
+	}
+	return nil
+}
+
+// insertOutbox writes the message of the change from the context in the transaction of the change.
+func insertOutbox(ctx context.Context, tx pgx.Tx, results []error) error {
+	fn := models.OutboxFromCtx(ctx)
+	if fn == nil {
+		return nil
+	}
+	message, err := fn(results)
+	if err != nil {
+		return errors.Wrap(err, "outbox: message")
+	}
+	headers, err := json.Marshal(message.Headers)
+	if err != nil {
+		return errors.Wrap(err, "outbox: marshal headers")
+	}
+
+	// the changes made without a request are not deduplicated
+	var uid interface{}
+	if message.RequestID = requestID(ctx); message.RequestID != "" {
+		uid = message.RequestID
+	}
+
+	query, args, err := squirrel.Insert(outboxTable).
+		Columns(topicField, keyField, valueField, headersField, requestIDField).
+		Values(message.Topic, message.Key, message.Value, headers, uid).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "outbox: to sql")
+	}
+	if _, err = tx.Exec(ctx, query, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation && pgErr.ConstraintName == outboxRequestIndex {
+			return errors.Wrapf(errorsPkg.ErrRequestProcessed, "request: [%s]", message.RequestID)
+		}
+		return errors.Wrap(err, "outbox: insert")
+	}
+	return nil
+}
+
+func scanOutbox(row scanner) (models.OutboxMessage, error) {
+	var (
+		message models.OutboxMessage
+		headers []byte
+	)
+	if err := row.Scan(&message.ID, &message.Topic, &message.Key, &message.Value, &headers, &message.CreatedAt); err != nil {
+		return models.OutboxMessage{}, err
+	}
+	if err := json.Unmarshal(headers, &message.Headers); err != nil {
+		return models.OutboxMessage{}, errors.Wrap(err, "unmarshal headers")
+	}
+	return message, nil
+}
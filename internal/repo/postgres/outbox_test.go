@@ -0,0 +1,355 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+)
+
+func TestRepo_UserCreateOutbox(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	message := models.OutboxMessage{
+		Topic:   "topic_mailing",
+		Key:     "user_create",
+		Headers: map[string]string{"uid": "uid-1"},
+	}
+	outboxQuery := "INSERT INTO outbox (topic,key,value,headers,request_id) VALUES ($1,$2,$3,$4,$5)"
+	args := []interface{}{user.Name, user.Password, user.Email, user.FullName}
+
+	cases := []struct {
+		name   string
+		fn     models.OutboxFunc
+		expect func()
+		expErr error
+	}{
+		{
+			name: "success",
+			fn: func(results []error) (models.OutboxMessage, error) {
+				return message, nil
+			},
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).WithArgs(args...).WillReturnRows(userRows(user))
+				expectHistory(mock, models.OperationCreate, user).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec(outboxQuery).
+					WithArgs(message.Topic, message.Key, []byte(nil), []byte(`{"uid":"uid-1"}`), nil).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "failed, message not built",
+			fn: func(results []error) (models.OutboxMessage, error) {
+				return models.OutboxMessage{}, errorsPkg.ErrUnexpected
+			},
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).WithArgs(args...).WillReturnRows(userRows(user))
+				expectHistory(mock, models.OperationCreate, user).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectRollback()
+			},
+			expErr: errorsPkg.ErrUnexpected,
+		},
+		{
+			name: "failed, insert crashed",
+			fn: func(results []error) (models.OutboxMessage, error) {
+				return message, nil
+			},
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).WithArgs(args...).WillReturnRows(userRows(user))
+				expectHistory(mock, models.OperationCreate, user).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec(outboxQuery).
+					WithArgs(message.Topic, message.Key, []byte(nil), []byte(`{"uid":"uid-1"}`), nil).
+					WillReturnError(errorsPkg.ErrUnexpected)
+				mock.ExpectRollback()
+			},
+			expErr: errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.expect()
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			err = r.UserCreate(models.WithOutbox(context.Background(), c.fn), user)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepo_UserCreateRequest(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	const uid = "uid-1"
+	message := models.OutboxMessage{Topic: "topic_mailing", Key: "user_create"}
+	lockQuery := "SELECT pg_advisory_xact_lock(hashtext($1))"
+	processedQuery := "SELECT EXISTS ( SELECT 1 FROM outbox WHERE request_id = $1 )"
+	outboxQuery := "INSERT INTO outbox (topic,key,value,headers,request_id) VALUES ($1,$2,$3,$4,$5)"
+	args := []interface{}{user.Name, user.Password, user.Email, user.FullName}
+
+	cases := []struct {
+		name   string
+		expect func()
+		expErr error
+	}{
+		{
+			name: "success, request recorded",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(lockQuery).WithArgs(uid).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+				mock.ExpectQuery(processedQuery).WithArgs(uid).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery(insertQuery).WithArgs(args...).WillReturnRows(userRows(user))
+				mock.ExpectExec(historyOne).
+					WithArgs(user.Name, models.OperationCreate, pgxmock.AnyArg(), pgxmock.AnyArg(), uid, user.UpdatedAt).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec(outboxQuery).
+					WithArgs(message.Topic, message.Key, []byte(nil), []byte("null"), uid).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "failed, request processed",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(lockQuery).WithArgs(uid).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+				mock.ExpectQuery(processedQuery).WithArgs(uid).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			expErr: errorsPkg.ErrRequestProcessed,
+		},
+		{
+			name: "failed, request recorded concurrently",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(lockQuery).WithArgs(uid).WillReturnResult(pgxmock.NewResult("SELECT", 1))
+				mock.ExpectQuery(processedQuery).WithArgs(uid).
+					WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectQuery(insertQuery).WithArgs(args...).WillReturnRows(userRows(user))
+				mock.ExpectExec(historyOne).
+					WithArgs(user.Name, models.OperationCreate, pgxmock.AnyArg(), pgxmock.AnyArg(), uid, user.UpdatedAt).
+					WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectExec(outboxQuery).
+					WithArgs(message.Topic, message.Key, []byte(nil), []byte("null"), uid).
+					WillReturnError(&pgconn.PgError{Code: uniqueViolation, ConstraintName: outboxRequestIndex})
+				mock.ExpectRollback()
+			},
+			expErr: errorsPkg.ErrRequestProcessed,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.expect()
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			ctx := models.WithOutbox(helper.InjectUidPubToCtx(context.Background(), uid, "pub"),
+				func([]error) (models.OutboxMessage, error) {
+					return message, nil
+				})
+			err = r.UserCreate(ctx, user)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepo_OutboxList(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	query := "SELECT id, topic, key, value, headers, created_at FROM outbox " +
+		"WHERE (published_at IS NULL AND (claimed_at IS NULL OR claimed_at < now() - $1::interval)) " +
+		"ORDER BY id LIMIT 10 FOR UPDATE SKIP LOCKED"
+	claimQuery := "UPDATE outbox SET claimed_at = now() WHERE id IN ($1)"
+	columns := []string{idField, topicField, keyField, valueField, headersField, createdAtField}
+	message := models.OutboxMessage{
+		ID:        1,
+		Topic:     "topic_mailing",
+		Key:       "user_create",
+		Value:     []byte("value"),
+		Headers:   map[string]string{"uid": "uid-1"},
+		CreatedAt: user.CreatedAt,
+	}
+	rows := func() *pgxmock.Rows {
+		return pgxmock.NewRows(columns).
+			AddRow(message.ID, message.Topic, message.Key, message.Value, []byte(`{"uid":"uid-1"}`), message.CreatedAt)
+	}
+
+	cases := []struct {
+		name        string
+		expect      func()
+		expMessages []models.OutboxMessage
+		expErr      error
+	}{
+		{
+			name: "success, claimed",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(outboxClaimTimeout).WillReturnRows(rows())
+				mock.ExpectExec(claimQuery).WithArgs(message.ID).WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+				mock.ExpectCommit()
+			},
+			expMessages: []models.OutboxMessage{message},
+		},
+		{
+			name: "success, nothing to publish",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(outboxClaimTimeout).WillReturnRows(pgxmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			expMessages: []models.OutboxMessage{},
+		},
+		{
+			name: "failed, query crashed",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(outboxClaimTimeout).WillReturnError(errorsPkg.ErrUnexpected)
+				mock.ExpectRollback()
+			},
+			expErr: errorsPkg.ErrUnexpected,
+		},
+		{
+			name: "failed, claim crashed",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(outboxClaimTimeout).WillReturnRows(rows())
+				mock.ExpectExec(claimQuery).WithArgs(message.ID).WillReturnError(errorsPkg.ErrUnexpected)
+				mock.ExpectRollback()
+			},
+			expErr: errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.expect()
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			messages, err := r.OutboxList(context.Background(), 10)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expMessages, messages)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepo_OutboxMarkPublished(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	cases := []struct {
+		name    string
+		execErr error
+		expErr  error
+	}{
+		{
+			name: "success",
+		},
+		{
+			name:    "failed, exec crashed",
+			execErr: errorsPkg.ErrUnexpected,
+			expErr:  errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mock.ExpectExec("UPDATE outbox SET published_at = now() WHERE id IN ($1,$2)").
+				WithArgs(uint64(1), uint64(2)).
+				WillReturnResult(pgxmock.NewResult("UPDATE", 2)).
+				WillReturnError(c.execErr)
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			err = r.OutboxMarkPublished(context.Background(), []uint64{1, 2})
+			assert.ErrorIs(t, err, c.expErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepo_OutboxPurge(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+	before := time.Date(2022, 11, 20, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		execErr   error
+		expPurged int64
+		expErr    error
+	}{
+		{
+			name:      "success",
+			expPurged: 2,
+		},
+		{
+			name:    "failed, exec crashed",
+			execErr: errorsPkg.ErrUnexpected,
+			expErr:  errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mock.ExpectExec("DELETE FROM outbox WHERE published_at < $1").
+				WithArgs(before).
+				WillReturnResult(pgxmock.NewResult("DELETE", 2)).
+				WillReturnError(c.execErr)
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			purged, err := r.OutboxPurge(context.Background(), before)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expPurged, purged)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
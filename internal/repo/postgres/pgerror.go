@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"unicode/utf8"
+
+	"github.com/jackc/pgconn"
+	"github.com/pkg/errors"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+// PostgreSQL error codes of the users table constraint violations.
+const (
+	uniqueViolation   = "23505"
+	checkViolation    = "23514"
+	stringDataTooLong = "22001"
+)
+
+// constraintColumns are the columns of the users table constraints.
+var constraintColumns = map[string]string{
+	"users_pkey":      nameField,
+	"users_email_key": emailField,
+	"name_right":      nameField,
+	"email_right":     emailField,
+}
+
+// columnLengths are the varchar limits, PostgreSQL does not report the column of a too long value.
+var columnLengths = map[string]int{
+	nameField:     30,
+	passwordField: 255,
+	emailField:    50,
+	fullNameField: 255,
+}
+
+// pgError turns the constraint violation into customerrors.FieldError,
+// values are the written columns, they are used to find the too long one.
+func pgError(err error, values map[string]string) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	switch pgErr.Code {
+	case uniqueViolation:
+		field := constraintColumn(pgErr)
+		if field == emailField {
+			return &errorsPkg.FieldError{Field: field, Err: errorsPkg.ErrEmailAlreadyExists}
+		}
+		return &errorsPkg.FieldError{Field: field, Err: errorsPkg.ErrUserAlreadyExists}
+	case checkViolation:
+		return &errorsPkg.FieldError{Field: constraintColumn(pgErr), Err: errorsPkg.ErrInvalidField}
+	case stringDataTooLong:
+		field := pgErr.ColumnName
+		for _, column := range insertColumns {
+			value, ok := values[column]
+			if ok && field == "" && utf8.RuneCountInString(value) > columnLengths[column] {
+				field = column
+			}
+		}
+		return &errorsPkg.FieldError{Field: field, Err: errorsPkg.ErrFieldTooLong}
+	}
+	return err
+}
+
+func constraintColumn(pgErr *pgconn.PgError) string {
+	if pgErr.ColumnName != "" {
+		return pgErr.ColumnName
+	}
+	if column, ok := constraintColumns[pgErr.ConstraintName]; ok {
+		return column
+	}
+	return pgErr.ConstraintName
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+)
+
+func Test_pgError(t *testing.T) {
+	values := userValues(user)
+	values[emailField] = strings.Repeat("e", 51)
+
+	cases := []struct {
+		name     string
+		err      error
+		expErr   error
+		expField string
+	}{
+		{
+			name:     "unique email",
+			err:      &pgconn.PgError{Code: uniqueViolation, ConstraintName: "users_email_key"},
+			expErr:   errorsPkg.ErrEmailAlreadyExists,
+			expField: emailField,
+		},
+		{
+			name:     "unique name",
+			err:      &pgconn.PgError{Code: uniqueViolation, ConstraintName: "users_pkey"},
+			expErr:   errorsPkg.ErrUserAlreadyExists,
+			expField: nameField,
+		},
+		{
+			name:     "check name",
+			err:      &pgconn.PgError{Code: checkViolation, ConstraintName: "name_right"},
+			expErr:   errorsPkg.ErrInvalidField,
+			expField: nameField,
+		},
+		{
+			name:     "too long email",
+			err:      &pgconn.PgError{Code: stringDataTooLong},
+			expErr:   errorsPkg.ErrFieldTooLong,
+			expField: emailField,
+		},
+		{
+			name:   "other error",
+			err:    errorsPkg.ErrUnexpected,
+			expErr: errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := pgError(c.err, values)
+			assert.ErrorIs(t, err, c.expErr)
+
+			assert.Equal(t, c.expField != "", errorsPkg.IsFieldError(err))
+			var fieldErr *errorsPkg.FieldError
+			if c.expField != "" && assert.ErrorAs(t, err, &fieldErr) {
+				assert.Equal(t, c.expField, fieldErr.Field)
+			}
+		})
+	}
+}
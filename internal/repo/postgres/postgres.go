@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgtype/pgxtype"
@@ -14,25 +16,58 @@ import (
 	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
 	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	pgModels "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres/models"
 	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
 )
 
 const (
 	usersTable = "users"
+	// batchTable is the temporary table the batch is copied to before the insert
+	batchTable = "users_batch"
 
 	nameField      = "name"
 	passwordField  = "password"
 	emailField     = "email"
 	fullNameField  = "full_name"
 	createdAtField = "created_at"
+	updatedAtField = "updated_at"
+	versionField   = "version"
+	deletedAtField = "deleted_at"
 
 	desc = " DESC"
 
 	repoService = "repo"
 )
 
+// userColumns are scanned by scanUser, deleted_at is NULL for not deleted users.
+var userColumns = []string{
+	nameField, passwordField, emailField, fullNameField, createdAtField, updatedAtField, versionField,
+	"COALESCE(" + deletedAtField + ", 0)",
+}
+
+// insertColumns are set by the service, the timestamps are set by the database.
+var insertColumns = []string{nameField, passwordField, emailField, fullNameField}
+
+// now is the time of the change set by the database.
+var now = squirrel.Expr("now()")
+
+// epochNow is the UNIX time of the change set by the database.
+const epochNow = "extract(epoch from now())::bigint"
+
+// sortColumns are the columns of the list sort fields.
+var sortColumns = map[string]string{
+	models.SortName:      nameField,
+	models.SortCreatedAt: createdAtField,
+	models.SortEmail:     emailField,
+	models.SortFullName:  fullNameField,
+}
+
+// likeEscaper escapes the LIKE pattern characters of the user input.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type PgxPool interface {
 	pgxtype.Querier
+	Begin(ctx context.Context) (pgx.Tx, error)
 	Close()
 }
 
@@ -45,8 +80,7 @@ func New(pool *pgxpool.Pool, logger *zap.SugaredLogger) repoPkg.Interface {
 }
 
 func NewPostgres(ctx context.Context, host, port, user, password, dbname string, logger *zap.SugaredLogger) (*pgxpool.Pool, error) {
-	psqlConn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		host, port, user, password, dbname)
+	psqlConn := pgModels.Config{Host: host, Port: port, User: user, Password: password, DBName: dbname}.DSN()
 	logger.Debugln("PostgreSQL connection", psqlConn)
 
 	pool, err := pgxpool.Connect(ctx, psqlConn)
@@ -72,37 +106,260 @@ func (r *repo) UserCreate(ctx context.Context, user models.User) error {
 	}()
 	go helper.StartNewSpan(ctx, repoService, stop)
 
+	if err := r.inTx(ctx, func(tx pgx.Tx) error {
+		if err := checkRequest(ctx, tx); err != nil {
+			return err
+		}
+		if err := r.insert(ctx, tx, user); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, nil)
+	}); err != nil {
+		return errors.WithMessage(err, "postgres UserCreate")
+	}
+
+	return nil
+}
+
+// insert creates the user with its history record in the transaction.
+func (r *repo) insert(ctx context.Context, tx pgx.Tx, user models.User) error {
 	query, args, err := squirrel.Insert(usersTable).
-		Columns(nameField, passwordField, emailField, fullNameField, createdAtField).
-		Values(user.Name, user.Password, user.Email, user.FullName, user.CreatedAt).
+		Columns(insertColumns...).
+		Values(user.Name, user.Password, user.Email, user.FullName).
+		Suffix(returningUser).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
-		return errors.Wrap(err, "postgres UserCreate: to sql")
+		return errors.Wrap(err, "to sql")
 	}
-	r.logger.Debugln("UserCreate", query, args)
+	r.logger.Debugln("insert", query, args)
 
-	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
-		return errors.Wrap(err, "postgres UserCreate: insert")
+	created, err := scanUser(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		return errors.Wrap(pgError(err, userValues(user)), "insert")
 	}
+	return insertHistory(ctx, tx, models.NewUserChange(models.OperationCreate, nil, created, requestID(ctx)))
+}
 
-	return nil
+// UserCreateBatch copies the users to a temporary table and inserts them skipping the conflicts,
+// the skipped users with the existing names are reported as taken names, the others as taken emails.
+// If the insert fails as a whole, the users are inserted one by one to get the error of every user.
+// The outbox message gets the errors of the users in the transaction of the batch.
+func (r *repo) UserCreateBatch(ctx context.Context, users []models.User) ([]error, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres UserCreateBatch: begin")
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err = checkRequest(ctx, tx); err != nil {
+		return nil, errors.WithMessage(err, "postgres UserCreateBatch")
+	}
+	created, existing, err := r.copyBatch(ctx, tx, users)
+	if err != nil {
+		r.logger.Debugln("UserCreateBatch", err)
+		_ = tx.Rollback(ctx)
+		res, err := r.insertEach(ctx, users)
+		if err != nil {
+			return nil, errors.WithMessage(err, "postgres UserCreateBatch")
+		}
+		return res, nil
+	}
+	res := batchResults(users, created, existing)
+	if err = insertOutbox(ctx, tx, res); err != nil {
+		return nil, errors.WithMessage(err, "postgres UserCreateBatch")
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return nil, errors.Wrap(err, "postgres UserCreateBatch: commit")
+	}
+
+	return res, nil
+}
+
+// batchResults returns the errors of the batch users skipped by the insert.
+func batchResults(users []models.User, created, existing map[string]bool) []error {
+	res := make([]error, len(users))
+	for i, user := range users {
+		switch {
+		case created[user.Name]:
+		case existing[user.Name]:
+			res[i] = errors.Wrapf(errorsPkg.ErrUserAlreadyExists, "user-name: [%s]", user.Name)
+		default:
+			res[i] = errors.Wrapf(errorsPkg.ErrEmailAlreadyExists, "email: [%s]", user.Email)
+		}
+	}
+	return res
+}
+
+// copyBatch inserts the users in the transaction and returns the names of the created ones
+// and the names of the skipped ones which were already stored.
+func (r *repo) copyBatch(ctx context.Context, tx pgx.Tx, users []models.User) (map[string]bool, map[string]bool, error) {
+	if _, err := tx.Exec(ctx, fmt.Sprintf("CREATE TEMP TABLE %s (LIKE %s INCLUDING DEFAULTS) ON COMMIT DROP",
+		batchTable, usersTable)); err != nil {
+		return nil, nil, errors.Wrap(err, "postgres UserCreateBatch: create table")
+	}
+
+	rows := make([][]interface{}, 0, len(users))
+	for _, user := range users {
+		rows = append(rows, []interface{}{user.Name, user.Password, user.Email, user.FullName})
+	}
+	if _, err := tx.CopyFrom(ctx, pgx.Identifier{batchTable}, insertColumns, pgx.CopyFromRows(rows)); err != nil {
+		return nil, nil, errors.Wrap(err, "postgres UserCreateBatch: copy")
+	}
+
+	query, args, err := squirrel.Insert(usersTable).
+		Columns(insertColumns...).
+		Select(squirrel.Select(insertColumns...).From(batchTable)).
+		Suffix("ON CONFLICT DO NOTHING " + returningUser).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "postgres UserCreateBatch: to sql")
+	}
+	r.logger.Debugln("UserCreateBatch", query, args)
+
+	created, changes, err := queryCreated(ctx, tx, query, args)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "postgres UserCreateBatch: insert")
+	}
+	if err = insertHistory(ctx, tx, changes...); err != nil {
+		return nil, nil, errors.WithMessage(err, "postgres UserCreateBatch")
+	}
+	skipped := make([]string, 0)
+	for _, user := range users {
+		if !created[user.Name] {
+			skipped = append(skipped, user.Name)
+		}
+	}
+	if len(skipped) == 0 {
+		return created, nil, nil
+	}
+
+	query, args, err = squirrel.Select(nameField).
+		From(usersTable).
+		Where(squirrel.Eq{nameField: skipped}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "postgres UserCreateBatch: to sql")
+	}
+	r.logger.Debugln("UserCreateBatch", query, args)
+
+	existing, err := queryNames(ctx, tx, query, args)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "postgres UserCreateBatch: select existing")
+	}
+	return created, existing, nil
 }
 
-func (r *repo) UserUpdate(ctx context.Context, user models.User) error {
+// queryCreated returns the set of the names of the users created by the query and their history records.
+func queryCreated(ctx context.Context, tx pgx.Tx, query string, args []interface{}) (map[string]bool, []models.UserChange, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[string]bool)
+	changes := make([]models.UserChange, 0)
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "row scan")
+		}
+		names[user.Name] = true
+		changes = append(changes, models.NewUserChange(models.OperationCreate, nil, user, requestID(ctx)))
+	}
+	return names, changes, rows.Err()
+}
+
+// queryNames returns the set of the user names selected by the query.
+func queryNames(ctx context.Context, tx pgx.Tx, query string, args []interface{}) (map[string]bool, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); err != nil {
+			return nil, errors.Wrap(err, "row scan")
+		}
+		names[name] = true
+	}
+	return names, rows.Err()
+}
+
+// insertEach inserts the users one by one in the savepoints of one transaction.
+func (r *repo) insertEach(ctx context.Context, users []models.User) ([]error, error) {
+	res := make([]error, len(users))
+	if err := r.inTx(ctx, func(tx pgx.Tx) error {
+		if err := checkRequest(ctx, tx); err != nil {
+			return err
+		}
+		for i, user := range users {
+			savepoint, err := tx.Begin(ctx)
+			if err != nil {
+				return errors.Wrap(err, "savepoint")
+			}
+			if err = r.insert(ctx, savepoint, user); err != nil {
+				res[i] = err
+				if err = savepoint.Rollback(ctx); err != nil {
+					return errors.Wrap(err, "rollback to savepoint")
+				}
+				continue
+			}
+			if err = savepoint.Commit(ctx); err != nil {
+				return errors.Wrap(err, "release savepoint")
+			}
+		}
+		return insertOutbox(ctx, tx, res)
+	}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *repo) UserUpdate(ctx context.Context, profile models.Profile) error {
 	stop := make(chan struct{})
 	defer func() {
 		stop <- struct{}{}
 	}()
 	go helper.StartNewSpan(ctx, repoService, stop)
 
-	query, args, err := squirrel.Update(usersTable).
-		Set(passwordField, user.Password).
-		Set(emailField, user.Email).
-		Set(fullNameField, user.FullName).
-		Where(squirrel.Eq{
-			nameField: user.Name,
-		}).
+	if profile.Empty() {
+		return errors.Wrap(errorsPkg.ErrValidation, "postgres UserUpdate: nothing to update")
+	}
+
+	update := squirrel.Update(usersTable)
+	values := make(map[string]string, 3)
+	if profile.Password != nil {
+		update = update.Set(passwordField, *profile.Password)
+		values[passwordField] = *profile.Password
+	}
+	if profile.Email != nil {
+		update = update.Set(emailField, *profile.Email)
+		values[emailField] = *profile.Email
+	}
+	if profile.FullName != nil {
+		update = update.Set(fullNameField, *profile.FullName)
+		values[fullNameField] = *profile.FullName
+	}
+	query, args, err := update.
+		Set(versionField, squirrel.Expr(versionField+" + 1")).
+		Set(updatedAtField, now).
+		Where(whereVersion(profile.Name, profile.Version)).
+		Suffix(returningUser).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -110,24 +367,33 @@ func (r *repo) UserUpdate(ctx context.Context, user models.User) error {
 	}
 	r.logger.Debugln("UserUpdate", query, args)
 
-	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
-		return errors.Wrap(err, "postgres UserUpdate: update")
+	if err = r.change(ctx, models.OperationUpdate, profile.Name, query, args,
+		func(old *models.User) error {
+			return checkVersion(old, profile.Name, profile.Version)
+		},
+		func(err error) error {
+			return pgError(err, values)
+		},
+	); err != nil {
+		return errors.WithMessage(err, "postgres UserUpdate")
 	}
 
 	return nil
 }
 
-func (r *repo) UserDelete(ctx context.Context, name string) error {
+func (r *repo) UserDelete(ctx context.Context, name string, version uint64) error {
 	stop := make(chan struct{})
 	defer func() {
 		stop <- struct{}{}
 	}()
 	go helper.StartNewSpan(ctx, repoService, stop)
 
-	query, args, err := squirrel.Delete(usersTable).
-		Where(squirrel.Eq{
-			nameField: name,
-		}).
+	query, args, err := squirrel.Update(usersTable).
+		Set(deletedAtField, squirrel.Expr(epochNow)).
+		Set(versionField, squirrel.Expr(versionField+" + 1")).
+		Set(updatedAtField, now).
+		Where(whereVersion(name, version)).
+		Suffix(returningUser).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -135,61 +401,170 @@ func (r *repo) UserDelete(ctx context.Context, name string) error {
 	}
 	r.logger.Debugln("UserDelete", query, args)
 
-	if _, err = r.pool.Exec(ctx, query, args...); err != nil {
-		return errors.Wrap(err, "postgres UserDelete: delete")
+	if err = r.change(ctx, models.OperationDelete, name, query, args,
+		func(old *models.User) error {
+			return checkVersion(old, name, version)
+		},
+		nil,
+	); err != nil {
+		return errors.WithMessage(err, "postgres UserDelete")
 	}
 
 	return nil
 }
 
-func (r *repo) UserGet(ctx context.Context, name string) (models.User, error) {
+func (r *repo) UserRestore(ctx context.Context, name string) error {
 	stop := make(chan struct{})
 	defer func() {
 		stop <- struct{}{}
 	}()
 	go helper.StartNewSpan(ctx, repoService, stop)
 
-	query, args, err := squirrel.Select(nameField, passwordField, emailField, fullNameField, createdAtField).
-		From(usersTable).
-		Where(squirrel.Eq{
-			nameField: name,
+	query, args, err := squirrel.Update(usersTable).
+		Set(deletedAtField, nil).
+		Set(versionField, squirrel.Expr(versionField+" + 1")).
+		Set(updatedAtField, now).
+		Where(squirrel.And{
+			squirrel.Eq{nameField: name},
+			squirrel.NotEq{deletedAtField: nil},
 		}).
+		Suffix(returningUser).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
-		return models.User{}, errors.Wrap(err, "postgres UserGet: to sql")
+		return errors.Wrap(err, "postgres UserRestore: to sql")
+	}
+	r.logger.Debugln("UserRestore", query, args)
+
+	if err = r.change(ctx, models.OperationRestore, name, query, args,
+		func(old *models.User) error {
+			if old == nil || !old.Deleted() {
+				return errors.Wrapf(errorsPkg.ErrUserNotFound, "deleted user-name: [%s]", name)
+			}
+			return nil
+		},
+		nil,
+	); err != nil {
+		return errors.WithMessage(err, "postgres UserRestore")
 	}
-	r.logger.Debugln("UserGet", query, args)
 
-	row := r.pool.QueryRow(ctx, query, args...)
-	var user models.User
-	if err = row.Scan(&user.Name, &user.Password, &user.Email, &user.FullName, &user.CreatedAt); err != nil {
+	return nil
+}
+
+func (r *repo) UserPurge(ctx context.Context, retention time.Duration) (int64, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	query, args, err := squirrel.Delete(usersTable).
+		Where(squirrel.Expr(deletedAtField+" < "+epochNow+" - ?", int64(retention/time.Second))).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres UserPurge: to sql")
+	}
+	r.logger.Debugln("UserPurge", query, args)
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres UserPurge: delete")
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *repo) UserGet(ctx context.Context, name string, withDeleted bool) (models.User, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	user, err := r.get(ctx, squirrel.Eq{nameField: name}, withDeleted)
+	if err != nil {
+		return models.User{}, errors.WithMessage(err, "postgres UserGet")
+	}
+	r.logger.Debugln("UserGet", user.String())
+
+	return user, nil
+}
+
+func (r *repo) UserGetByEmail(ctx context.Context, email string, withDeleted bool) (models.User, error) {
+	stop := make(chan struct{})
+	defer func() {
+		stop <- struct{}{}
+	}()
+	go helper.StartNewSpan(ctx, repoService, stop)
+
+	user, err := r.get(ctx, squirrel.Eq{emailField: email}, withDeleted)
+	if err != nil {
+		return models.User{}, errors.WithMessage(err, "postgres UserGetByEmail")
+	}
+	r.logger.Debugln("UserGetByEmail", user.String())
+
+	return user, nil
+}
+
+// get returns the user matching the unique column.
+func (r *repo) get(ctx context.Context, where squirrel.Eq, withDeleted bool) (models.User, error) {
+	if !withDeleted {
+		where[deletedAtField] = nil
+	}
+	query, args, err := squirrel.Select(userColumns...).
+		From(usersTable).
+		Where(where).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return models.User{}, errors.Wrap(err, "to sql")
+	}
+	r.logger.Debugln("get", query, args)
+
+	user, err := scanUser(r.pool.QueryRow(ctx, query, args...))
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return models.User{}, errorsPkg.ErrUserNotFound
 		}
-		return models.User{}, errors.Wrap(err, "postgres UserGet: get")
+		return models.User{}, errors.Wrap(err, "get")
 	}
-	r.logger.Debugln("UserGet", user.String())
 
 	return user, nil
 }
 
-func (r *repo) UserList(ctx context.Context, order bool, limit, offset uint64) ([]models.User, error) {
+func (r *repo) UserList(ctx context.Context, params models.UserListParams) ([]models.User, error) {
 	stop := make(chan struct{})
 	defer func() {
 		stop <- struct{}{}
 	}()
 	go helper.StartNewSpan(ctx, repoService, stop)
 
-	var sort string
-	if order {
-		sort = desc
-	}
-	query, args, err := squirrel.Select(nameField, passwordField, emailField, fullNameField, createdAtField).
+	selectBuilder := squirrel.Select(userColumns...).
 		From(usersTable).
-		Limit(limit).
-		Offset(offset * limit).
-		OrderBy(nameField + sort).
+		Limit(params.Limit)
+	if !params.WithDeleted {
+		selectBuilder = selectBuilder.Where(squirrel.Eq{deletedAtField: nil})
+	}
+	if where := filter(params.Filter); len(where) > 0 {
+		selectBuilder = selectBuilder.Where(where)
+	}
+	sorting := params.Sorting()
+	if params.After != nil {
+		selectBuilder = selectBuilder.Where(keyset(sorting, *params.After))
+	} else {
+		selectBuilder = selectBuilder.Offset(params.Offset * params.Limit)
+	}
+	orderBy := make([]string, 0, len(sorting))
+	for _, sort := range sorting {
+		if sort.Desc {
+			orderBy = append(orderBy, sortColumns[sort.Field]+desc)
+		} else {
+			orderBy = append(orderBy, sortColumns[sort.Field])
+		}
+	}
+	query, args, err := selectBuilder.
+		OrderBy(orderBy...).
 		PlaceholderFormat(squirrel.Dollar).
 		ToSql()
 	if err != nil {
@@ -201,20 +576,119 @@ func (r *repo) UserList(ctx context.Context, order bool, limit, offset uint64) (
 	if err != nil {
 		return nil, errors.Wrap(err, "postgres UserList: query")
 	}
+	defer rows.Close()
 
 	users := make([]models.User, 0)
 	for rows.Next() {
-		var user models.User
-		if err = rows.Scan(&user.Name, &user.Password, &user.Email, &user.FullName, &user.CreatedAt); err != nil {
+		user, err := scanUser(rows)
+		if err != nil {
 			return nil, errors.Wrap(err, "postgres UserList: row scan")
 		}
 		users = append(users, user)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "postgres UserList: rows")
+	}
 	r.logger.Debugln("UserList", users)
 
 	return users, nil
 }
 
+// checkVersion checks the locked user row can be changed: it is not deleted and has the expected version.
+func checkVersion(old *models.User, name string, version uint64) error {
+	if old == nil || old.Deleted() {
+		return errors.Wrapf(errorsPkg.ErrUserNotFound, "user-name: [%s]", name)
+	}
+	if version != 0 && old.Version != version {
+		return errors.Wrapf(errorsPkg.ErrVersionConflict, "user-name: [%s], version: [%d], expected: [%d]",
+			name, old.Version, version)
+	}
+	return nil
+}
+
+// filter matches the users passing the list filter.
+func filter(f models.UserFilter) squirrel.And {
+	and := squirrel.And{}
+	if f.EmailDomain != "" {
+		and = append(and, squirrel.ILike{emailField: "%@" + likeEscaper.Replace(f.EmailDomain)})
+	}
+	if f.FullName != "" {
+		and = append(and, squirrel.ILike{fullNameField: "%" + likeEscaper.Replace(f.FullName) + "%"})
+	}
+	if f.CreatedFrom != 0 {
+		and = append(and, squirrel.GtOrEq{createdAtField: time.Unix(f.CreatedFrom, 0)})
+	}
+	// the bound is inclusive up to the end of the second
+	if f.CreatedTo != 0 {
+		and = append(and, squirrel.Lt{createdAtField: time.Unix(f.CreatedTo+1, 0)})
+	}
+	return and
+}
+
+// keyset matches the users following the last user of the previous page in the list order:
+// (a > $1) OR (a = $1 AND b > $2) OR ...
+func keyset(sorting []models.UserSort, after models.User) squirrel.Or {
+	or := squirrel.Or{}
+	for i, sort := range sorting {
+		and := squirrel.And{}
+		for _, prev := range sorting[:i] {
+			and = append(and, squirrel.Eq{sortColumns[prev.Field]: sortValue(prev.Field, after)})
+		}
+		if sort.Desc {
+			and = append(and, squirrel.Lt{sortColumns[sort.Field]: sortValue(sort.Field, after)})
+		} else {
+			and = append(and, squirrel.Gt{sortColumns[sort.Field]: sortValue(sort.Field, after)})
+		}
+		or = append(or, and)
+	}
+	return or
+}
+
+func sortValue(field string, user models.User) interface{} {
+	switch field {
+	case models.SortCreatedAt:
+		return user.CreatedAt
+	case models.SortEmail:
+		return user.Email
+	case models.SortFullName:
+		return user.FullName
+	default:
+		return user.Name
+	}
+}
+
+// whereVersion matches not deleted user with the expected version.
+func whereVersion(name string, version uint64) squirrel.Eq {
+	where := squirrel.Eq{
+		nameField:      name,
+		deletedAtField: nil,
+	}
+	if version != 0 {
+		where[versionField] = version
+	}
+	return where
+}
+
+// userValues are the written text columns of the user.
+func userValues(user models.User) map[string]string {
+	return map[string]string{
+		nameField:     user.Name,
+		passwordField: user.Password,
+		emailField:    user.Email,
+		fullNameField: user.FullName,
+	}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row scanner) (models.User, error) {
+	var user models.User
+	err := row.Scan(&user.Name, &user.Password, &user.Email, &user.FullName, &user.CreatedAt, &user.UpdatedAt, &user.Version, &user.DeletedAt)
+	return user, err
+}
+
 func (r *repo) Close() {
 	r.pool.Close()
 	r.logger.Infoln("PostgreSQL connection closed")
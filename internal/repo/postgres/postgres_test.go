@@ -2,8 +2,9 @@ package postgres
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/pashagolub/pgxmock"
@@ -20,10 +21,33 @@ var (
 		Password:  "123",
 		Email:     "ivan@email.com",
 		FullName:  "Ivan the Dummy",
-		CreatedAt: 1660412940,
+		CreatedAt: time.Unix(1660412940, 0),
+		UpdatedAt: time.Unix(1660412950, 0),
 	}
 )
 
+const (
+	returning   = " RETURNING name, password, email, full_name, created_at, updated_at, version, COALESCE(deleted_at, 0)"
+	lockQuery   = "SELECT name, password, email, full_name, created_at, updated_at, version, COALESCE(deleted_at, 0) FROM users WHERE name = $1 FOR UPDATE"
+	insertQuery = "INSERT INTO users (name,password,email,full_name) VALUES ($1,$2,$3,$4)" + returning
+	historyOne  = "INSERT INTO user_history (name,operation,old_values,new_values,request_id,changed_at) VALUES ($1,$2,$3,$4,$5,$6)"
+)
+
+// userRows returns the rows of the users in the userColumns order.
+func userRows(users ...models.User) *pgxmock.Rows {
+	rows := pgxmock.NewRows([]string{nameField, passwordField, emailField, fullNameField, createdAtField, updatedAtField, versionField, deletedAtField})
+	for _, u := range users {
+		rows.AddRow(u.Name, u.Password, u.Email, u.FullName, u.CreatedAt, u.UpdatedAt, u.Version, u.DeletedAt)
+	}
+	return rows
+}
+
+// expectHistory expects the history record of the operation on the user.
+func expectHistory(mock pgxmock.PgxPoolIface, operation string, u models.User) *pgxmock.ExpectedExec {
+	return mock.ExpectExec(historyOne).
+		WithArgs(u.Name, operation, pgxmock.AnyArg(), pgxmock.AnyArg(), "", u.UpdatedAt)
+}
+
 func TestRepo_UserCreate(t *testing.T) {
 	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
 	if err != nil {
@@ -31,28 +55,47 @@ func TestRepo_UserCreate(t *testing.T) {
 	}
 	defer mock.Close()
 
+	args := []interface{}{user.Name, user.Password, user.Email, user.FullName}
+
 	cases := []struct {
 		name   string
+		expect func()
 		expErr error
 	}{
 		{
-			name:   "success",
+			name: "success",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).WithArgs(args...).WillReturnRows(userRows(user))
+				expectHistory(mock, models.OperationCreate, user).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			},
 			expErr: nil,
 		},
 		{
-			name:   "failed, exec crashed",
+			name: "failed, insert crashed",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).WithArgs(args...).WillReturnError(errorsPkg.ErrUnexpected)
+				mock.ExpectRollback()
+			},
+			expErr: errorsPkg.ErrUnexpected,
+		},
+		{
+			name: "failed, history crashed",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).WithArgs(args...).WillReturnRows(userRows(user))
+				expectHistory(mock, models.OperationCreate, user).WillReturnError(errorsPkg.ErrUnexpected)
+				mock.ExpectRollback()
+			},
 			expErr: errorsPkg.ErrUnexpected,
 		},
 	}
-	query := "INSERT INTO users (name,password,email,full_name,created_at) VALUES ($1,$2,$3,$4,$5)"
-	args := []interface{}{user.Name, user.Password, user.Email, user.FullName, user.CreatedAt}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			mock.ExpectExec(query).
-				WithArgs(args...).
-				WillReturnResult(pgxmock.NewResult("INSERT", 1)).
-				WillReturnError(c.expErr)
+			c.expect()
 
 			r := &repo{
 				pool:   mock,
@@ -60,50 +103,252 @@ func TestRepo_UserCreate(t *testing.T) {
 			}
 			err = r.UserCreate(context.Background(), user)
 			assert.ErrorIs(t, err, c.expErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
-func TestRepo_UserUpdate(t *testing.T) {
+func TestRepo_UserCreateBatch(t *testing.T) {
 	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
 	if err != nil {
 		t.Fatal(err)
 	}
 	defer mock.Close()
 
+	other := models.User{
+		Name:      "Boris",
+		Password:  "321",
+		Email:     "boris@email.com",
+		FullName:  "Boris The Blade",
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+	users := []models.User{user, other}
+
+	createTable := "CREATE TEMP TABLE users_batch (LIKE users INCLUDING DEFAULTS) ON COMMIT DROP"
+	insertSelect := "INSERT INTO users (name,password,email,full_name) " +
+		"SELECT name, password, email, full_name FROM users_batch ON CONFLICT DO NOTHING" + returning
+	selectExisting := "SELECT name FROM users WHERE name IN ($1)"
+
 	cases := []struct {
 		name   string
+		expect func()
+		expRes []error
 		expErr error
 	}{
 		{
-			name:   "success",
-			expErr: nil,
+			name: "success, existing user skipped",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(createTable).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom(`"users_batch"`, insertColumns).WillReturnResult(2)
+				mock.ExpectQuery(insertSelect).WillReturnRows(userRows(other))
+				expectHistory(mock, models.OperationCreate, other).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectQuery(selectExisting).WithArgs(user.Name).
+					WillReturnRows(pgxmock.NewRows([]string{nameField}).AddRow(user.Name))
+				mock.ExpectCommit()
+			},
+			expRes: []error{errorsPkg.ErrUserAlreadyExists, nil},
+		},
+		{
+			name: "success, existing email skipped",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(createTable).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom(`"users_batch"`, insertColumns).WillReturnResult(2)
+				mock.ExpectQuery(insertSelect).WillReturnRows(userRows(user))
+				expectHistory(mock, models.OperationCreate, user).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectQuery(selectExisting).WithArgs(other.Name).
+					WillReturnRows(pgxmock.NewRows([]string{nameField}))
+				mock.ExpectCommit()
+			},
+			expRes: []error{nil, errorsPkg.ErrEmailAlreadyExists},
+		},
+		{
+			name: "success, all created",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(createTable).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom(`"users_batch"`, insertColumns).WillReturnResult(2)
+				mock.ExpectQuery(insertSelect).WillReturnRows(userRows(user, other))
+				mock.ExpectExec(historyOne+",($7,$8,$9,$10,$11,$12)").
+					WithArgs(user.Name, models.OperationCreate, pgxmock.AnyArg(), pgxmock.AnyArg(), "", user.UpdatedAt,
+						other.Name, models.OperationCreate, pgxmock.AnyArg(), pgxmock.AnyArg(), "", other.UpdatedAt).
+					WillReturnResult(pgxmock.NewResult("INSERT", 2))
+				mock.ExpectCommit()
+			},
+			expRes: []error{nil, nil},
+		},
+		{
+			name: "success, inserted one by one after failed copy",
+			expect: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(createTable).WillReturnResult(pgxmock.NewResult("CREATE", 0))
+				mock.ExpectCopyFrom(`"users_batch"`, insertColumns).WillReturnError(errorsPkg.ErrUnexpected)
+				mock.ExpectRollback()
+				mock.ExpectBegin()
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs(user.Name, user.Password, user.Email, user.FullName).
+					WillReturnRows(userRows(user))
+				expectHistory(mock, models.OperationCreate, user).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+				mock.ExpectBegin()
+				mock.ExpectQuery(insertQuery).
+					WithArgs(other.Name, other.Password, other.Email, other.FullName).
+					WillReturnError(errorsPkg.ErrValidation)
+				mock.ExpectRollback()
+				mock.ExpectCommit()
+			},
+			expRes: []error{nil, errorsPkg.ErrValidation},
 		},
 		{
-			name:   "failed, exec crashed",
+			name: "failed, begin crashed",
+			expect: func() {
+				mock.ExpectBegin().WillReturnError(errorsPkg.ErrUnexpected)
+			},
 			expErr: errorsPkg.ErrUnexpected,
 		},
 	}
-	query := "UPDATE users SET password = $1, email = $2, full_name = $3 WHERE name = $4"
-	args := []interface{}{user.Password, user.Email, user.FullName, user.Name}
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			mock.ExpectExec(query).
-				WithArgs(args...).
-				WillReturnResult(pgxmock.NewResult("UPDATE", 1)).
-				WillReturnError(c.expErr)
+			c.expect()
 
 			r := &repo{
 				pool:   mock,
 				logger: loggerPkg.NewFatal(),
 			}
-			err = r.UserUpdate(context.Background(), user)
+			res, err := r.UserCreateBatch(context.Background(), users)
 			assert.ErrorIs(t, err, c.expErr)
+			assert.Len(t, res, len(c.expRes))
+			for i := range c.expRes {
+				assert.ErrorIs(t, res[i], c.expRes[i])
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
 
+func TestRepo_UserUpdate(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	full := models.Profile{
+		Name:     user.Name,
+		Password: &user.Password,
+		Email:    &user.Email,
+		FullName: &user.FullName,
+	}
+	emailOnly := models.Profile{
+		Name:  user.Name,
+		Email: &user.Email,
+	}
+	versioned := emailOnly
+	versioned.Version = 2
+
+	stored := user
+	stored.Version = 3
+	deleted := stored
+	deleted.DeletedAt = 1660412960
+	updated := stored
+	updated.Version = 4
+
+	cases := []struct {
+		name      string
+		profile   models.Profile
+		query     string
+		args      []interface{}
+		old       *models.User
+		updateErr error
+		expErr    error
+	}{
+		{
+			name:    "success, all fields",
+			profile: full,
+			query:   "UPDATE users SET password = $1, email = $2, full_name = $3, version = version + 1, updated_at = now() WHERE deleted_at IS NULL AND name = $4" + returning,
+			args:    []interface{}{user.Password, user.Email, user.FullName, user.Name},
+			old:     &stored,
+		},
+		{
+			name:    "success, only email",
+			profile: emailOnly,
+			query:   "UPDATE users SET email = $1, version = version + 1, updated_at = now() WHERE deleted_at IS NULL AND name = $2" + returning,
+			args:    []interface{}{user.Email, user.Name},
+			old:     &stored,
+		},
+		{
+			name:    "failed, user not found",
+			profile: emailOnly,
+			old:     nil,
+			expErr:  errorsPkg.ErrUserNotFound,
+		},
+		{
+			name:    "failed, user deleted",
+			profile: emailOnly,
+			old:     &deleted,
+			expErr:  errorsPkg.ErrUserNotFound,
+		},
+		{
+			name:    "failed, version conflict",
+			profile: versioned,
+			old:     &stored,
+			expErr:  errorsPkg.ErrVersionConflict,
+		},
+		{
+			name:      "failed, update crashed",
+			profile:   full,
+			query:     "UPDATE users SET password = $1, email = $2, full_name = $3, version = version + 1, updated_at = now() WHERE deleted_at IS NULL AND name = $4" + returning,
+			args:      []interface{}{user.Password, user.Email, user.FullName, user.Name},
+			old:       &stored,
+			updateErr: errorsPkg.ErrUnexpected,
+			expErr:    errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if c.old != nil {
+				mock.ExpectQuery(lockQuery).WithArgs(user.Name).WillReturnRows(userRows(*c.old))
+			} else {
+				mock.ExpectQuery(lockQuery).WithArgs(user.Name).WillReturnError(pgx.ErrNoRows)
+			}
+			switch {
+			case c.query == "":
+				mock.ExpectRollback()
+			case c.updateErr != nil:
+				mock.ExpectQuery(c.query).WithArgs(c.args...).WillReturnError(c.updateErr)
+				mock.ExpectRollback()
+			default:
+				mock.ExpectQuery(c.query).WithArgs(c.args...).WillReturnRows(userRows(updated))
+				expectHistory(mock, models.OperationUpdate, updated).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			}
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			err = r.UserUpdate(context.Background(), c.profile)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+
+	t.Run("failed, nothing to update", func(t *testing.T) {
+		r := &repo{
+			pool:   mock,
+			logger: loggerPkg.NewFatal(),
+		}
+		err = r.UserUpdate(context.Background(), models.Profile{Name: user.Name})
+		assert.ErrorIs(t, err, errorsPkg.ErrValidation)
+	})
+}
+
 func TestRepo_UserDelete(t *testing.T) {
 	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
 	if err != nil {
@@ -111,35 +356,214 @@ func TestRepo_UserDelete(t *testing.T) {
 	}
 	defer mock.Close()
 
+	stored := user
+	stored.Version = 2
+	deleted := stored
+	deleted.Version = 3
+	deleted.DeletedAt = 1660412960
+
 	cases := []struct {
-		name   string
-		expErr error
+		name      string
+		version   uint64
+		query     string
+		args      []interface{}
+		old       *models.User
+		updateErr error
+		expErr    error
+	}{
+		{
+			name:    "success",
+			version: 0,
+			query:   "UPDATE users SET deleted_at = extract(epoch from now())::bigint, version = version + 1, updated_at = now() WHERE deleted_at IS NULL AND name = $1" + returning,
+			args:    []interface{}{user.Name},
+			old:     &stored,
+		},
+		{
+			name:    "success, expected version",
+			version: 2,
+			query:   "UPDATE users SET deleted_at = extract(epoch from now())::bigint, version = version + 1, updated_at = now() WHERE deleted_at IS NULL AND name = $1 AND version = $2" + returning,
+			args:    []interface{}{user.Name, uint64(2)},
+			old:     &stored,
+		},
+		{
+			name:    "failed, user not found",
+			version: 0,
+			old:     nil,
+			expErr:  errorsPkg.ErrUserNotFound,
+		},
+		{
+			name:    "failed, already deleted",
+			version: 0,
+			old:     &deleted,
+			expErr:  errorsPkg.ErrUserNotFound,
+		},
+		{
+			name:    "failed, version conflict",
+			version: 1,
+			old:     &stored,
+			expErr:  errorsPkg.ErrVersionConflict,
+		},
+		{
+			name:      "failed, update crashed",
+			version:   0,
+			query:     "UPDATE users SET deleted_at = extract(epoch from now())::bigint, version = version + 1, updated_at = now() WHERE deleted_at IS NULL AND name = $1" + returning,
+			args:      []interface{}{user.Name},
+			old:       &stored,
+			updateErr: errorsPkg.ErrUnexpected,
+			expErr:    errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if c.old != nil {
+				mock.ExpectQuery(lockQuery).WithArgs(user.Name).WillReturnRows(userRows(*c.old))
+			} else {
+				mock.ExpectQuery(lockQuery).WithArgs(user.Name).WillReturnError(pgx.ErrNoRows)
+			}
+			switch {
+			case c.query == "":
+				mock.ExpectRollback()
+			case c.updateErr != nil:
+				mock.ExpectQuery(c.query).WithArgs(c.args...).WillReturnError(c.updateErr)
+				mock.ExpectRollback()
+			default:
+				mock.ExpectQuery(c.query).WithArgs(c.args...).WillReturnRows(userRows(deleted))
+				expectHistory(mock, models.OperationDelete, deleted).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			}
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			err = r.UserDelete(context.Background(), user.Name, c.version)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepo_UserRestore(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	deleted := user
+	deleted.Version = 2
+	deleted.DeletedAt = 1660412960
+	restored := user
+	restored.Version = 3
+
+	cases := []struct {
+		name      string
+		old       *models.User
+		update    bool
+		updateErr error
+		expErr    error
 	}{
 		{
 			name:   "success",
-			expErr: nil,
+			old:    &deleted,
+			update: true,
 		},
 		{
-			name:   "failed, exec crashed",
-			expErr: errorsPkg.ErrUnexpected,
+			name:   "failed, user not found",
+			old:    nil,
+			expErr: errorsPkg.ErrUserNotFound,
+		},
+		{
+			name:   "failed, user not deleted",
+			old:    &restored,
+			expErr: errorsPkg.ErrUserNotFound,
+		},
+		{
+			name:      "failed, update crashed",
+			old:       &deleted,
+			update:    true,
+			updateErr: errorsPkg.ErrUnexpected,
+			expErr:    errorsPkg.ErrUnexpected,
 		},
 	}
-	query := "DELETE FROM users WHERE name = $1"
-	args := []interface{}{user.Name}
+	query := "UPDATE users SET deleted_at = $1, version = version + 1, updated_at = now() WHERE (name = $2 AND deleted_at IS NOT NULL)" + returning
+	args := []interface{}{nil, user.Name}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			mock.ExpectBegin()
+			if c.old != nil {
+				mock.ExpectQuery(lockQuery).WithArgs(user.Name).WillReturnRows(userRows(*c.old))
+			} else {
+				mock.ExpectQuery(lockQuery).WithArgs(user.Name).WillReturnError(pgx.ErrNoRows)
+			}
+			switch {
+			case !c.update:
+				mock.ExpectRollback()
+			case c.updateErr != nil:
+				mock.ExpectQuery(query).WithArgs(args...).WillReturnError(c.updateErr)
+				mock.ExpectRollback()
+			default:
+				mock.ExpectQuery(query).WithArgs(args...).WillReturnRows(userRows(restored))
+				expectHistory(mock, models.OperationRestore, restored).WillReturnResult(pgxmock.NewResult("INSERT", 1))
+				mock.ExpectCommit()
+			}
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			err = r.UserRestore(context.Background(), user.Name)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepo_UserPurge(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	cases := []struct {
+		name      string
+		execErr   error
+		expErr    error
+		expPurged int64
+	}{
+		{
+			name:      "success",
+			execErr:   nil,
+			expErr:    nil,
+			expPurged: 3,
+		},
+		{
+			name:      "failed, exec crashed",
+			execErr:   errorsPkg.ErrUnexpected,
+			expErr:    errorsPkg.ErrUnexpected,
+			expPurged: 0,
+		},
+	}
+	query := "DELETE FROM users WHERE deleted_at < extract(epoch from now())::bigint - $1"
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
 			mock.ExpectExec(query).
-				WithArgs(args...).
-				WillReturnResult(pgxmock.NewResult("DELETE", 1)).
-				WillReturnError(c.expErr)
+				WithArgs(int64(3600)).
+				WillReturnResult(pgxmock.NewResult("DELETE", 3)).
+				WillReturnError(c.execErr)
 
 			r := &repo{
 				pool:   mock,
 				logger: loggerPkg.NewFatal(),
 			}
-			err = r.UserDelete(context.Background(), user.Name)
+			purged, err := r.UserPurge(context.Background(), time.Hour)
 			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expPurged, purged)
 		})
 	}
 }
@@ -172,12 +596,12 @@ func TestRepo_UserGet(t *testing.T) {
 			expErr: errorsPkg.ErrUserNotFound,
 		},
 	}
-	query := "SELECT name, password, email, full_name, created_at FROM users WHERE name = $1"
+	query := "SELECT name, password, email, full_name, created_at, updated_at, version, COALESCE(deleted_at, 0) FROM users WHERE deleted_at IS NULL AND name = $1"
 	args := []interface{}{user.Name}
 
 	for _, c := range cases {
-		rows := pgxmock.NewRows([]string{nameField, passwordField, emailField, fullNameField, createdAtField}).
-			AddRow(user.Name, user.Password, user.Email, user.FullName, user.CreatedAt)
+		rows := pgxmock.NewRows([]string{nameField, passwordField, emailField, fullNameField, createdAtField, updatedAtField, versionField, deletedAtField}).
+			AddRow(user.Name, user.Password, user.Email, user.FullName, user.CreatedAt, user.UpdatedAt, user.Version, user.DeletedAt)
 		t.Run(c.name, func(t *testing.T) {
 			mock.ExpectQuery(query).
 				WithArgs(args...).
@@ -189,7 +613,62 @@ func TestRepo_UserGet(t *testing.T) {
 				pool:   mock,
 				logger: loggerPkg.NewFatal(),
 			}
-			_, err = r.UserGet(context.Background(), user.Name)
+			_, err = r.UserGet(context.Background(), user.Name, false)
+			assert.ErrorIs(t, err, c.expErr)
+		})
+	}
+}
+
+func TestRepo_UserGetByEmail(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	cases := []struct {
+		name        string
+		withDeleted bool
+		query       string
+		err         error
+		expErr      error
+	}{
+		{
+			name:   "success",
+			query:  "SELECT name, password, email, full_name, created_at, updated_at, version, COALESCE(deleted_at, 0) FROM users WHERE deleted_at IS NULL AND email = $1",
+			err:    nil,
+			expErr: nil,
+		},
+		{
+			name:        "success, with deleted",
+			withDeleted: true,
+			query:       "SELECT name, password, email, full_name, created_at, updated_at, version, COALESCE(deleted_at, 0) FROM users WHERE email = $1",
+			err:         nil,
+			expErr:      nil,
+		},
+		{
+			name:   "failed, no data",
+			query:  "SELECT name, password, email, full_name, created_at, updated_at, version, COALESCE(deleted_at, 0) FROM users WHERE deleted_at IS NULL AND email = $1",
+			err:    pgx.ErrNoRows,
+			expErr: errorsPkg.ErrUserNotFound,
+		},
+	}
+
+	for _, c := range cases {
+		rows := pgxmock.NewRows([]string{nameField, passwordField, emailField, fullNameField, createdAtField, updatedAtField, versionField, deletedAtField}).
+			AddRow(user.Name, user.Password, user.Email, user.FullName, user.CreatedAt, user.UpdatedAt, user.Version, user.DeletedAt)
+		t.Run(c.name, func(t *testing.T) {
+			mock.ExpectQuery(c.query).
+				WithArgs(user.Email).
+				WillReturnRows(rows).
+				WillReturnError(c.err).
+				RowsWillBeClosed()
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			_, err = r.UserGetByEmail(context.Background(), user.Email, c.withDeleted)
 			assert.ErrorIs(t, err, c.expErr)
 		})
 	}
@@ -202,34 +681,88 @@ func TestRepo_UserList(t *testing.T) {
 	}
 	defer mock.Close()
 
+	columns := "SELECT name, password, email, full_name, created_at, updated_at, version, COALESCE(deleted_at, 0) FROM users "
+
 	cases := []struct {
 		name   string
+		params models.UserListParams
+		query  string
+		args   []interface{}
 		err    error
+		rowErr error
 		expErr error
 	}{
 		{
 			name:   "success",
+			params: models.UserListParams{Limit: 2, Order: true},
+			query:  columns + "WHERE deleted_at IS NULL ORDER BY name DESC LIMIT 2 OFFSET 0",
+			err:    nil,
+			expErr: nil,
+		},
+		{
+			name:   "success, desc after cursor",
+			params: models.UserListParams{Limit: 2, Order: true, After: &user},
+			query:  columns + "WHERE deleted_at IS NULL AND ((name < $1)) ORDER BY name DESC LIMIT 2",
+			args:   []interface{}{user.Name},
+			err:    nil,
+			expErr: nil,
+		},
+		{
+			name:   "success, asc after cursor with deleted",
+			params: models.UserListParams{Limit: 2, Offset: 3, After: &user, WithDeleted: true},
+			query:  columns + "WHERE ((name > $1)) ORDER BY name LIMIT 2",
+			args:   []interface{}{user.Name},
+			err:    nil,
+			expErr: nil,
+		},
+		{
+			name: "success, filter and sort after cursor",
+			params: models.UserListParams{
+				Limit: 2,
+				Sort:  []models.UserSort{{Field: models.SortCreatedAt, Desc: true}, {Field: models.SortEmail}},
+				Filter: models.UserFilter{
+					EmailDomain: "e_mail.com",
+					FullName:    "iv%",
+					CreatedFrom: 10,
+					CreatedTo:   20,
+				},
+				After: &user,
+			},
+			query: columns + "WHERE deleted_at IS NULL " +
+				"AND (email ILIKE $1 AND full_name ILIKE $2 AND created_at >= $3 AND created_at < $4) " +
+				"AND ((created_at < $5) OR (created_at = $6 AND email > $7) OR (created_at = $8 AND email = $9 AND name > $10)) " +
+				"ORDER BY created_at DESC, email, name LIMIT 2",
+			args: []interface{}{`%@e\_mail.com`, `%iv\%%`, time.Unix(10, 0), time.Unix(21, 0),
+				user.CreatedAt, user.CreatedAt, user.Email, user.CreatedAt, user.Email, user.Name},
 			err:    nil,
 			expErr: nil,
 		},
 		{
 			name:   "failed, query crashed",
+			params: models.UserListParams{Limit: 2, Order: true},
+			query:  columns + "WHERE deleted_at IS NULL ORDER BY name DESC LIMIT 2 OFFSET 0",
 			err:    errorsPkg.ErrUnexpected,
 			expErr: errorsPkg.ErrUnexpected,
 		},
+		{
+			name:   "failed, rows crashed",
+			params: models.UserListParams{Limit: 2, Order: true},
+			query:  columns + "WHERE deleted_at IS NULL ORDER BY name DESC LIMIT 2 OFFSET 0",
+			rowErr: errorsPkg.ErrUnexpected,
+			expErr: errorsPkg.ErrUnexpected,
+		},
 	}
-	order := true
-	limit := uint64(2)
-	offset := uint64(0)
-	query := fmt.Sprintf("SELECT name, password, email, full_name, created_at "+
-		"FROM users ORDER BY name DESC LIMIT %d OFFSET %d", limit, offset)
 
 	for _, c := range cases {
-		rows := pgxmock.NewRows([]string{nameField, passwordField, emailField, fullNameField, createdAtField}).
-			AddRow(user.Name, user.Password, user.Email, user.FullName, user.CreatedAt).
-			AddRow(user.Name, user.Password, user.Email, user.FullName, user.CreatedAt)
+		rows := pgxmock.NewRows([]string{nameField, passwordField, emailField, fullNameField, createdAtField, updatedAtField, versionField, deletedAtField}).
+			AddRow(user.Name, user.Password, user.Email, user.FullName, user.CreatedAt, user.UpdatedAt, user.Version, user.DeletedAt).
+			AddRow(user.Name, user.Password, user.Email, user.FullName, user.CreatedAt, user.UpdatedAt, user.Version, user.DeletedAt)
+		if c.rowErr != nil {
+			rows.RowError(1, c.rowErr)
+		}
 		t.Run(c.name, func(t *testing.T) {
-			mock.ExpectQuery(query).
+			mock.ExpectQuery(c.query).
+				WithArgs(c.args...).
 				WillReturnRows(rows).
 				WillReturnError(c.err)
 
@@ -237,8 +770,138 @@ func TestRepo_UserList(t *testing.T) {
 				pool:   mock,
 				logger: loggerPkg.NewFatal(),
 			}
-			_, err = r.UserList(context.Background(), order, limit, offset)
+			_, err = r.UserList(context.Background(), c.params)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepo_UserHistory(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	created := models.NewUserChange(models.OperationCreate, nil, user, "uid-1")
+	updated := user
+	updated.FullName = "Ivan the Clever"
+	updated.Version = 1
+	changed := models.NewUserChange(models.OperationUpdate, &user, updated, "uid-2")
+
+	oldValues, _ := json.Marshal(changed.Old)
+	newValues, _ := json.Marshal(changed.New)
+	query := "SELECT name, operation, old_values, new_values, request_id, changed_at FROM user_history WHERE name = $1 ORDER BY changed_at, id"
+	columns := []string{nameField, operationField, oldValuesField, newValuesField, requestIDField, changedAtField}
+
+	cases := []struct {
+		name       string
+		rows       *pgxmock.Rows
+		queryErr   error
+		expChanges []models.UserChange
+		expErr     error
+	}{
+		{
+			name: "success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(user.Name, created.Operation, []byte(nil), oldValues, created.RequestID, created.ChangedAt).
+				AddRow(user.Name, changed.Operation, oldValues, newValues, changed.RequestID, changed.ChangedAt),
+			expChanges: []models.UserChange{created, changed},
+		},
+		{
+			name:       "success, no history",
+			rows:       pgxmock.NewRows(columns),
+			expChanges: []models.UserChange{},
+		},
+		{
+			name:     "failed, query crashed",
+			queryErr: errorsPkg.ErrUnexpected,
+			expErr:   errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.queryErr != nil {
+				mock.ExpectQuery(query).WithArgs(user.Name).WillReturnError(c.queryErr)
+			} else {
+				mock.ExpectQuery(query).WithArgs(user.Name).WillReturnRows(c.rows)
+			}
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			changes, err := r.UserHistory(context.Background(), user.Name)
+			assert.ErrorIs(t, err, c.expErr)
+			if c.expErr == nil {
+				assert.Len(t, changes, len(c.expChanges))
+				for i := range c.expChanges {
+					assert.Equal(t, c.expChanges[i].Operation, changes[i].Operation)
+					assert.Equal(t, c.expChanges[i].RequestID, changes[i].RequestID)
+					assert.Equal(t, c.expChanges[i].New.FullName, changes[i].New.FullName)
+					assert.Equal(t, c.expChanges[i].Old == nil, changes[i].Old == nil)
+				}
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRepo_UserGetAsOf(t *testing.T) {
+	mock, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mock.Close()
+
+	at := time.Unix(1660412955, 0)
+	query := "SELECT name, operation, old_values, new_values, request_id, changed_at FROM user_history " +
+		"WHERE (name = $1 AND changed_at <= $2) ORDER BY changed_at DESC, id DESC LIMIT 1"
+	columns := []string{nameField, operationField, oldValuesField, newValuesField, requestIDField, changedAtField}
+
+	cases := []struct {
+		name     string
+		rows     *pgxmock.Rows
+		queryErr error
+		expName  string
+		expErr   error
+	}{
+		{
+			name: "success",
+			rows: pgxmock.NewRows(columns).
+				AddRow(user.Name, models.OperationCreate, []byte(nil), []byte(`{"name":"Ivan","email":"ivan@email.com"}`), "", user.UpdatedAt),
+			expName: user.Name,
+		},
+		{
+			name:     "failed, user not found",
+			queryErr: pgx.ErrNoRows,
+			expErr:   errorsPkg.ErrUserNotFound,
+		},
+		{
+			name:     "failed, query crashed",
+			queryErr: errorsPkg.ErrUnexpected,
+			expErr:   errorsPkg.ErrUnexpected,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if c.queryErr != nil {
+				mock.ExpectQuery(query).WithArgs(user.Name, at).WillReturnError(c.queryErr)
+			} else {
+				mock.ExpectQuery(query).WithArgs(user.Name, at).WillReturnRows(c.rows)
+			}
+
+			r := &repo{
+				pool:   mock,
+				logger: loggerPkg.NewFatal(),
+			}
+			got, err := r.UserGetAsOf(context.Background(), user.Name, at)
 			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expName, got.Name)
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -4,15 +4,54 @@ package repo
 
 import (
 	"context"
+	"time"
 
 	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
 )
 
+// Storages of the data service.
+const (
+	Postgres = "postgres"
+	Memory   = "memory"
+	Embedded = "embedded"
+)
+
+// Interface is the users storage. UserUpdate and UserDelete with not zero
+// expected version return customerrors.ErrVersionConflict if the stored version differs.
+// UserDelete leaves a tombstone, deleted users are hidden unless withDeleted is set,
+// UserPurge removes the tombstones older than retention by the storage clock and returns their count.
+// UserList returns the users sorted by name, starting after params.After if it is set.
+// UserCreate and UserUpdate return customerrors.ErrEmailAlreadyExists if the email belongs to another user,
+// deleted users keep their emails until purged.
+// UserCreateBatch creates the users with unique names and returns the error of every user,
+// nil for the created ones, customerrors.ErrUserAlreadyExists if the name is taken,
+// customerrors.ErrEmailAlreadyExists if the email is taken.
+// Every create, update, delete and restore is recorded in the user history together with the change,
+// the history is kept after the purge. UserHistory returns the changes from the oldest one,
+// UserGetAsOf returns the user at the time, customerrors.ErrUserNotFound if it was not created yet.
+// The message of models.OutboxFromCtx is written to the outbox together with the change,
+// the batch message gets the errors of the users. OutboxList returns up to limit not published
+// messages from the oldest one and claims them, the concurrent relays skip the claimed messages
+// until they are published or the claim expires. OutboxMarkPublished marks the published ones,
+// OutboxPurge removes the messages published before the time and returns their count.
+// The outbox message keeps the uid of the request, the change of the processed request returns
+// customerrors.ErrRequestProcessed until its message is purged. RequestProcessed returns true if
+// the message of the request is in the outbox.
 type Interface interface {
 	UserCreate(ctx context.Context, user models.User) error
-	UserUpdate(ctx context.Context, user models.User) error
-	UserDelete(ctx context.Context, name string) error
-	UserGet(ctx context.Context, name string) (models.User, error)
-	UserList(ctx context.Context, order bool, limit, offset uint64) ([]models.User, error)
+	UserCreateBatch(ctx context.Context, users []models.User) ([]error, error)
+	UserUpdate(ctx context.Context, profile models.Profile) error
+	UserDelete(ctx context.Context, name string, version uint64) error
+	UserRestore(ctx context.Context, name string) error
+	UserPurge(ctx context.Context, retention time.Duration) (int64, error)
+	UserGet(ctx context.Context, name string, withDeleted bool) (models.User, error)
+	UserGetByEmail(ctx context.Context, email string, withDeleted bool) (models.User, error)
+	UserList(ctx context.Context, params models.UserListParams) ([]models.User, error)
+	UserHistory(ctx context.Context, name string) ([]models.UserChange, error)
+	UserGetAsOf(ctx context.Context, name string, at time.Time) (models.User, error)
+	OutboxList(ctx context.Context, limit uint64) ([]models.OutboxMessage, error)
+	OutboxMarkPublished(ctx context.Context, ids []uint64) error
+	OutboxPurge(ctx context.Context, before time.Time) (int64, error)
+	RequestProcessed(ctx context.Context, requestID string) (bool, error)
 	Close()
 }
@@ -0,0 +1,651 @@
+// Package repotest is the behavioural suite every repo.Interface implementation must pass.
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	errorsPkg "gitlab.ozon.dev/iTukaev/homework/internal/customerrors"
+	"gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	"gitlab.ozon.dev/iTukaev/homework/pkg/helper"
+)
+
+// Factory returns an empty repository, it is called for every test of the suite.
+// The suite does not close the repositories.
+type Factory func(t *testing.T) repoPkg.Interface
+
+// The names, emails and full names have the same order in any collation.
+// The timestamps are set by the storage.
+var (
+	anna = models.User{
+		Name:     "Anna",
+		Password: "hash1",
+		Email:    "anna@mail.com",
+		FullName: "Anna Karenina",
+	}
+	boris = models.User{
+		Name:     "Boris",
+		Password: "hash2",
+		Email:    "boris@post.com",
+		FullName: "Boris Blade",
+	}
+	clara = models.User{
+		Name:     "Clara",
+		Password: "hash3",
+		Email:    "clara@mail.com",
+		FullName: "Clara Zetkin",
+	}
+	dmitry = models.User{
+		Name:     "Dmitry",
+		Password: "hash4",
+		Email:    "dmitry@post.com",
+		FullName: "Dmitry Karamazov",
+	}
+)
+
+// Run runs the suite against the repositories of the factory.
+func Run(t *testing.T, newRepo Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, repo repoPkg.Interface)
+	}{
+		{name: "UserCreate", test: testUserCreate},
+		{name: "UserCreateBatch", test: testUserCreateBatch},
+		{name: "UserUpdate", test: testUserUpdate},
+		{name: "UserDelete", test: testUserDelete},
+		{name: "UserRestore", test: testUserRestore},
+		{name: "UserPurge", test: testUserPurge},
+		{name: "UserGetByEmail", test: testUserGetByEmail},
+		{name: "UserList", test: testUserList},
+		{name: "UserHistory", test: testUserHistory},
+		{name: "UserGetAsOf", test: testUserGetAsOf},
+		{name: "Outbox", test: testOutbox},
+		{name: "RequestProcessed", test: testRequestProcessed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newRepo(t))
+		})
+	}
+}
+
+func testUserCreate(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+
+	require.NoError(t, repo.UserCreate(ctx, anna))
+	stored, err := repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+	assert.False(t, stored.CreatedAt.IsZero())
+	assert.True(t, stored.UpdatedAt.Equal(stored.CreatedAt))
+	expected := anna
+	expected.Version = 1
+	expected.CreatedAt, expected.UpdatedAt = stored.CreatedAt, stored.UpdatedAt
+	assert.Equal(t, expected, stored)
+
+	sameName := boris
+	sameName.Name = anna.Name
+	assert.ErrorIs(t, repo.UserCreate(ctx, sameName), errorsPkg.ErrUserAlreadyExists)
+
+	sameEmail := boris
+	sameEmail.Email = anna.Email
+	assert.ErrorIs(t, repo.UserCreate(ctx, sameEmail), errorsPkg.ErrEmailAlreadyExists)
+
+	// the name of the deleted user is reserved until the user is purged
+	require.NoError(t, repo.UserDelete(ctx, anna.Name, 0))
+	assert.ErrorIs(t, repo.UserCreate(ctx, sameName), errorsPkg.ErrUserAlreadyExists)
+
+	_, err = repo.UserGet(ctx, boris.Name, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+}
+
+func testUserCreateBatch(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+	require.NoError(t, repo.UserCreate(ctx, anna))
+
+	sameName := clara
+	sameName.Name = anna.Name
+	sameEmail := clara
+	sameEmail.Email = anna.Email
+	batchEmail := dmitry
+	batchEmail.Email = boris.Email
+
+	res, err := repo.UserCreateBatch(ctx, []models.User{boris, sameName, sameEmail, batchEmail})
+	require.NoError(t, err)
+	require.Len(t, res, 4)
+	assert.NoError(t, res[0])
+	assert.ErrorIs(t, res[1], errorsPkg.ErrUserAlreadyExists)
+	assert.ErrorIs(t, res[2], errorsPkg.ErrEmailAlreadyExists)
+	assert.ErrorIs(t, res[3], errorsPkg.ErrEmailAlreadyExists)
+
+	stored, err := repo.UserGet(ctx, boris.Name, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), stored.Version)
+	stored, err = repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+	assert.Equal(t, anna.Email, stored.Email)
+	_, err = repo.UserGet(ctx, clara.Name, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+	_, err = repo.UserGet(ctx, dmitry.Name, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+}
+
+func testUserUpdate(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+	require.NoError(t, repo.UserCreate(ctx, anna))
+	require.NoError(t, repo.UserCreate(ctx, boris))
+	created, err := repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+
+	tick()
+	fullName := "Anna Arkadyevna"
+	require.NoError(t, repo.UserUpdate(ctx, models.Profile{Name: anna.Name, FullName: &fullName}))
+	stored, err := repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+	assert.True(t, stored.UpdatedAt.After(created.UpdatedAt))
+	expected := anna
+	expected.FullName = fullName
+	expected.Version = 2
+	expected.CreatedAt, expected.UpdatedAt = created.CreatedAt, stored.UpdatedAt
+	assert.Equal(t, expected, stored)
+
+	email := "anna@post.com"
+	assert.ErrorIs(t, repo.UserUpdate(ctx, models.Profile{Name: anna.Name, Email: &email, Version: 1}),
+		errorsPkg.ErrVersionConflict)
+	require.NoError(t, repo.UserUpdate(ctx, models.Profile{Name: anna.Name, Email: &email, Version: 2}))
+	stored, err = repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+	assert.Equal(t, email, stored.Email)
+	assert.Equal(t, fullName, stored.FullName)
+	assert.Equal(t, uint64(3), stored.Version)
+
+	assert.ErrorIs(t, repo.UserUpdate(ctx, models.Profile{Name: anna.Name, Email: &boris.Email}),
+		errorsPkg.ErrEmailAlreadyExists)
+	assert.ErrorIs(t, repo.UserUpdate(ctx, models.Profile{Name: anna.Name}), errorsPkg.ErrValidation)
+	assert.ErrorIs(t, repo.UserUpdate(ctx, models.Profile{Name: clara.Name, FullName: &fullName}),
+		errorsPkg.ErrUserNotFound)
+	assert.ErrorIs(t, repo.UserUpdate(ctx, models.Profile{Name: clara.Name, FullName: &fullName, Version: 1}),
+		errorsPkg.ErrUserNotFound)
+
+	require.NoError(t, repo.UserDelete(ctx, boris.Name, 0))
+	assert.ErrorIs(t, repo.UserUpdate(ctx, models.Profile{Name: boris.Name, FullName: &fullName}),
+		errorsPkg.ErrUserNotFound)
+
+	_, err = repo.UserGet(ctx, clara.Name, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+}
+
+func testUserDelete(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+	require.NoError(t, repo.UserCreate(ctx, anna))
+	created, err := repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+
+	tick()
+	assert.ErrorIs(t, repo.UserDelete(ctx, anna.Name, 2), errorsPkg.ErrVersionConflict)
+	require.NoError(t, repo.UserDelete(ctx, anna.Name, 1))
+
+	_, err = repo.UserGet(ctx, anna.Name, false)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+	stored, err := repo.UserGet(ctx, anna.Name, true)
+	require.NoError(t, err)
+	assert.True(t, stored.Deleted())
+	assert.Equal(t, uint64(2), stored.Version)
+	assert.True(t, stored.UpdatedAt.After(created.UpdatedAt))
+	assert.True(t, stored.CreatedAt.Equal(created.CreatedAt))
+
+	assert.ErrorIs(t, repo.UserDelete(ctx, anna.Name, 0), errorsPkg.ErrUserNotFound)
+	assert.ErrorIs(t, repo.UserDelete(ctx, anna.Name, 2), errorsPkg.ErrUserNotFound)
+	assert.ErrorIs(t, repo.UserDelete(ctx, boris.Name, 0), errorsPkg.ErrUserNotFound)
+}
+
+func testUserRestore(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+	require.NoError(t, repo.UserCreate(ctx, anna))
+
+	assert.ErrorIs(t, repo.UserRestore(ctx, anna.Name), errorsPkg.ErrUserNotFound)
+	require.NoError(t, repo.UserDelete(ctx, anna.Name, 0))
+	require.NoError(t, repo.UserRestore(ctx, anna.Name))
+
+	stored, err := repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+	assert.False(t, stored.Deleted())
+	assert.Equal(t, uint64(3), stored.Version)
+
+	assert.ErrorIs(t, repo.UserRestore(ctx, boris.Name), errorsPkg.ErrUserNotFound)
+}
+
+func testUserPurge(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+	require.NoError(t, repo.UserCreate(ctx, anna))
+	require.NoError(t, repo.UserCreate(ctx, boris))
+	require.NoError(t, repo.UserDelete(ctx, anna.Name, 0))
+
+	purged, err := repo.UserPurge(ctx, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	purged, err = repo.UserPurge(ctx, -time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = repo.UserGet(ctx, anna.Name, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+	_, err = repo.UserGet(ctx, boris.Name, false)
+	assert.NoError(t, err)
+
+	// the name and the email of the purged user are free
+	require.NoError(t, repo.UserCreate(ctx, anna))
+}
+
+func testUserGetByEmail(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+	require.NoError(t, repo.UserCreate(ctx, anna))
+
+	stored, err := repo.UserGetByEmail(ctx, anna.Email, false)
+	require.NoError(t, err)
+	assert.Equal(t, anna.Name, stored.Name)
+
+	_, err = repo.UserGetByEmail(ctx, boris.Email, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+
+	require.NoError(t, repo.UserDelete(ctx, anna.Name, 0))
+	_, err = repo.UserGetByEmail(ctx, anna.Email, false)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+	stored, err = repo.UserGetByEmail(ctx, anna.Email, true)
+	require.NoError(t, err)
+	assert.Equal(t, anna.Name, stored.Name)
+}
+
+func testUserList(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+	// the creation order differs from the name order
+	for _, user := range []models.User{anna, clara, dmitry, boris} {
+		tick()
+		require.NoError(t, repo.UserCreate(ctx, user))
+	}
+	require.NoError(t, repo.UserDelete(ctx, dmitry.Name, 0))
+	first, err := repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+	last, err := repo.UserGet(ctx, boris.Name, false)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		params models.UserListParams
+		exp    []string
+	}{
+		{
+			name:   "by name",
+			params: models.UserListParams{Limit: 10},
+			exp:    []string{anna.Name, boris.Name, clara.Name},
+		},
+		{
+			name:   "by name desc",
+			params: models.UserListParams{Limit: 10, Order: true},
+			exp:    []string{clara.Name, boris.Name, anna.Name},
+		},
+		{
+			name:   "with deleted",
+			params: models.UserListParams{Limit: 10, WithDeleted: true},
+			exp:    []string{anna.Name, boris.Name, clara.Name, dmitry.Name},
+		},
+		{
+			name:   "first page",
+			params: models.UserListParams{Limit: 2},
+			exp:    []string{anna.Name, boris.Name},
+		},
+		{
+			name:   "second page",
+			params: models.UserListParams{Limit: 2, Offset: 1},
+			exp:    []string{clara.Name},
+		},
+		{
+			name:   "page after the last one",
+			params: models.UserListParams{Limit: 2, Offset: 2},
+			exp:    []string{},
+		},
+		{
+			name:   "zero limit",
+			params: models.UserListParams{Limit: 0},
+			exp:    []string{},
+		},
+		{
+			name:   "after cursor, offset is ignored",
+			params: models.UserListParams{Limit: 2, Offset: 5, After: &anna},
+			exp:    []string{boris.Name, clara.Name},
+		},
+		{
+			name:   "by name desc after cursor",
+			params: models.UserListParams{Limit: 10, Order: true, After: &clara},
+			exp:    []string{boris.Name, anna.Name},
+		},
+		{
+			name:   "by name desc after cursor past the last user",
+			params: models.UserListParams{Limit: 10, Order: true, After: &models.User{Name: "zoe"}},
+			exp:    []string{clara.Name, boris.Name, anna.Name},
+		},
+		{
+			name: "by created_at desc",
+			params: models.UserListParams{
+				Limit:       10,
+				WithDeleted: true,
+				Sort:        []models.UserSort{{Field: models.SortCreatedAt, Desc: true}},
+			},
+			exp: []string{boris.Name, dmitry.Name, clara.Name, anna.Name},
+		},
+		{
+			name: "by email after cursor",
+			params: models.UserListParams{
+				Limit:       10,
+				WithDeleted: true,
+				Sort:        []models.UserSort{{Field: models.SortEmail}},
+				After:       &boris,
+			},
+			exp: []string{clara.Name, dmitry.Name},
+		},
+		{
+			name: "filter by email domain and full name",
+			params: models.UserListParams{
+				Limit:       10,
+				WithDeleted: true,
+				Filter:      models.UserFilter{EmailDomain: "POST.com", FullName: "kara"},
+			},
+			exp: []string{dmitry.Name},
+		},
+		{
+			name: "filter by created_at range, the bounds are inclusive seconds",
+			params: models.UserListParams{
+				Limit:  10,
+				Filter: models.UserFilter{CreatedFrom: first.CreatedAt.Unix(), CreatedTo: last.CreatedAt.Unix()},
+			},
+			exp: []string{anna.Name, boris.Name, clara.Name},
+		},
+		{
+			name: "filter by created_at after the last user",
+			params: models.UserListParams{
+				Limit:  10,
+				Filter: models.UserFilter{CreatedFrom: last.CreatedAt.Unix() + 1},
+			},
+			exp: []string{},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			users, err := repo.UserList(ctx, c.params)
+			require.NoError(t, err)
+			names := make([]string, 0, len(users))
+			for _, user := range users {
+				names = append(names, user.Name)
+			}
+			assert.Equal(t, c.exp, names)
+		})
+	}
+}
+
+func testUserHistory(t *testing.T, repo repoPkg.Interface) {
+	ctx := helper.InjectUidPubToCtx(context.Background(), "uid-1", "")
+	email := "anna@post.com"
+
+	require.NoError(t, repo.UserCreate(ctx, anna))
+	tick()
+	require.NoError(t, repo.UserUpdate(ctx, models.Profile{Name: anna.Name, Email: &email}))
+	tick()
+	require.NoError(t, repo.UserDelete(ctx, anna.Name, 0))
+	tick()
+	require.NoError(t, repo.UserRestore(ctx, anna.Name))
+	// the failed changes are not recorded
+	require.Error(t, repo.UserRestore(ctx, anna.Name))
+
+	changes, err := repo.UserHistory(ctx, anna.Name)
+	require.NoError(t, err)
+	require.Len(t, changes, 4)
+	operations := make([]string, 0, len(changes))
+	for i, change := range changes {
+		operations = append(operations, change.Operation)
+		assert.Equal(t, anna.Name, change.Name)
+		assert.Equal(t, "uid-1", change.RequestID)
+		assert.Empty(t, change.New.Password)
+		assert.Equal(t, uint64(i+1), change.New.Version)
+		assert.True(t, change.ChangedAt.Equal(change.New.UpdatedAt))
+		if i == 0 {
+			assert.Nil(t, change.Old)
+			continue
+		}
+		require.NotNil(t, change.Old)
+		assert.Empty(t, change.Old.Password)
+		assert.Equal(t, changes[i-1].New.Version, change.Old.Version)
+		assert.True(t, changes[i-1].ChangedAt.Before(change.ChangedAt))
+	}
+	assert.Equal(t, []string{
+		models.OperationCreate, models.OperationUpdate, models.OperationDelete, models.OperationRestore,
+	}, operations)
+	assert.Equal(t, anna.Email, changes[1].Old.Email)
+	assert.Equal(t, email, changes[1].New.Email)
+	assert.False(t, changes[1].New.Deleted())
+	assert.True(t, changes[2].New.Deleted())
+	assert.False(t, changes[3].New.Deleted())
+
+	changes, err = repo.UserHistory(ctx, boris.Name)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	// the history outlives the purged user
+	require.NoError(t, repo.UserDelete(ctx, anna.Name, 0))
+	_, err = repo.UserPurge(ctx, -time.Minute)
+	require.NoError(t, err)
+	changes, err = repo.UserHistory(ctx, anna.Name)
+	require.NoError(t, err)
+	assert.Len(t, changes, 5)
+}
+
+func testUserGetAsOf(t *testing.T, repo repoPkg.Interface) {
+	ctx := context.Background()
+	email := "anna@post.com"
+
+	require.NoError(t, repo.UserCreate(ctx, anna))
+	created, err := repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+	tick()
+	require.NoError(t, repo.UserUpdate(ctx, models.Profile{Name: anna.Name, Email: &email}))
+	updated, err := repo.UserGet(ctx, anna.Name, false)
+	require.NoError(t, err)
+	tick()
+	require.NoError(t, repo.UserDelete(ctx, anna.Name, 0))
+
+	cases := []struct {
+		name       string
+		at         time.Time
+		expErr     error
+		expVersion uint64
+		expEmail   string
+	}{
+		{
+			name:   "before create",
+			at:     created.CreatedAt.Add(-time.Millisecond),
+			expErr: errorsPkg.ErrUserNotFound,
+		},
+		{
+			name:       "at create",
+			at:         created.CreatedAt,
+			expVersion: 1,
+			expEmail:   anna.Email,
+		},
+		{
+			name:       "between create and update",
+			at:         updated.UpdatedAt.Add(-time.Microsecond),
+			expVersion: 1,
+			expEmail:   anna.Email,
+		},
+		{
+			name:       "at update",
+			at:         updated.UpdatedAt,
+			expVersion: 2,
+			expEmail:   email,
+		},
+		{
+			name:       "now",
+			at:         time.Now().Add(time.Minute),
+			expVersion: 3,
+			expEmail:   email,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			user, err := repo.UserGetAsOf(ctx, anna.Name, c.at)
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.expVersion, user.Version)
+			assert.Equal(t, c.expEmail, user.Email)
+			assert.Empty(t, user.Password)
+		})
+	}
+
+	_, err = repo.UserGetAsOf(ctx, boris.Name, time.Now())
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+}
+
+func testOutbox(t *testing.T, repo repoPkg.Interface) {
+	// the message value lists the results of the batch users
+	outbox := func(key string) context.Context {
+		return models.WithOutbox(context.Background(), func(results []error) (models.OutboxMessage, error) {
+			value := ""
+			for _, err := range results {
+				if err != nil {
+					value += "E"
+				} else {
+					value += "+"
+				}
+			}
+			return models.OutboxMessage{
+				Topic:   "topic",
+				Key:     key,
+				Value:   []byte(value),
+				Headers: map[string]string{"uid": key},
+			}, nil
+		})
+	}
+	ctx := context.Background()
+	email := "anna@post.com"
+
+	require.NoError(t, repo.UserCreate(outbox("create"), anna))
+	require.ErrorIs(t, repo.UserCreate(outbox("create again"), anna), errorsPkg.ErrUserAlreadyExists)
+	res, err := repo.UserCreateBatch(outbox("batch"), []models.User{boris, anna})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	require.NoError(t, repo.UserUpdate(outbox("update"), models.Profile{Name: anna.Name, Email: &email}))
+	require.NoError(t, repo.UserDelete(outbox("delete"), anna.Name, 0))
+	require.NoError(t, repo.UserRestore(outbox("restore"), anna.Name))
+	// the change without the message is not written to the outbox
+	require.NoError(t, repo.UserCreate(ctx, clara))
+
+	// the change is not written without its message
+	failed := models.WithOutbox(ctx, func([]error) (models.OutboxMessage, error) {
+		return models.OutboxMessage{}, errorsPkg.ErrUnexpected
+	})
+	assert.Error(t, repo.UserCreate(failed, dmitry))
+	_, err = repo.UserGet(ctx, dmitry.Name, true)
+	assert.ErrorIs(t, err, errorsPkg.ErrUserNotFound)
+
+	first, err := repo.OutboxList(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, "create", first[0].Key)
+	assert.Equal(t, "topic", first[0].Topic)
+	assert.Equal(t, map[string]string{"uid": "create"}, first[0].Headers)
+	assert.False(t, first[0].CreatedAt.IsZero())
+	assert.Equal(t, "batch", first[1].Key)
+	assert.Equal(t, "+E", string(first[1].Value))
+	assert.Less(t, first[0].ID, first[1].ID)
+
+	require.NoError(t, repo.OutboxMarkPublished(ctx, []uint64{first[0].ID, first[1].ID}))
+	rest, err := repo.OutboxList(ctx, 10)
+	require.NoError(t, err)
+	keys := make([]string, 0, len(rest))
+	for _, message := range rest {
+		keys = append(keys, message.Key)
+	}
+	assert.Equal(t, []string{"update", "delete", "restore"}, keys)
+
+	require.NoError(t, repo.OutboxMarkPublished(ctx, []uint64{rest[0].ID, rest[1].ID, rest[2].ID}))
+	rest, err = repo.OutboxList(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, rest)
+}
+
+func testRequestProcessed(t *testing.T, repo repoPkg.Interface) {
+	// request returns the context of the change of the request with the outbox message
+	request := func(uid string) context.Context {
+		ctx := helper.InjectUidPubToCtx(context.Background(), uid, "pub")
+		return models.WithOutbox(ctx, func([]error) (models.OutboxMessage, error) {
+			return models.OutboxMessage{Topic: "topic", Key: uid}, nil
+		})
+	}
+	ctx := context.Background()
+	fullName := "Anna Arkadyevna"
+	profile := models.Profile{Name: anna.Name, FullName: &fullName}
+
+	require.NoError(t, repo.UserCreate(request("create"), anna))
+	require.NoError(t, repo.UserUpdate(request("update"), profile))
+	res, err := repo.UserCreateBatch(request("batch"), []models.User{boris})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.NoError(t, repo.UserDelete(request("delete"), boris.Name, 0))
+	require.NoError(t, repo.UserRestore(request("restore"), boris.Name))
+
+	// the redelivered requests are not applied again
+	assert.ErrorIs(t, repo.UserCreate(request("create"), anna), errorsPkg.ErrRequestProcessed)
+	assert.ErrorIs(t, repo.UserUpdate(request("update"), profile), errorsPkg.ErrRequestProcessed)
+	_, err = repo.UserCreateBatch(request("batch"), []models.User{boris})
+	assert.ErrorIs(t, err, errorsPkg.ErrRequestProcessed)
+	assert.ErrorIs(t, repo.UserDelete(request("delete"), boris.Name, 0), errorsPkg.ErrRequestProcessed)
+
+	changes, err := repo.UserHistory(ctx, anna.Name)
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+	user, err := repo.UserGet(ctx, boris.Name, false)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), user.Version)
+	messages, err := repo.OutboxList(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, messages, 5)
+
+	// the changes without the request are not deduplicated
+	require.NoError(t, repo.UserUpdate(request(""), profile))
+	require.NoError(t, repo.UserUpdate(request(""), profile))
+
+	processed, err := repo.RequestProcessed(ctx, "create")
+	require.NoError(t, err)
+	assert.True(t, processed)
+	processed, err = repo.RequestProcessed(ctx, "unknown")
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	// the request is processed until its published message is purged
+	messages, err = repo.OutboxList(ctx, 10)
+	require.NoError(t, err)
+	ids := make([]uint64, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	require.NoError(t, repo.OutboxMarkPublished(ctx, ids))
+	assert.ErrorIs(t, repo.UserRestore(request("restore"), boris.Name), errorsPkg.ErrRequestProcessed)
+	purged, err := repo.OutboxPurge(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	// the storage may purge the messages without the request too
+	assert.GreaterOrEqual(t, purged, int64(5))
+	processed, err = repo.RequestProcessed(ctx, "create")
+	require.NoError(t, err)
+	assert.False(t, processed)
+}
+
+// tick makes the time of the next change differ from the previous one in the precision of any storage.
+func tick() {
+	time.Sleep(2 * time.Millisecond)
+}
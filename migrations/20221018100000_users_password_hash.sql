@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+ALTER TABLE public.users ALTER COLUMN password TYPE varchar(255);
+
+-- hash the plaintext passwords left from the previous versions, bcrypt hashes are compatible with the service
+UPDATE public.users
+SET password = crypt(password, gen_salt('bf', 10))
+WHERE password NOT LIKE '$%';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- password hashes can not be turned back into plaintext and do not fit varchar(30),
+-- so the column stays wide
+SELECT 'down SQL query';
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS deleted_at bigint;
+
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON public.users (deleted_at) WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM public.users WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS users_deleted_at_idx;
+
+ALTER TABLE public.users DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX IF NOT EXISTS users_created_at_name_idx ON public.users (created_at, name);
+
+CREATE INDEX IF NOT EXISTS users_full_name_name_idx ON public.users (full_name, name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS users_full_name_name_idx;
+
+DROP INDEX IF EXISTS users_created_at_name_idx;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the UNIX seconds set by the receiver become timestamps, the rows without the time get the migration time
+ALTER TABLE public.users
+    ALTER COLUMN created_at TYPE timestamptz USING COALESCE(to_timestamp(created_at), now()),
+    ALTER COLUMN created_at SET DEFAULT now(),
+    ALTER COLUMN created_at SET NOT NULL;
+
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now();
+
+UPDATE public.users SET updated_at = created_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE public.users DROP COLUMN IF EXISTS updated_at;
+
+ALTER TABLE public.users
+    ALTER COLUMN created_at DROP NOT NULL,
+    ALTER COLUMN created_at DROP DEFAULT,
+    ALTER COLUMN created_at TYPE integer USING extract(epoch FROM created_at)::integer;
+-- +goose StatementEnd
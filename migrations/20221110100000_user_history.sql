@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the changes of the users without the passwords, the rows are kept after the user is purged
+CREATE TABLE IF NOT EXISTS public.user_history
+(
+    id         bigserial PRIMARY KEY,
+    name       varchar(30) NOT NULL,
+    operation  varchar(16) NOT NULL,
+    old_values jsonb,
+    new_values jsonb       NOT NULL,
+    request_id text        NOT NULL DEFAULT '',
+    changed_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS user_history_name_changed_at_idx ON public.user_history (name, changed_at);
+
+-- the users created before the history are recorded as created at their creation time,
+-- the deleted ones are recorded as deleted at their deletion time as well
+INSERT INTO public.user_history (name, operation, new_values, changed_at)
+SELECT name,
+       'create',
+       jsonb_build_object('name', name, 'email', email, 'full_name', full_name,
+                          'created_at', created_at, 'updated_at', updated_at, 'version', version),
+       created_at
+FROM public.users;
+
+INSERT INTO public.user_history (name, operation, old_values, new_values, changed_at)
+SELECT name,
+       'delete',
+       jsonb_build_object('name', name, 'email', email, 'full_name', full_name,
+                          'created_at', created_at, 'updated_at', updated_at, 'version', version),
+       jsonb_build_object('name', name, 'email', email, 'full_name', full_name,
+                          'created_at', created_at, 'updated_at', updated_at, 'version', version,
+                          'deleted_at', deleted_at),
+       to_timestamp(deleted_at)
+FROM public.users
+WHERE deleted_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.user_history;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the messages about the user changes written with the changes and published by the relay
+CREATE TABLE IF NOT EXISTS public.outbox
+(
+    id           bigserial PRIMARY KEY,
+    topic        text        NOT NULL,
+    key          text        NOT NULL,
+    value        bytea,
+    headers      jsonb       NOT NULL DEFAULT '{}',
+    created_at   timestamptz NOT NULL DEFAULT now(),
+    published_at timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS outbox_not_published_idx ON public.outbox (id) WHERE published_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS public.outbox;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the relay claims the messages it publishes, the other relays skip them until the claim expires
+ALTER TABLE public.outbox ADD COLUMN IF NOT EXISTS claimed_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS outbox_published_at_idx ON public.outbox (published_at) WHERE published_at IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS outbox_published_at_idx;
+
+ALTER TABLE public.outbox DROP COLUMN IF EXISTS claimed_at;
+-- +goose StatementEnd
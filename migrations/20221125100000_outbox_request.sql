@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- the message keeps the request of the change, the redelivered request is not applied again
+ALTER TABLE public.outbox ADD COLUMN IF NOT EXISTS request_id text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS outbox_request_id_idx ON public.outbox (request_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS outbox_request_id_idx;
+
+ALTER TABLE public.outbox DROP COLUMN IF EXISTS request_id;
+-- +goose StatementEnd
@@ -1,28 +1,47 @@
 package adaptor
 
 import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	sessionModels "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/session/models"
 	coreModels "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
 	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
 )
 
 func ToUserPbModel(u coreModels.User) *pbModels.User {
 	return &pbModels.User{
 		Name:      u.Name,
-		Password:  u.Password,
 		Email:     u.Email,
 		FullName:  u.FullName,
-		CreatedAt: u.CreatedAt,
+		CreatedAt: toTimestamp(u.CreatedAt),
+		UpdatedAt: toTimestamp(u.UpdatedAt),
+		Version:   u.Version,
+		DeletedAt: u.DeletedAt,
 	}
 }
 
 func ToUserCoreModel(u *pbModels.User) *coreModels.User {
 	return &coreModels.User{
-		Name:      u.Name,
-		Password:  u.Password,
-		Email:     u.Email,
-		FullName:  u.FullName,
-		CreatedAt: u.CreatedAt,
+		Name:     u.GetName(),
+		Password: u.GetPassword(),
+		Email:    u.GetEmail(),
+		FullName: u.GetFullName(),
+	}
+}
+
+// ToProfileCoreModel keeps the presence of the optional profile fields.
+func ToProfileCoreModel(name string, p *pbModels.Profile) *coreModels.Profile {
+	if p == nil {
+		return coreModels.NewProfile().NameSet(name)
 	}
+	return coreModels.NewProfile().
+		NameSet(name).
+		PasswordSet(p.Password).
+		EmailSet(p.Email).
+		FullNameSet(p.FullName)
 }
 
 func ToUserListPbModel(users []coreModels.User) []*pbModels.User {
@@ -33,3 +52,62 @@ func ToUserListPbModel(users []coreModels.User) []*pbModels.User {
 
 	return list
 }
+
+func ToUserChangeListPbModel(changes []coreModels.UserChange) []*pbModels.UserChange {
+	list := make([]*pbModels.UserChange, 0, len(changes))
+	for _, change := range changes {
+		var old *pbModels.User
+		if change.Old != nil {
+			old = ToUserPbModel(*change.Old)
+		}
+		list = append(list, &pbModels.UserChange{
+			Operation: change.Operation,
+			Old:       old,
+			New:       ToUserPbModel(change.New),
+			RequestId: change.RequestID,
+			ChangedAt: toTimestamp(change.ChangedAt),
+		})
+	}
+
+	return list
+}
+
+func ToUserFilterCoreModel(f *pb.UserFilter) coreModels.UserFilter {
+	return coreModels.UserFilter{
+		EmailDomain: f.GetEmailDomain(),
+		FullName:    f.GetFullName(),
+		CreatedFrom: f.GetCreatedFrom(),
+		CreatedTo:   f.GetCreatedTo(),
+	}
+}
+
+// toTimestamp returns nil for the zero time, so the unset time is omitted.
+func toTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func ToTokenPbModel(t sessionModels.Token) *pbModels.Token {
+	return &pbModels.Token{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    t.ExpiresIn,
+		SessionId:    t.SessionID,
+	}
+}
+
+func ToSessionListPbModel(sessions []sessionModels.Session) []*pbModels.Session {
+	list := make([]*pbModels.Session, 0, len(sessions))
+	for _, s := range sessions {
+		list = append(list, &pbModels.Session{
+			Id:        s.ID,
+			CreatedAt: s.CreatedAt,
+			ExpiresAt: s.ExpiresAt,
+		})
+	}
+
+	return list
+}
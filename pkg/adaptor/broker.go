@@ -0,0 +1,38 @@
+package adaptor
+
+import (
+	coreModels "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+// ToOutboxMessage converts the message to be written to the outbox.
+func ToOutboxMessage(msg *brokerPkg.Message) coreModels.OutboxMessage {
+	message := coreModels.OutboxMessage{
+		Topic: msg.Topic,
+		Key:   string(msg.Key),
+		Value: msg.Value,
+	}
+	if len(msg.Headers) != 0 {
+		message.Headers = make(map[string]string, len(msg.Headers))
+		for _, header := range msg.Headers {
+			message.Headers[string(header.Key)] = string(header.Value)
+		}
+	}
+	return message
+}
+
+// ToBrokerMessage converts the outbox message to be published.
+func ToBrokerMessage(message coreModels.OutboxMessage) *brokerPkg.Message {
+	msg := &brokerPkg.Message{
+		Topic: message.Topic,
+		Key:   []byte(message.Key),
+		Value: message.Value,
+	}
+	for key, value := range message.Headers {
+		msg.Headers = append(msg.Headers, brokerPkg.Header{
+			Key:   []byte(key),
+			Value: []byte(value),
+		})
+	}
+	return msg
+}
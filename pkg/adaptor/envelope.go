@@ -0,0 +1,165 @@
+package adaptor
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	coreModels "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/models"
+	pbModels "gitlab.ozon.dev/iTukaev/homework/pkg/api/models"
+)
+
+// ToUserRequestPbModel keeps the password of the user to create.
+func ToUserRequestPbModel(u coreModels.User) *pbModels.User {
+	user := ToUserPbModel(u)
+	user.Password = u.Password
+	return user
+}
+
+// ToUserResultCoreModel converts the got user with its timestamps.
+func ToUserResultCoreModel(u *pbModels.User) coreModels.User {
+	user := ToUserCoreModel(u)
+	user.CreatedAt = fromTimestamp(u.GetCreatedAt())
+	user.UpdatedAt = fromTimestamp(u.GetUpdatedAt())
+	user.Version = u.GetVersion()
+	user.DeletedAt = u.GetDeletedAt()
+	return *user
+}
+
+func ToUserBatchPbModel(b coreModels.UserBatch) *pbModels.UserBatch {
+	users := make([]*pbModels.User, 0, len(b.Users))
+	for _, user := range b.Users {
+		users = append(users, ToUserRequestPbModel(user))
+	}
+	return &pbModels.UserBatch{
+		Users:  users,
+		Errors: b.Errors,
+	}
+}
+
+func ToUserBatchCoreModel(b *pbModels.UserBatch) *coreModels.UserBatch {
+	users := make([]coreModels.User, 0, len(b.GetUsers()))
+	for _, user := range b.GetUsers() {
+		if user == nil {
+			user = &pbModels.User{}
+		}
+		users = append(users, *ToUserCoreModel(user))
+	}
+	return coreModels.NewUserBatch().
+		UsersSet(users).
+		ErrorsSet(b.GetErrors())
+}
+
+func ToProfileUpdatePbModel(p coreModels.Profile) *pbModels.ProfileUpdate {
+	return &pbModels.ProfileUpdate{
+		Name: p.Name,
+		Profile: &pbModels.Profile{
+			Password: p.Password,
+			Email:    p.Email,
+			FullName: p.FullName,
+		},
+		Version: p.Version,
+	}
+}
+
+func ToProfileUpdateCoreModel(p *pbModels.ProfileUpdate) *coreModels.Profile {
+	return ToProfileCoreModel(p.GetName(), p.GetProfile()).VersionSet(p.GetVersion())
+}
+
+func ToUserListParamsPbModel(p coreModels.UserListParams) *pbModels.UserListParams {
+	sorting := make([]*pbModels.UserSort, 0, len(p.Sort))
+	for _, sort := range p.Sort {
+		sorting = append(sorting, &pbModels.UserSort{
+			Field: sort.Field,
+			Desc:  sort.Desc,
+		})
+	}
+	return &pbModels.UserListParams{
+		Limit:       p.Limit,
+		Offset:      p.Offset,
+		Order:       p.Order,
+		WithDeleted: p.WithDeleted,
+		PageToken:   p.PageToken,
+		Sort:        sorting,
+		Filter: &pbModels.UserListFilter{
+			EmailDomain: p.Filter.EmailDomain,
+			FullName:    p.Filter.FullName,
+			CreatedFrom: p.Filter.CreatedFrom,
+			CreatedTo:   p.Filter.CreatedTo,
+		},
+	}
+}
+
+func ToUserListParamsCoreModel(p *pbModels.UserListParams) *coreModels.UserListParams {
+	sorting := make([]coreModels.UserSort, 0, len(p.GetSort()))
+	for _, sort := range p.GetSort() {
+		sorting = append(sorting, coreModels.UserSort{
+			Field: sort.GetField(),
+			Desc:  sort.GetDesc(),
+		})
+	}
+	filter := p.GetFilter()
+	return coreModels.NewUserListParams().
+		LimitSet(p.GetLimit()).
+		OffsetSet(p.GetOffset()).
+		OrderSet(p.GetOrder()).
+		WithDeletedSet(p.GetWithDeleted()).
+		PageTokenSet(p.GetPageToken()).
+		SortSet(sorting).
+		FilterSet(coreModels.UserFilter{
+			EmailDomain: filter.GetEmailDomain(),
+			FullName:    filter.GetFullName(),
+			CreatedFrom: filter.GetCreatedFrom(),
+			CreatedTo:   filter.GetCreatedTo(),
+		})
+}
+
+func ToUserBatchResultsPbModel(results []coreModels.UserBatchResult) *pbModels.UserBatchResults {
+	list := make([]*pbModels.UserBatchResult, 0, len(results))
+	for _, result := range results {
+		list = append(list, &pbModels.UserBatchResult{
+			Name:  result.Name,
+			Error: result.Error,
+		})
+	}
+	return &pbModels.UserBatchResults{
+		Results: list,
+	}
+}
+
+func ToUserBatchResultsCoreModel(r *pbModels.UserBatchResults) []coreModels.UserBatchResult {
+	results := make([]coreModels.UserBatchResult, 0, len(r.GetResults()))
+	for _, result := range r.GetResults() {
+		results = append(results, coreModels.UserBatchResult{
+			Name:  result.GetName(),
+			Error: result.GetError(),
+		})
+	}
+	return results
+}
+
+func ToUserListPagePbModel(p coreModels.UserListPage) *pbModels.UserListPage {
+	return &pbModels.UserListPage{
+		Users:         ToUserListPbModel(p.Users),
+		NextPageToken: p.NextPageToken,
+	}
+}
+
+func ToUserListPageCoreModel(p *pbModels.UserListPage) coreModels.UserListPage {
+	users := make([]coreModels.User, 0, len(p.GetUsers()))
+	for _, user := range p.GetUsers() {
+		users = append(users, ToUserResultCoreModel(user))
+	}
+	return coreModels.UserListPage{
+		Users:         users,
+		NextPageToken: p.GetNextPageToken(),
+	}
+}
+
+// fromTimestamp returns the zero time for the unset timestamp.
+func fromTimestamp(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
@@ -26,21 +26,41 @@ type UserClient interface {
 	//
 	// Add new user to DB and cache
 	UserCreate(ctx context.Context, in *UserCreateRequest, opts ...grpc.CallOption) (*UserCreateResponse, error)
+	// Create users in batch
+	//
+	// Add the users to DB, the result reports the outcome of every user
+	UserBatchCreate(ctx context.Context, in *UserBatchCreateRequest, opts ...grpc.CallOption) (*UserBatchCreateResponse, error)
+	// Import users
+	//
+	// Streams the users to create, they are sent in batches, the result of every batch is read by its uid
+	UserImport(ctx context.Context, opts ...grpc.CallOption) (User_UserImportClient, error)
 	// Update user information
 	//
-	// Update user's password, email and full name in DB and cache
+	// Update user's password, email and full name in DB and cache, only the fields set in profile are changed
 	UserUpdate(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*UserUpdateResponse, error)
 	// Delete user
 	//
-	// Delete user from DB and cache
+	// Mark user as deleted in DB and remove from cache, the user can be restored until purged
 	UserDelete(ctx context.Context, in *UserDeleteRequest, opts ...grpc.CallOption) (*UserDeleteResponse, error)
+	// Restore user
+	//
+	// Restore deleted user if it is not purged yet
+	UserRestore(ctx context.Context, in *UserRestoreRequest, opts ...grpc.CallOption) (*UserRestoreResponse, error)
 	// Get user information
 	//
 	// Returns user information by user name
 	UserGet(ctx context.Context, in *UserGetRequest, opts ...grpc.CallOption) (*UserGetResponse, error)
+	// Get user history
+	//
+	// Returns the changes of the user from the oldest one, passwords are not recorded
+	UserHistory(ctx context.Context, in *UserHistoryRequest, opts ...grpc.CallOption) (*UserHistoryResponse, error)
+	// Get user as of time
+	//
+	// Returns user information at the time, the user deleted at the time is returned with deleted_at
+	UserGetAsOf(ctx context.Context, in *UserGetAsOfRequest, opts ...grpc.CallOption) (*UserGetAsOfResponse, error)
 	// Get users list
 	//
-	// Returns all users from DB
+	// Returns the page of users and the token of the next page
 	UserList(ctx context.Context, in *UserListRequest, opts ...grpc.CallOption) (*UserListResponse, error)
 	// Get users list
 	//
@@ -50,6 +70,26 @@ type UserClient interface {
 	//
 	// Returns all users from DB
 	UserAllList(ctx context.Context, in *UserAllListRequest, opts ...grpc.CallOption) (User_UserAllListClient, error)
+	// Log in
+	//
+	// Checks user credentials and opens a new session
+	UserLogin(ctx context.Context, in *UserLoginRequest, opts ...grpc.CallOption) (*UserLoginResponse, error)
+	// Log out
+	//
+	// Closes the session of the refresh token
+	UserLogout(ctx context.Context, in *UserLogoutRequest, opts ...grpc.CallOption) (*UserLogoutResponse, error)
+	// Refresh tokens
+	//
+	// Returns a new token pair, the refresh token can be used only once
+	TokenRefresh(ctx context.Context, in *TokenRefreshRequest, opts ...grpc.CallOption) (*TokenRefreshResponse, error)
+	// Get user sessions
+	//
+	// Returns active sessions of the user, requires user's access token
+	SessionList(ctx context.Context, in *SessionListRequest, opts ...grpc.CallOption) (*SessionListResponse, error)
+	// Revoke user session
+	//
+	// Closes the user's session, requires user's access token
+	SessionRevoke(ctx context.Context, in *SessionRevokeRequest, opts ...grpc.CallOption) (*SessionRevokeResponse, error)
 }
 
 type userClient struct {
@@ -69,6 +109,49 @@ func (c *userClient) UserCreate(ctx context.Context, in *UserCreateRequest, opts
 	return out, nil
 }
 
+func (c *userClient) UserBatchCreate(ctx context.Context, in *UserBatchCreateRequest, opts ...grpc.CallOption) (*UserBatchCreateResponse, error) {
+	out := new(UserBatchCreateResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserBatchCreate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserImport(ctx context.Context, opts ...grpc.CallOption) (User_UserImportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &User_ServiceDesc.Streams[0], "/gitlab.ozon.dev.iTukaev.homework.api.User/UserImport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userUserImportClient{stream}
+	return x, nil
+}
+
+type User_UserImportClient interface {
+	Send(*UserImportRequest) error
+	CloseAndRecv() (*UserImportResponse, error)
+	grpc.ClientStream
+}
+
+type userUserImportClient struct {
+	grpc.ClientStream
+}
+
+func (x *userUserImportClient) Send(m *UserImportRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *userUserImportClient) CloseAndRecv() (*UserImportResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UserImportResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *userClient) UserUpdate(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*UserUpdateResponse, error) {
 	out := new(UserUpdateResponse)
 	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserUpdate", in, out, opts...)
@@ -87,6 +170,15 @@ func (c *userClient) UserDelete(ctx context.Context, in *UserDeleteRequest, opts
 	return out, nil
 }
 
+func (c *userClient) UserRestore(ctx context.Context, in *UserRestoreRequest, opts ...grpc.CallOption) (*UserRestoreResponse, error) {
+	out := new(UserRestoreResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserRestore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userClient) UserGet(ctx context.Context, in *UserGetRequest, opts ...grpc.CallOption) (*UserGetResponse, error) {
 	out := new(UserGetResponse)
 	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserGet", in, out, opts...)
@@ -96,6 +188,24 @@ func (c *userClient) UserGet(ctx context.Context, in *UserGetRequest, opts ...gr
 	return out, nil
 }
 
+func (c *userClient) UserHistory(ctx context.Context, in *UserHistoryRequest, opts ...grpc.CallOption) (*UserHistoryResponse, error) {
+	out := new(UserHistoryResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserGetAsOf(ctx context.Context, in *UserGetAsOfRequest, opts ...grpc.CallOption) (*UserGetAsOfResponse, error) {
+	out := new(UserGetAsOfResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserGetAsOf", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userClient) UserList(ctx context.Context, in *UserListRequest, opts ...grpc.CallOption) (*UserListResponse, error) {
 	out := new(UserListResponse)
 	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserList", in, out, opts...)
@@ -115,7 +225,7 @@ func (c *userClient) Data(ctx context.Context, in *DataRequest, opts ...grpc.Cal
 }
 
 func (c *userClient) UserAllList(ctx context.Context, in *UserAllListRequest, opts ...grpc.CallOption) (User_UserAllListClient, error) {
-	stream, err := c.cc.NewStream(ctx, &User_ServiceDesc.Streams[0], "/gitlab.ozon.dev.iTukaev.homework.api.User/UserAllList", opts...)
+	stream, err := c.cc.NewStream(ctx, &User_ServiceDesc.Streams[1], "/gitlab.ozon.dev.iTukaev.homework.api.User/UserAllList", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +256,51 @@ func (x *userUserAllListClient) Recv() (*UserAllListResponse, error) {
 	return m, nil
 }
 
+func (c *userClient) UserLogin(ctx context.Context, in *UserLoginRequest, opts ...grpc.CallOption) (*UserLoginResponse, error) {
+	out := new(UserLoginResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserLogin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UserLogout(ctx context.Context, in *UserLogoutRequest, opts ...grpc.CallOption) (*UserLogoutResponse, error) {
+	out := new(UserLogoutResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/UserLogout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) TokenRefresh(ctx context.Context, in *TokenRefreshRequest, opts ...grpc.CallOption) (*TokenRefreshResponse, error) {
+	out := new(TokenRefreshResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/TokenRefresh", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) SessionList(ctx context.Context, in *SessionListRequest, opts ...grpc.CallOption) (*SessionListResponse, error) {
+	out := new(SessionListResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/SessionList", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) SessionRevoke(ctx context.Context, in *SessionRevokeRequest, opts ...grpc.CallOption) (*SessionRevokeResponse, error) {
+	out := new(SessionRevokeResponse)
+	err := c.cc.Invoke(ctx, "/gitlab.ozon.dev.iTukaev.homework.api.User/SessionRevoke", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServer is the server API for User service.
 // All implementations must embed UnimplementedUserServer
 // for forward compatibility
@@ -154,21 +309,41 @@ type UserServer interface {
 	//
 	// Add new user to DB and cache
 	UserCreate(context.Context, *UserCreateRequest) (*UserCreateResponse, error)
+	// Create users in batch
+	//
+	// Add the users to DB, the result reports the outcome of every user
+	UserBatchCreate(context.Context, *UserBatchCreateRequest) (*UserBatchCreateResponse, error)
+	// Import users
+	//
+	// Streams the users to create, they are sent in batches, the result of every batch is read by its uid
+	UserImport(User_UserImportServer) error
 	// Update user information
 	//
-	// Update user's password, email and full name in DB and cache
+	// Update user's password, email and full name in DB and cache, only the fields set in profile are changed
 	UserUpdate(context.Context, *UserUpdateRequest) (*UserUpdateResponse, error)
 	// Delete user
 	//
-	// Delete user from DB and cache
+	// Mark user as deleted in DB and remove from cache, the user can be restored until purged
 	UserDelete(context.Context, *UserDeleteRequest) (*UserDeleteResponse, error)
+	// Restore user
+	//
+	// Restore deleted user if it is not purged yet
+	UserRestore(context.Context, *UserRestoreRequest) (*UserRestoreResponse, error)
 	// Get user information
 	//
 	// Returns user information by user name
 	UserGet(context.Context, *UserGetRequest) (*UserGetResponse, error)
+	// Get user history
+	//
+	// Returns the changes of the user from the oldest one, passwords are not recorded
+	UserHistory(context.Context, *UserHistoryRequest) (*UserHistoryResponse, error)
+	// Get user as of time
+	//
+	// Returns user information at the time, the user deleted at the time is returned with deleted_at
+	UserGetAsOf(context.Context, *UserGetAsOfRequest) (*UserGetAsOfResponse, error)
 	// Get users list
 	//
-	// Returns all users from DB
+	// Returns the page of users and the token of the next page
 	UserList(context.Context, *UserListRequest) (*UserListResponse, error)
 	// Get users list
 	//
@@ -178,6 +353,26 @@ type UserServer interface {
 	//
 	// Returns all users from DB
 	UserAllList(*UserAllListRequest, User_UserAllListServer) error
+	// Log in
+	//
+	// Checks user credentials and opens a new session
+	UserLogin(context.Context, *UserLoginRequest) (*UserLoginResponse, error)
+	// Log out
+	//
+	// Closes the session of the refresh token
+	UserLogout(context.Context, *UserLogoutRequest) (*UserLogoutResponse, error)
+	// Refresh tokens
+	//
+	// Returns a new token pair, the refresh token can be used only once
+	TokenRefresh(context.Context, *TokenRefreshRequest) (*TokenRefreshResponse, error)
+	// Get user sessions
+	//
+	// Returns active sessions of the user, requires user's access token
+	SessionList(context.Context, *SessionListRequest) (*SessionListResponse, error)
+	// Revoke user session
+	//
+	// Closes the user's session, requires user's access token
+	SessionRevoke(context.Context, *SessionRevokeRequest) (*SessionRevokeResponse, error)
 	mustEmbedUnimplementedUserServer()
 }
 
@@ -188,15 +383,30 @@ type UnimplementedUserServer struct {
 func (UnimplementedUserServer) UserCreate(context.Context, *UserCreateRequest) (*UserCreateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UserCreate not implemented")
 }
+func (UnimplementedUserServer) UserBatchCreate(context.Context, *UserBatchCreateRequest) (*UserBatchCreateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserBatchCreate not implemented")
+}
+func (UnimplementedUserServer) UserImport(User_UserImportServer) error {
+	return status.Errorf(codes.Unimplemented, "method UserImport not implemented")
+}
 func (UnimplementedUserServer) UserUpdate(context.Context, *UserUpdateRequest) (*UserUpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UserUpdate not implemented")
 }
 func (UnimplementedUserServer) UserDelete(context.Context, *UserDeleteRequest) (*UserDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UserDelete not implemented")
 }
+func (UnimplementedUserServer) UserRestore(context.Context, *UserRestoreRequest) (*UserRestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRestore not implemented")
+}
 func (UnimplementedUserServer) UserGet(context.Context, *UserGetRequest) (*UserGetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UserGet not implemented")
 }
+func (UnimplementedUserServer) UserHistory(context.Context, *UserHistoryRequest) (*UserHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserHistory not implemented")
+}
+func (UnimplementedUserServer) UserGetAsOf(context.Context, *UserGetAsOfRequest) (*UserGetAsOfResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserGetAsOf not implemented")
+}
 func (UnimplementedUserServer) UserList(context.Context, *UserListRequest) (*UserListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UserList not implemented")
 }
@@ -206,6 +416,21 @@ func (UnimplementedUserServer) Data(context.Context, *DataRequest) (*DataRespons
 func (UnimplementedUserServer) UserAllList(*UserAllListRequest, User_UserAllListServer) error {
 	return status.Errorf(codes.Unimplemented, "method UserAllList not implemented")
 }
+func (UnimplementedUserServer) UserLogin(context.Context, *UserLoginRequest) (*UserLoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserLogin not implemented")
+}
+func (UnimplementedUserServer) UserLogout(context.Context, *UserLogoutRequest) (*UserLogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserLogout not implemented")
+}
+func (UnimplementedUserServer) TokenRefresh(context.Context, *TokenRefreshRequest) (*TokenRefreshResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TokenRefresh not implemented")
+}
+func (UnimplementedUserServer) SessionList(context.Context, *SessionListRequest) (*SessionListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SessionList not implemented")
+}
+func (UnimplementedUserServer) SessionRevoke(context.Context, *SessionRevokeRequest) (*SessionRevokeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SessionRevoke not implemented")
+}
 func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
 
 // UnsafeUserServer may be embedded to opt out of forward compatibility for this service.
@@ -237,6 +462,50 @@ func _User_UserCreate_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_UserBatchCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserBatchCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserBatchCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/UserBatchCreate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserBatchCreate(ctx, req.(*UserBatchCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserImport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(UserServer).UserImport(&userUserImportServer{stream})
+}
+
+type User_UserImportServer interface {
+	SendAndClose(*UserImportResponse) error
+	Recv() (*UserImportRequest, error)
+	grpc.ServerStream
+}
+
+type userUserImportServer struct {
+	grpc.ServerStream
+}
+
+func (x *userUserImportServer) SendAndClose(m *UserImportResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *userUserImportServer) Recv() (*UserImportRequest, error) {
+	m := new(UserImportRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _User_UserUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserUpdateRequest)
 	if err := dec(in); err != nil {
@@ -273,6 +542,24 @@ func _User_UserDelete_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_UserRestore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserRestore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/UserRestore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserRestore(ctx, req.(*UserRestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _User_UserGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserGetRequest)
 	if err := dec(in); err != nil {
@@ -291,6 +578,42 @@ func _User_UserGet_Handler(srv interface{}, ctx context.Context, dec func(interf
 	return interceptor(ctx, in, info, handler)
 }
 
+func _User_UserHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/UserHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserHistory(ctx, req.(*UserHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserGetAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserGetAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserGetAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/UserGetAsOf",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserGetAsOf(ctx, req.(*UserGetAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _User_UserList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UserListRequest)
 	if err := dec(in); err != nil {
@@ -348,6 +671,96 @@ func (x *userUserAllListServer) Send(m *UserAllListResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _User_UserLogin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserLoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserLogin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/UserLogin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserLogin(ctx, req.(*UserLoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UserLogout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserLogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UserLogout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/UserLogout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UserLogout(ctx, req.(*UserLogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_TokenRefresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TokenRefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).TokenRefresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/TokenRefresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).TokenRefresh(ctx, req.(*TokenRefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_SessionList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).SessionList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/SessionList",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).SessionList(ctx, req.(*SessionListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_SessionRevoke_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRevokeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).SessionRevoke(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gitlab.ozon.dev.iTukaev.homework.api.User/SessionRevoke",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).SessionRevoke(ctx, req.(*SessionRevokeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // User_ServiceDesc is the grpc.ServiceDesc for User service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -359,6 +772,10 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UserCreate",
 			Handler:    _User_UserCreate_Handler,
 		},
+		{
+			MethodName: "UserBatchCreate",
+			Handler:    _User_UserBatchCreate_Handler,
+		},
 		{
 			MethodName: "UserUpdate",
 			Handler:    _User_UserUpdate_Handler,
@@ -367,10 +784,22 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UserDelete",
 			Handler:    _User_UserDelete_Handler,
 		},
+		{
+			MethodName: "UserRestore",
+			Handler:    _User_UserRestore_Handler,
+		},
 		{
 			MethodName: "UserGet",
 			Handler:    _User_UserGet_Handler,
 		},
+		{
+			MethodName: "UserHistory",
+			Handler:    _User_UserHistory_Handler,
+		},
+		{
+			MethodName: "UserGetAsOf",
+			Handler:    _User_UserGetAsOf_Handler,
+		},
 		{
 			MethodName: "UserList",
 			Handler:    _User_UserList_Handler,
@@ -379,8 +808,33 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Data",
 			Handler:    _User_Data_Handler,
 		},
+		{
+			MethodName: "UserLogin",
+			Handler:    _User_UserLogin_Handler,
+		},
+		{
+			MethodName: "UserLogout",
+			Handler:    _User_UserLogout_Handler,
+		},
+		{
+			MethodName: "TokenRefresh",
+			Handler:    _User_TokenRefresh_Handler,
+		},
+		{
+			MethodName: "SessionList",
+			Handler:    _User_SessionList_Handler,
+		},
+		{
+			MethodName: "SessionRevoke",
+			Handler:    _User_SessionRevoke_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UserImport",
+			Handler:       _User_UserImport_Handler,
+			ClientStreams: true,
+		},
 		{
 			StreamName:    "UserAllList",
 			Handler:       _User_UserAllList_Handler,
@@ -0,0 +1,1415 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.0
+// 	protoc        (unknown)
+// source: models/envelope.proto
+
+package models
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// How the client waits for the result, the same as the request pub_sub.
+type WaitMode int32
+
+const (
+	WaitMode_pub   WaitMode = 0
+	WaitMode_cache WaitMode = 1
+)
+
+// Enum value maps for WaitMode.
+var (
+	WaitMode_name = map[int32]string{
+		0: "pub",
+		1: "cache",
+	}
+	WaitMode_value = map[string]int32{
+		"pub":   0,
+		"cache": 1,
+	}
+)
+
+func (x WaitMode) Enum() *WaitMode {
+	p := new(WaitMode)
+	*p = x
+	return p
+}
+
+func (x WaitMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WaitMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_envelope_proto_enumTypes[0].Descriptor()
+}
+
+func (WaitMode) Type() protoreflect.EnumType {
+	return &file_models_envelope_proto_enumTypes[0]
+}
+
+func (x WaitMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WaitMode.Descriptor instead.
+func (WaitMode) EnumDescriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{0}
+}
+
+type Error_Code int32
+
+const (
+	Error_UNKNOWN              Error_Code = 0
+	Error_VALIDATION           Error_Code = 1
+	Error_NOT_FOUND            Error_Code = 2
+	Error_ALREADY_EXISTS       Error_Code = 3
+	Error_EMAIL_ALREADY_EXISTS Error_Code = 4
+	Error_VERSION_CONFLICT     Error_Code = 5
+	Error_INVALID_PAGE_TOKEN   Error_Code = 6
+	Error_INVALID_FIELD        Error_Code = 7
+	Error_FIELD_TOO_LONG       Error_Code = 8
+	Error_DEADLINE_EXCEEDED    Error_Code = 9
+)
+
+// Enum value maps for Error_Code.
+var (
+	Error_Code_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "VALIDATION",
+		2: "NOT_FOUND",
+		3: "ALREADY_EXISTS",
+		4: "EMAIL_ALREADY_EXISTS",
+		5: "VERSION_CONFLICT",
+		6: "INVALID_PAGE_TOKEN",
+		7: "INVALID_FIELD",
+		8: "FIELD_TOO_LONG",
+		9: "DEADLINE_EXCEEDED",
+	}
+	Error_Code_value = map[string]int32{
+		"UNKNOWN":              0,
+		"VALIDATION":           1,
+		"NOT_FOUND":            2,
+		"ALREADY_EXISTS":       3,
+		"EMAIL_ALREADY_EXISTS": 4,
+		"VERSION_CONFLICT":     5,
+		"INVALID_PAGE_TOKEN":   6,
+		"INVALID_FIELD":        7,
+		"FIELD_TOO_LONG":       8,
+		"DEADLINE_EXCEEDED":    9,
+	}
+)
+
+func (x Error_Code) Enum() *Error_Code {
+	p := new(Error_Code)
+	*p = x
+	return p
+}
+
+func (x Error_Code) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Error_Code) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_envelope_proto_enumTypes[1].Descriptor()
+}
+
+func (Error_Code) Type() protoreflect.EnumType {
+	return &file_models_envelope_proto_enumTypes[1]
+}
+
+func (x Error_Code) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Error_Code.Descriptor instead.
+func (Error_Code) EnumDescriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{10, 0}
+}
+
+// Message between the services, the value of every Kafka message.
+type Envelope struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Format version, it is changed by incompatible changes only.
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Operation: create, batch_create, update, delete, restore, get or list.
+	Operation string `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	// Uid of the request returned to the client.
+	RequestId string `protobuf:"bytes,3,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`
+	// How the client waits for the result.
+	Wait WaitMode `protobuf:"varint,4,opt,name=wait,proto3,enum=gitlab.ozon.dev.iTukaev.homework.api.models.WaitMode" json:"wait,omitempty"`
+	// Time the request was received.
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// The request is not handled after the deadline, not set for the request without deadline.
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	// Request or result of the operation, not set for the results without data.
+	//
+	// Types that are assignable to Payload:
+	//	*Envelope_User
+	//	*Envelope_Batch
+	//	*Envelope_Profile
+	//	*Envelope_Key
+	//	*Envelope_ListParams
+	//	*Envelope_BatchResults
+	//	*Envelope_Page
+	//	*Envelope_Error
+	Payload isEnvelope_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Envelope) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Envelope) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *Envelope) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+func (x *Envelope) GetWait() WaitMode {
+	if x != nil {
+		return x.Wait
+	}
+	return WaitMode_pub
+}
+
+func (x *Envelope) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (m *Envelope) GetPayload() isEnvelope_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetUser() *User {
+	if x, ok := x.GetPayload().(*Envelope_User); ok {
+		return x.User
+	}
+	return nil
+}
+
+func (x *Envelope) GetBatch() *UserBatch {
+	if x, ok := x.GetPayload().(*Envelope_Batch); ok {
+		return x.Batch
+	}
+	return nil
+}
+
+func (x *Envelope) GetProfile() *ProfileUpdate {
+	if x, ok := x.GetPayload().(*Envelope_Profile); ok {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *Envelope) GetKey() *UserKey {
+	if x, ok := x.GetPayload().(*Envelope_Key); ok {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *Envelope) GetListParams() *UserListParams {
+	if x, ok := x.GetPayload().(*Envelope_ListParams); ok {
+		return x.ListParams
+	}
+	return nil
+}
+
+func (x *Envelope) GetBatchResults() *UserBatchResults {
+	if x, ok := x.GetPayload().(*Envelope_BatchResults); ok {
+		return x.BatchResults
+	}
+	return nil
+}
+
+func (x *Envelope) GetPage() *UserListPage {
+	if x, ok := x.GetPayload().(*Envelope_Page); ok {
+		return x.Page
+	}
+	return nil
+}
+
+func (x *Envelope) GetError() *Error {
+	if x, ok := x.GetPayload().(*Envelope_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+type isEnvelope_Payload interface {
+	isEnvelope_Payload()
+}
+
+type Envelope_User struct {
+	// User to create or the got user.
+	User *User `protobuf:"bytes,10,opt,name=user,proto3,oneof"`
+}
+
+type Envelope_Batch struct {
+	// Users to create.
+	Batch *UserBatch `protobuf:"bytes,11,opt,name=batch,proto3,oneof"`
+}
+
+type Envelope_Profile struct {
+	// Update of the user.
+	Profile *ProfileUpdate `protobuf:"bytes,12,opt,name=profile,proto3,oneof"`
+}
+
+type Envelope_Key struct {
+	// User to delete, restore or get.
+	Key *UserKey `protobuf:"bytes,13,opt,name=key,proto3,oneof"`
+}
+
+type Envelope_ListParams struct {
+	// Parameters of the list.
+	ListParams *UserListParams `protobuf:"bytes,14,opt,name=list_params,json=listParams,proto3,oneof"`
+}
+
+type Envelope_BatchResults struct {
+	// Results of the batch users.
+	BatchResults *UserBatchResults `protobuf:"bytes,15,opt,name=batch_results,json=batchResults,proto3,oneof"`
+}
+
+type Envelope_Page struct {
+	// Page of the list.
+	Page *UserListPage `protobuf:"bytes,16,opt,name=page,proto3,oneof"`
+}
+
+type Envelope_Error struct {
+	// Failure of the request.
+	Error *Error `protobuf:"bytes,17,opt,name=error,proto3,oneof"`
+}
+
+func (*Envelope_User) isEnvelope_Payload() {}
+
+func (*Envelope_Batch) isEnvelope_Payload() {}
+
+func (*Envelope_Profile) isEnvelope_Payload() {}
+
+func (*Envelope_Key) isEnvelope_Payload() {}
+
+func (*Envelope_ListParams) isEnvelope_Payload() {}
+
+func (*Envelope_BatchResults) isEnvelope_Payload() {}
+
+func (*Envelope_Page) isEnvelope_Payload() {}
+
+func (*Envelope_Error) isEnvelope_Payload() {}
+
+// Users to create.
+type UserBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Users with passwords.
+	Users []*User `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Validation errors of the users by index, the users with an error are not created.
+	Errors []string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *UserBatch) Reset() {
+	*x = UserBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBatch) ProtoMessage() {}
+
+func (x *UserBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBatch.ProtoReflect.Descriptor instead.
+func (*UserBatch) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UserBatch) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *UserBatch) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// Partial update of the user.
+type ProfileUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Fields to update, only set fields are changed.
+	Profile *Profile `protobuf:"bytes,2,opt,name=profile,proto3" json:"profile,omitempty"`
+	// Expected version of the user, zero skips the check.
+	Version uint64 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *ProfileUpdate) Reset() {
+	*x = ProfileUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProfileUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProfileUpdate) ProtoMessage() {}
+
+func (x *ProfileUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProfileUpdate.ProtoReflect.Descriptor instead.
+func (*ProfileUpdate) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProfileUpdate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProfileUpdate) GetProfile() *Profile {
+	if x != nil {
+		return x.Profile
+	}
+	return nil
+}
+
+func (x *ProfileUpdate) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// User addressed by the request.
+type UserKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// User name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Expected version of the user to delete, zero skips the check.
+	Version uint64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	// Get the deleted user too.
+	WithDeleted bool `protobuf:"varint,3,opt,name=with_deleted,json=withDeleted,proto3" json:"with_deleted,omitempty"`
+}
+
+func (x *UserKey) Reset() {
+	*x = UserKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserKey) ProtoMessage() {}
+
+func (x *UserKey) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserKey.ProtoReflect.Descriptor instead.
+func (*UserKey) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UserKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UserKey) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *UserKey) GetWithDeleted() bool {
+	if x != nil {
+		return x.WithDeleted
+	}
+	return false
+}
+
+// Sort field of the list.
+type UserSort struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Field: name, created_at, email or full_name.
+	Field string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	// Descending order.
+	Desc bool `protobuf:"varint,2,opt,name=desc,proto3" json:"desc,omitempty"`
+}
+
+func (x *UserSort) Reset() {
+	*x = UserSort{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserSort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSort) ProtoMessage() {}
+
+func (x *UserSort) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSort.ProtoReflect.Descriptor instead.
+func (*UserSort) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UserSort) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *UserSort) GetDesc() bool {
+	if x != nil {
+		return x.Desc
+	}
+	return false
+}
+
+// Filter of the list, zero fields are not applied.
+type UserListFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EmailDomain string `protobuf:"bytes,1,opt,name=email_domain,json=emailDomain,proto3" json:"email_domain,omitempty"`
+	FullName    string `protobuf:"bytes,2,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	CreatedFrom int64  `protobuf:"varint,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   int64  `protobuf:"varint,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+}
+
+func (x *UserListFilter) Reset() {
+	*x = UserListFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserListFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserListFilter) ProtoMessage() {}
+
+func (x *UserListFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserListFilter.ProtoReflect.Descriptor instead.
+func (*UserListFilter) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *UserListFilter) GetEmailDomain() string {
+	if x != nil {
+		return x.EmailDomain
+	}
+	return ""
+}
+
+func (x *UserListFilter) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *UserListFilter) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *UserListFilter) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+// Parameters of the list.
+type UserListParams struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Limit       uint64          `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset      uint64          `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	Order       bool            `protobuf:"varint,3,opt,name=order,proto3" json:"order,omitempty"`
+	WithDeleted bool            `protobuf:"varint,4,opt,name=with_deleted,json=withDeleted,proto3" json:"with_deleted,omitempty"`
+	PageToken   string          `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Sort        []*UserSort     `protobuf:"bytes,6,rep,name=sort,proto3" json:"sort,omitempty"`
+	Filter      *UserListFilter `protobuf:"bytes,7,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *UserListParams) Reset() {
+	*x = UserListParams{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserListParams) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserListParams) ProtoMessage() {}
+
+func (x *UserListParams) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserListParams.ProtoReflect.Descriptor instead.
+func (*UserListParams) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UserListParams) GetLimit() uint64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *UserListParams) GetOffset() uint64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UserListParams) GetOrder() bool {
+	if x != nil {
+		return x.Order
+	}
+	return false
+}
+
+func (x *UserListParams) GetWithDeleted() bool {
+	if x != nil {
+		return x.WithDeleted
+	}
+	return false
+}
+
+func (x *UserListParams) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *UserListParams) GetSort() []*UserSort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *UserListParams) GetFilter() *UserListFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// Result of the batch user, empty error means the user is created.
+type UserBatchResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *UserBatchResult) Reset() {
+	*x = UserBatchResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserBatchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBatchResult) ProtoMessage() {}
+
+func (x *UserBatchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBatchResult.ProtoReflect.Descriptor instead.
+func (*UserBatchResult) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UserBatchResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UserBatchResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Results of the batch users.
+type UserBatchResults struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*UserBatchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *UserBatchResults) Reset() {
+	*x = UserBatchResults{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserBatchResults) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserBatchResults) ProtoMessage() {}
+
+func (x *UserBatchResults) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserBatchResults.ProtoReflect.Descriptor instead.
+func (*UserBatchResults) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UserBatchResults) GetResults() []*UserBatchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Page of the list, empty next_page_token means the last page.
+type UserListPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Users         []*User `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	NextPageToken string  `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *UserListPage) Reset() {
+	*x = UserListPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UserListPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserListPage) ProtoMessage() {}
+
+func (x *UserListPage) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserListPage.ProtoReflect.Descriptor instead.
+func (*UserListPage) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UserListPage) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *UserListPage) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Failure of the request.
+type Error struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code Error_Code `protobuf:"varint,1,opt,name=code,proto3,enum=gitlab.ozon.dev.iTukaev.homework.api.models.Error_Code" json:"code,omitempty"`
+	// Description of the failure.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// Field of the user caused the failure, if any.
+	Field string `protobuf:"bytes,3,opt,name=field,proto3" json:"field,omitempty"`
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_models_envelope_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_models_envelope_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_models_envelope_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Error) GetCode() Error_Code {
+	if x != nil {
+		return x.Code
+	}
+	return Error_UNKNOWN
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+var File_models_envelope_proto protoreflect.FileDescriptor
+
+var file_models_envelope_proto_rawDesc = []byte{
+	0x0a, 0x15, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2f, 0x65, 0x6e, 0x76, 0x65, 0x6c, 0x6f, 0x70,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2b, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e,
+	0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61, 0x65, 0x76,
+	0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x11, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2f, 0x75, 0x73,
+	0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc8, 0x07, 0x0a, 0x08, 0x45, 0x6e, 0x76,
+	0x65, 0x6c, 0x6f, 0x70, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x1c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1d, 0x0a,
+	0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49, 0x64, 0x12, 0x49, 0x0a, 0x04,
+	0x77, 0x61, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x35, 0x2e, 0x67, 0x69, 0x74,
+	0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75,
+	0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x4d, 0x6f, 0x64,
+	0x65, 0x52, 0x04, 0x77, 0x61, 0x69, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x41, 0x74, 0x12, 0x36, 0x0a, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x08, 0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x47, 0x0a, 0x04, 0x75, 0x73,
+	0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61,
+	0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61,
+	0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x48, 0x00, 0x52, 0x04, 0x75,
+	0x73, 0x65, 0x72, 0x12, 0x4e, 0x0a, 0x05, 0x62, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x36, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e,
+	0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d,
+	0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73,
+	0x2e, 0x55, 0x73, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48, 0x00, 0x52, 0x05, 0x62, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x56, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x3a, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a,
+	0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68,
+	0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x48, 0x00, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x48, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61,
+	0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61,
+	0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x48, 0x00,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x5e, 0x0a, 0x0b, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x70, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x67, 0x69, 0x74,
+	0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75,
+	0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73,
+	0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x64, 0x0a, 0x0d, 0x62, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x72,
+	0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x67,
+	0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69,
+	0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x48, 0x00, 0x52, 0x0c, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x12, 0x4f, 0x0a, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x67, 0x69, 0x74, 0x6c,
+	0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b,
+	0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x50, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x4a, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x32, 0x2e, 0x67, 0x69,
+	0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54,
+	0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61,
+	0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x48,
+	0x00, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c,
+	0x6f, 0x61, 0x64, 0x22, 0x6c, 0x0a, 0x09, 0x55, 0x73, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x47, 0x0a, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x31, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65,
+	0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f,
+	0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x52, 0x05, 0x75, 0x73, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x73, 0x22, 0x8d, 0x01, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x4e, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x66, 0x69,
+	0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61,
+	0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61,
+	0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x52, 0x07,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x22, 0x5a, 0x0a, 0x07, 0x55, 0x73, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x69,
+	0x74, 0x68, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x22, 0x34, 0x0a,
+	0x08, 0x55, 0x73, 0x65, 0x72, 0x53, 0x6f, 0x72, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x65, 0x73, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x64,
+	0x65, 0x73, 0x63, 0x22, 0x92, 0x01, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f,
+	0x64, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x65, 0x6d,
+	0x61, 0x69, 0x6c, 0x44, 0x6f, 0x6d, 0x61, 0x69, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x6c,
+	0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x75,
+	0x6c, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x6f, 0x22, 0xb6, 0x02, 0x0a, 0x0e, 0x55, 0x73, 0x65,
+	0x72, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12,
+	0x21, 0x0a, 0x0c, 0x77, 0x69, 0x74, 0x68, 0x5f, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x69, 0x74, 0x68, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x12, 0x49, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x35, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65,
+	0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f,
+	0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73,
+	0x65, 0x72, 0x53, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x53, 0x0a, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3b, 0x2e, 0x67,
+	0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69,
+	0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e,
+	0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x4c,
+	0x69, 0x73, 0x74, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x22, 0x3b, 0x0a, 0x0f, 0x55, 0x73, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x6a,
+	0x0a, 0x10, 0x55, 0x73, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x73, 0x12, 0x56, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f,
+	0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f,
+	0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x7f, 0x0a, 0x0c, 0x55, 0x73,
+	0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x50, 0x61, 0x67, 0x65, 0x12, 0x47, 0x0a, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x31, 0x2e, 0x67, 0x69, 0x74, 0x6c,
+	0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b,
+	0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f, 0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x2e, 0x55, 0x73, 0x65, 0x72, 0x52, 0x05, 0x75, 0x73,
+	0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0xd3, 0x02, 0x0a, 0x05,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x4b, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x37, 0x2e, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f,
+	0x6e, 0x2e, 0x64, 0x65, 0x76, 0x2e, 0x69, 0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2e, 0x68, 0x6f,
+	0x6d, 0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f,
+	0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x22, 0xcc, 0x01, 0x0a, 0x04, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x55,
+	0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x56, 0x41, 0x4c, 0x49,
+	0x44, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f, 0x54, 0x5f,
+	0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x52, 0x45, 0x41,
+	0x44, 0x59, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x03, 0x12, 0x18, 0x0a, 0x14, 0x45,
+	0x4d, 0x41, 0x49, 0x4c, 0x5f, 0x41, 0x4c, 0x52, 0x45, 0x41, 0x44, 0x59, 0x5f, 0x45, 0x58, 0x49,
+	0x53, 0x54, 0x53, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x56, 0x45, 0x52, 0x53, 0x49, 0x4f, 0x4e,
+	0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x4c, 0x49, 0x43, 0x54, 0x10, 0x05, 0x12, 0x16, 0x0a, 0x12, 0x49,
+	0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x50, 0x41, 0x47, 0x45, 0x5f, 0x54, 0x4f, 0x4b, 0x45,
+	0x4e, 0x10, 0x06, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x46,
+	0x49, 0x45, 0x4c, 0x44, 0x10, 0x07, 0x12, 0x12, 0x0a, 0x0e, 0x46, 0x49, 0x45, 0x4c, 0x44, 0x5f,
+	0x54, 0x4f, 0x4f, 0x5f, 0x4c, 0x4f, 0x4e, 0x47, 0x10, 0x08, 0x12, 0x15, 0x0a, 0x11, 0x44, 0x45,
+	0x41, 0x44, 0x4c, 0x49, 0x4e, 0x45, 0x5f, 0x45, 0x58, 0x43, 0x45, 0x45, 0x44, 0x45, 0x44, 0x10,
+	0x09, 0x2a, 0x1e, 0x0a, 0x08, 0x57, 0x61, 0x69, 0x74, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x07, 0x0a,
+	0x03, 0x70, 0x75, 0x62, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x63, 0x61, 0x63, 0x68, 0x65, 0x10,
+	0x01, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x6c, 0x61, 0x62, 0x2e, 0x6f, 0x7a, 0x6f, 0x6e,
+	0x2e, 0x64, 0x65, 0x76, 0x2f, 0x69, 0x54, 0x75, 0x6b, 0x61, 0x65, 0x76, 0x2f, 0x68, 0x6f, 0x6d,
+	0x65, 0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x73, 0x3b, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_models_envelope_proto_rawDescOnce sync.Once
+	file_models_envelope_proto_rawDescData = file_models_envelope_proto_rawDesc
+)
+
+func file_models_envelope_proto_rawDescGZIP() []byte {
+	file_models_envelope_proto_rawDescOnce.Do(func() {
+		file_models_envelope_proto_rawDescData = protoimpl.X.CompressGZIP(file_models_envelope_proto_rawDescData)
+	})
+	return file_models_envelope_proto_rawDescData
+}
+
+var file_models_envelope_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_models_envelope_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_models_envelope_proto_goTypes = []interface{}{
+	(WaitMode)(0),                 // 0: gitlab.ozon.dev.iTukaev.homework.api.models.WaitMode
+	(Error_Code)(0),               // 1: gitlab.ozon.dev.iTukaev.homework.api.models.Error.Code
+	(*Envelope)(nil),              // 2: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope
+	(*UserBatch)(nil),             // 3: gitlab.ozon.dev.iTukaev.homework.api.models.UserBatch
+	(*ProfileUpdate)(nil),         // 4: gitlab.ozon.dev.iTukaev.homework.api.models.ProfileUpdate
+	(*UserKey)(nil),               // 5: gitlab.ozon.dev.iTukaev.homework.api.models.UserKey
+	(*UserSort)(nil),              // 6: gitlab.ozon.dev.iTukaev.homework.api.models.UserSort
+	(*UserListFilter)(nil),        // 7: gitlab.ozon.dev.iTukaev.homework.api.models.UserListFilter
+	(*UserListParams)(nil),        // 8: gitlab.ozon.dev.iTukaev.homework.api.models.UserListParams
+	(*UserBatchResult)(nil),       // 9: gitlab.ozon.dev.iTukaev.homework.api.models.UserBatchResult
+	(*UserBatchResults)(nil),      // 10: gitlab.ozon.dev.iTukaev.homework.api.models.UserBatchResults
+	(*UserListPage)(nil),          // 11: gitlab.ozon.dev.iTukaev.homework.api.models.UserListPage
+	(*Error)(nil),                 // 12: gitlab.ozon.dev.iTukaev.homework.api.models.Error
+	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+	(*User)(nil),                  // 14: gitlab.ozon.dev.iTukaev.homework.api.models.User
+	(*Profile)(nil),               // 15: gitlab.ozon.dev.iTukaev.homework.api.models.Profile
+}
+var file_models_envelope_proto_depIdxs = []int32{
+	0,  // 0: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.wait:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.WaitMode
+	13, // 1: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.created_at:type_name -> google.protobuf.Timestamp
+	13, // 2: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.deadline:type_name -> google.protobuf.Timestamp
+	14, // 3: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.user:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.User
+	3,  // 4: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.batch:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.UserBatch
+	4,  // 5: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.profile:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.ProfileUpdate
+	5,  // 6: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.key:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.UserKey
+	8,  // 7: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.list_params:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.UserListParams
+	10, // 8: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.batch_results:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.UserBatchResults
+	11, // 9: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.page:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.UserListPage
+	12, // 10: gitlab.ozon.dev.iTukaev.homework.api.models.Envelope.error:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.Error
+	14, // 11: gitlab.ozon.dev.iTukaev.homework.api.models.UserBatch.users:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.User
+	15, // 12: gitlab.ozon.dev.iTukaev.homework.api.models.ProfileUpdate.profile:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.Profile
+	6,  // 13: gitlab.ozon.dev.iTukaev.homework.api.models.UserListParams.sort:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.UserSort
+	7,  // 14: gitlab.ozon.dev.iTukaev.homework.api.models.UserListParams.filter:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.UserListFilter
+	9,  // 15: gitlab.ozon.dev.iTukaev.homework.api.models.UserBatchResults.results:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.UserBatchResult
+	14, // 16: gitlab.ozon.dev.iTukaev.homework.api.models.UserListPage.users:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.User
+	1,  // 17: gitlab.ozon.dev.iTukaev.homework.api.models.Error.code:type_name -> gitlab.ozon.dev.iTukaev.homework.api.models.Error.Code
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_models_envelope_proto_init() }
+func file_models_envelope_proto_init() {
+	if File_models_envelope_proto != nil {
+		return
+	}
+	file_models_user_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_models_envelope_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Envelope); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProfileUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserSort); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserListFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserListParams); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserBatchResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserBatchResults); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UserListPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_models_envelope_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Error); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_models_envelope_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*Envelope_User)(nil),
+		(*Envelope_Batch)(nil),
+		(*Envelope_Profile)(nil),
+		(*Envelope_Key)(nil),
+		(*Envelope_ListParams)(nil),
+		(*Envelope_BatchResults)(nil),
+		(*Envelope_Page)(nil),
+		(*Envelope_Error)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_models_envelope_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_models_envelope_proto_goTypes,
+		DependencyIndexes: file_models_envelope_proto_depIdxs,
+		EnumInfos:         file_models_envelope_proto_enumTypes,
+		MessageInfos:      file_models_envelope_proto_msgTypes,
+	}.Build()
+	File_models_envelope_proto = out.File
+	file_models_envelope_proto_rawDesc = nil
+	file_models_envelope_proto_goTypes = nil
+	file_models_envelope_proto_depIdxs = nil
+}
@@ -0,0 +1,66 @@
+//go:generate mockgen -source=broker.go -destination=./mock/broker_mock.go -package=mock
+
+package broker
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var ErrClosed = errors.New("broker: closed")
+
+// Message is the message of the topic, the partition and the offset are set by the broker.
+type Message struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   []Header
+	Timestamp time.Time
+}
+
+// Header is the header of the message.
+type Header struct {
+	Key   []byte
+	Value []byte
+}
+
+// Publisher publishes the messages to the topics.
+type Publisher interface {
+	// Publish sets the partition and the offset of the published message.
+	Publish(ctx context.Context, msg *Message) error
+	Close() error
+}
+
+// Subscriber is the member of the consumer group.
+type Subscriber interface {
+	// Subscribe consumes the partitions of the topics claimed by the member until ctx is done
+	// or the group is rebalanced, so it is called in the loop.
+	Subscribe(ctx context.Context, topics []string, handler Handler) error
+	// Close leaves the group.
+	Close() error
+}
+
+// Handler handles the messages of the claimed partition until the claim is closed.
+type Handler interface {
+	ConsumeClaim(session Session, claim Claim) error
+}
+
+// Session is the generation of the group member, it is done on the rebalance.
+type Session interface {
+	Context() context.Context
+	// MarkMessage commits the offset of the message of the claimed partition,
+	// the partition is consumed after it by the next generation.
+	MarkMessage(msg *Message, metadata string)
+}
+
+// Claim is the partition claimed by the group member.
+type Claim interface {
+	Topic() string
+	Partition() int32
+	// Messages are closed when the session is done.
+	Messages() <-chan *Message
+}
@@ -0,0 +1,157 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+// NewPublisher wraps the producer into the publisher interface.
+func NewPublisher(producer sarama.SyncProducer) brokerPkg.Publisher {
+	return &publisher{
+		producer: producer,
+	}
+}
+
+type publisher struct {
+	producer sarama.SyncProducer
+}
+
+func (p *publisher) Publish(_ context.Context, msg *brokerPkg.Message) error {
+	partition, offset, err := p.producer.SendMessage(ProducerMessage(msg))
+	if err != nil {
+		return err
+	}
+	msg.Partition = partition
+	msg.Offset = offset
+	return nil
+}
+
+func (p *publisher) Close() error {
+	return p.producer.Close()
+}
+
+// NewSubscriber wraps the consumer group into the subscriber interface.
+func NewSubscriber(group sarama.ConsumerGroup) brokerPkg.Subscriber {
+	return &subscriber{
+		group: group,
+	}
+}
+
+type subscriber struct {
+	group sarama.ConsumerGroup
+}
+
+func (s *subscriber) Subscribe(ctx context.Context, topics []string, handler brokerPkg.Handler) error {
+	return s.group.Consume(ctx, topics, &groupHandler{handler: handler})
+}
+
+func (s *subscriber) Close() error {
+	return s.group.Close()
+}
+
+// groupHandler passes the claims of the sarama session to the handler.
+type groupHandler struct {
+	handler brokerPkg.Handler
+}
+
+func (h *groupHandler) Setup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *groupHandler) Cleanup(sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+// ConsumeClaim converts the messages of the claim until the handler returns.
+func (h *groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	messages := make(chan *brokerPkg.Message)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(messages)
+		for {
+			select {
+			case msg, ok := <-claim.Messages():
+				if !ok {
+					return
+				}
+				select {
+				case messages <- Message(msg):
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return h.handler.ConsumeClaim(&groupSession{session: session}, &groupClaim{claim: claim, messages: messages})
+}
+
+type groupSession struct {
+	session sarama.ConsumerGroupSession
+}
+
+func (s *groupSession) Context() context.Context {
+	return s.session.Context()
+}
+
+func (s *groupSession) MarkMessage(msg *brokerPkg.Message, metadata string) {
+	s.session.MarkOffset(msg.Topic, msg.Partition, msg.Offset+1, metadata)
+}
+
+type groupClaim struct {
+	claim    sarama.ConsumerGroupClaim
+	messages chan *brokerPkg.Message
+}
+
+func (c *groupClaim) Topic() string {
+	return c.claim.Topic()
+}
+
+func (c *groupClaim) Partition() int32 {
+	return c.claim.Partition()
+}
+
+func (c *groupClaim) Messages() <-chan *brokerPkg.Message {
+	return c.messages
+}
+
+// Message converts the consumed message.
+func Message(msg *sarama.ConsumerMessage) *brokerPkg.Message {
+	res := &brokerPkg.Message{
+		Topic:     msg.Topic,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   make([]brokerPkg.Header, 0, len(msg.Headers)),
+		Timestamp: msg.Timestamp,
+	}
+	for _, header := range msg.Headers {
+		res.Headers = append(res.Headers, brokerPkg.Header{Key: header.Key, Value: header.Value})
+	}
+	return res
+}
+
+// ProducerMessage converts the message to be sent by the producer.
+func ProducerMessage(msg *brokerPkg.Message) *sarama.ProducerMessage {
+	res := &sarama.ProducerMessage{
+		Topic:     msg.Topic,
+		Headers:   make([]sarama.RecordHeader, 0, len(msg.Headers)),
+		Timestamp: msg.Timestamp,
+	}
+	if msg.Key != nil {
+		res.Key = sarama.ByteEncoder(msg.Key)
+	}
+	if msg.Value != nil {
+		res.Value = sarama.ByteEncoder(msg.Value)
+	}
+	for _, header := range msg.Headers {
+		res.Headers = append(res.Headers, sarama.RecordHeader{Key: header.Key, Value: header.Value})
+	}
+	return res
+}
@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+func TestMessage(t *testing.T) {
+	now := time.Now()
+	msg := Message(&sarama.ConsumerMessage{
+		Topic:     "topic",
+		Partition: 2,
+		Offset:    7,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		Headers:   []*sarama.RecordHeader{{Key: []byte("uid"), Value: []byte("1")}},
+		Timestamp: now,
+	})
+	assert.Equal(t, &brokerPkg.Message{
+		Topic:     "topic",
+		Partition: 2,
+		Offset:    7,
+		Key:       []byte("key"),
+		Value:     []byte("value"),
+		Headers:   []brokerPkg.Header{{Key: []byte("uid"), Value: []byte("1")}},
+		Timestamp: now,
+	}, msg)
+}
+
+func TestProducerMessage(t *testing.T) {
+	cases := []struct {
+		name   string
+		msg    *brokerPkg.Message
+		expKey sarama.Encoder
+	}{
+		{
+			name: "with key",
+			msg: &brokerPkg.Message{
+				Topic:   "topic",
+				Key:     []byte("key"),
+				Value:   []byte("value"),
+				Headers: []brokerPkg.Header{{Key: []byte("uid"), Value: []byte("1")}},
+			},
+			expKey: sarama.ByteEncoder("key"),
+		},
+		{
+			name: "without key",
+			msg: &brokerPkg.Message{
+				Topic: "topic",
+				Value: []byte("value"),
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			msg := ProducerMessage(c.msg)
+			assert.Equal(t, c.msg.Topic, msg.Topic)
+			assert.Equal(t, c.expKey, msg.Key)
+			assert.Equal(t, sarama.ByteEncoder("value"), msg.Value)
+			require.Len(t, msg.Headers, len(c.msg.Headers))
+			for i, header := range c.msg.Headers {
+				assert.Equal(t, header.Key, msg.Headers[i].Key)
+				assert.Equal(t, header.Value, msg.Headers[i].Value)
+			}
+		})
+	}
+}
+
+func TestPublisher_Publish(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	publisher := NewPublisher(producer)
+
+	msg := &brokerPkg.Message{Topic: "topic", Value: []byte("value")}
+	require.NoError(t, publisher.Publish(context.Background(), msg))
+	assert.Equal(t, int64(1), msg.Offset)
+
+	assert.ErrorIs(t, publisher.Publish(context.Background(), &brokerPkg.Message{Topic: "topic"}), sarama.ErrOutOfBrokers)
+	assert.NoError(t, publisher.Close())
+}
@@ -0,0 +1,417 @@
+package memory
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+// Broker publishes the messages to the in-process topics and consumes them by the groups.
+type Broker interface {
+	brokerPkg.Publisher
+	// Subscriber returns the new member of the consumer group.
+	Subscriber(group string) brokerPkg.Subscriber
+}
+
+// New returns the in-process broker, the topics are created on the first use with the partitions
+// and their messages are partitioned by the keys. The groups consume the topics from the oldest offset.
+func New(partitions int32) Broker {
+	if partitions <= 0 {
+		partitions = 1
+	}
+	return &broker{
+		partitions: partitions,
+		topics:     make(map[string]*topic),
+		groups:     make(map[string]*group),
+	}
+}
+
+type broker struct {
+	mu         sync.Mutex
+	partitions int32
+	topics     map[string]*topic
+	groups     map[string]*group
+	closed     bool
+}
+
+type topic struct {
+	partitions []*partition
+	// next is the partition of the next message without the key
+	next int32
+}
+
+type partition struct {
+	messages []*brokerPkg.Message
+	// appended is closed and replaced when the message is appended
+	appended chan struct{}
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+type group struct {
+	members []*member
+	offsets map[topicPartition]int64
+	owners  map[topicPartition]*member
+	// changed is closed and replaced when the members are changed
+	changed chan struct{}
+	// released is closed and replaced when the partition is released by its owner
+	released chan struct{}
+}
+
+func (b *broker) Publish(_ context.Context, msg *brokerPkg.Message) error {
+	stored := *msg
+	stored.Headers = append([]brokerPkg.Header(nil), msg.Headers...)
+	if stored.Timestamp.IsZero() {
+		stored.Timestamp = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return brokerPkg.ErrClosed
+	}
+	t := b.topic(msg.Topic)
+	index := t.partition(msg.Key)
+	p := t.partitions[index]
+	offset := int64(len(p.messages))
+	stored.Partition = index
+	stored.Offset = offset
+	p.messages = append(p.messages, &stored)
+	close(p.appended)
+	p.appended = make(chan struct{})
+
+	msg.Partition = index
+	msg.Offset = offset
+	return nil
+}
+
+// Close ends the sessions of the groups, the messages are not published and consumed after it.
+func (b *broker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for _, g := range b.groups {
+		g.rebalance()
+	}
+	return nil
+}
+
+func (b *broker) Subscriber(name string) brokerPkg.Subscriber {
+	return &member{
+		broker: b,
+		name:   name,
+	}
+}
+
+// topic returns the topic creating it on the first use, it is called under the lock.
+func (b *broker) topic(name string) *topic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{partitions: make([]*partition, b.partitions)}
+		for i := range t.partitions {
+			t.partitions[i] = &partition{appended: make(chan struct{})}
+		}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// group returns the group creating it on the first use, it is called under the lock.
+func (b *broker) group(name string) *group {
+	g, ok := b.groups[name]
+	if !ok {
+		g = &group{
+			offsets:  make(map[topicPartition]int64),
+			owners:   make(map[topicPartition]*member),
+			changed:  make(chan struct{}),
+			released: make(chan struct{}),
+		}
+		b.groups[name] = g
+	}
+	return g
+}
+
+// partition returns the partition of the key, the messages without the key are spread round-robin.
+func (t *topic) partition(key []byte) int32 {
+	if key == nil {
+		index := t.next
+		t.next = (t.next + 1) % int32(len(t.partitions))
+		return index
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write(key)
+	return int32(hash.Sum32() % uint32(len(t.partitions)))
+}
+
+// rebalance ends the sessions of the members.
+func (g *group) rebalance() {
+	close(g.changed)
+	g.changed = make(chan struct{})
+}
+
+// assignment returns the partitions of the member, the partitions of every topic are spread
+// over the members subscribed to it in the order they joined.
+func (b *broker) assignment(g *group, m *member) []topicPartition {
+	var res []topicPartition
+	for _, name := range m.topics {
+		index, subscribed := 0, 0
+		for _, other := range g.members {
+			if other == m {
+				index = subscribed
+			}
+			if other.subscribed(name) {
+				subscribed++
+			}
+		}
+		t := b.topic(name)
+		for i := index; i < len(t.partitions); i += subscribed {
+			res = append(res, topicPartition{topic: name, partition: int32(i)})
+		}
+	}
+	return res
+}
+
+type member struct {
+	broker *broker
+	name   string
+	topics []string
+	joined bool
+	closed bool
+}
+
+func (m *member) subscribed(name string) bool {
+	for _, topic := range m.topics {
+		if topic == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Subscribe joins the group on the first call or when the topics are changed, which rebalances the group.
+func (m *member) Subscribe(ctx context.Context, topics []string, handler brokerPkg.Handler) error {
+	b := m.broker
+	b.mu.Lock()
+	if b.closed || m.closed {
+		b.mu.Unlock()
+		return brokerPkg.ErrClosed
+	}
+	g := b.group(m.name)
+	if !m.joined || !equal(m.topics, topics) {
+		m.topics = append([]string(nil), topics...)
+		if !m.joined {
+			g.members = append(g.members, m)
+			m.joined = true
+		}
+		g.rebalance()
+	}
+	claims := b.assignment(g, m)
+	changed := g.changed
+	b.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-changed:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	s := &session{ctx: ctx, member: m}
+	var (
+		wg      sync.WaitGroup
+		once    sync.Once
+		failure error
+	)
+	for _, tp := range claims {
+		wg.Add(1)
+		go func(tp topicPartition) {
+			defer wg.Done()
+			if err := m.consume(s, tp, handler); err != nil {
+				once.Do(func() {
+					failure = err
+					cancel()
+				})
+			}
+		}(tp)
+	}
+	// the member without the partitions waits for the rebalance as well
+	<-ctx.Done()
+	wg.Wait()
+	return failure
+}
+
+// Close leaves the group, which rebalances it.
+func (m *member) Close() error {
+	b := m.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	m.closed = true
+	if !m.joined {
+		return nil
+	}
+	g := b.group(m.name)
+	for i, other := range g.members {
+		if other == m {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			break
+		}
+	}
+	m.joined = false
+	g.rebalance()
+	return nil
+}
+
+// consume passes the messages of the partition to the handler after its previous owner releases it.
+func (m *member) consume(s *session, tp topicPartition, handler brokerPkg.Handler) error {
+	offset, ok := m.acquire(s.ctx, tp)
+	if !ok {
+		return nil
+	}
+	defer m.release(tp)
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	messages := make(chan *brokerPkg.Message)
+	fed := make(chan struct{})
+	go func() {
+		defer close(fed)
+		defer close(messages)
+		m.broker.feed(ctx, tp, offset, messages)
+	}()
+
+	err := handler.ConsumeClaim(s, &claim{
+		topic:     tp.topic,
+		partition: tp.partition,
+		messages:  messages,
+	})
+	cancel()
+	<-fed
+	return err
+}
+
+// acquire returns the committed offset of the partition when it is claimed, false if ctx is done.
+func (m *member) acquire(ctx context.Context, tp topicPartition) (int64, bool) {
+	b := m.broker
+	b.mu.Lock()
+	for {
+		g := b.group(m.name)
+		if _, ok := g.owners[tp]; !ok {
+			g.owners[tp] = m
+			offset := g.offsets[tp]
+			b.mu.Unlock()
+			return offset, true
+		}
+		released := g.released
+		b.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return 0, false
+		}
+		b.mu.Lock()
+	}
+}
+
+func (m *member) release(tp topicPartition) {
+	b := m.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.group(m.name)
+	delete(g.owners, tp)
+	close(g.released)
+	g.released = make(chan struct{})
+}
+
+// feed sends the messages of the partition from the offset until ctx is done.
+func (b *broker) feed(ctx context.Context, tp topicPartition, offset int64, messages chan<- *brokerPkg.Message) {
+	for {
+		b.mu.Lock()
+		p := b.topic(tp.topic).partitions[tp.partition]
+		if offset < int64(len(p.messages)) {
+			msg := *p.messages[offset]
+			b.mu.Unlock()
+			msg.Headers = append([]brokerPkg.Header(nil), msg.Headers...)
+
+			select {
+			case messages <- &msg:
+				offset++
+			case <-ctx.Done():
+				return
+			}
+			continue
+		}
+		appended := p.appended
+		b.mu.Unlock()
+
+		select {
+		case <-appended:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type session struct {
+	ctx    context.Context
+	member *member
+}
+
+func (s *session) Context() context.Context {
+	return s.ctx
+}
+
+// MarkMessage commits the offset after the message, the committed offset is not moved back.
+func (s *session) MarkMessage(msg *brokerPkg.Message, _ string) {
+	b := s.member.broker
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.group(s.member.name)
+	tp := topicPartition{topic: msg.Topic, partition: msg.Partition}
+	if msg.Offset+1 > g.offsets[tp] {
+		g.offsets[tp] = msg.Offset + 1
+	}
+}
+
+type claim struct {
+	topic     string
+	partition int32
+	messages  chan *brokerPkg.Message
+}
+
+func (c *claim) Topic() string {
+	return c.topic
+}
+
+func (c *claim) Partition() int32 {
+	return c.partition
+}
+
+func (c *claim) Messages() <-chan *brokerPkg.Message {
+	return c.messages
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
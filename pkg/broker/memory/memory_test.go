@@ -0,0 +1,214 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+const timeout = time.Second
+
+// collector sends the consumed messages and marks them if mark is set.
+type collector struct {
+	messages chan *brokerPkg.Message
+	mark     bool
+}
+
+func newCollector(mark bool) *collector {
+	return &collector{
+		messages: make(chan *brokerPkg.Message, 100),
+		mark:     mark,
+	}
+}
+
+func (c *collector) ConsumeClaim(session brokerPkg.Session, claim brokerPkg.Claim) error {
+	for msg := range claim.Messages() {
+		c.messages <- msg
+		if c.mark {
+			session.MarkMessage(msg, "")
+		}
+	}
+	return nil
+}
+
+func (c *collector) next(t *testing.T) *brokerPkg.Message {
+	select {
+	case msg := <-c.messages:
+		return msg
+	case <-time.After(timeout):
+		t.Fatal("no message consumed")
+		return nil
+	}
+}
+
+func (c *collector) none(t *testing.T) {
+	select {
+	case msg := <-c.messages:
+		t.Fatalf("unexpected message [%s/%d/%d]", msg.Topic, msg.Partition, msg.Offset)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// subscribe consumes the topic until the returned function is called.
+func subscribe(subscriber brokerPkg.Subscriber, topic string, handler brokerPkg.Handler) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			_ = subscriber.Subscribe(ctx, []string{topic}, handler)
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func publish(t *testing.T, broker Broker, topic, key, value string) *brokerPkg.Message {
+	msg := &brokerPkg.Message{
+		Topic:   topic,
+		Key:     []byte(key),
+		Value:   []byte(value),
+		Headers: []brokerPkg.Header{{Key: []byte("uid"), Value: []byte(value)}},
+	}
+	require.NoError(t, broker.Publish(context.Background(), msg))
+	return msg
+}
+
+func Test_PublishSubscribe(t *testing.T) {
+	broker := New(4)
+	defer broker.Close()
+
+	first := publish(t, broker, "topic", "Ivan", "1")
+	second := publish(t, broker, "topic", "Ivan", "2")
+	assert.Equal(t, first.Partition, second.Partition)
+	assert.Equal(t, first.Offset+1, second.Offset)
+
+	handler := newCollector(true)
+	stop := subscribe(broker.Subscriber("group"), "topic", handler)
+	defer stop()
+
+	for _, exp := range []string{"1", "2"} {
+		msg := handler.next(t)
+		assert.Equal(t, "topic", msg.Topic)
+		assert.Equal(t, first.Partition, msg.Partition)
+		assert.Equal(t, []byte("Ivan"), msg.Key)
+		assert.Equal(t, []byte(exp), msg.Value)
+		require.Len(t, msg.Headers, 1)
+		assert.Equal(t, []byte(exp), msg.Headers[0].Value)
+	}
+
+	publish(t, broker, "topic", "Ivan", "3")
+	assert.Equal(t, []byte("3"), handler.next(t).Value)
+}
+
+func Test_CommittedOffset(t *testing.T) {
+	testCases := []struct {
+		name string
+		mark bool
+		exp  []string
+	}{
+		{
+			name: "marked, consumed after them",
+			mark: true,
+			exp:  []string{"3"},
+		},
+		{
+			name: "not marked, consumed again",
+			exp:  []string{"1", "2", "3"},
+		},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			broker := New(1)
+			defer broker.Close()
+
+			publish(t, broker, "topic", "Ivan", "1")
+			publish(t, broker, "topic", "Ivan", "2")
+
+			handler := newCollector(c.mark)
+			subscriber := broker.Subscriber("group")
+			stop := subscribe(subscriber, "topic", handler)
+			handler.next(t)
+			handler.next(t)
+			stop()
+			require.NoError(t, subscriber.Close())
+
+			publish(t, broker, "topic", "Ivan", "3")
+
+			handler = newCollector(c.mark)
+			stop = subscribe(broker.Subscriber("group"), "topic", handler)
+			defer stop()
+			for _, exp := range c.exp {
+				assert.Equal(t, []byte(exp), handler.next(t).Value)
+			}
+			handler.none(t)
+		})
+	}
+}
+
+func Test_Groups(t *testing.T) {
+	broker := New(2)
+	defer broker.Close()
+
+	// the groups consume all the messages, the members of the group share them
+	other := newCollector(true)
+	stop := subscribe(broker.Subscriber("other"), "topic", other)
+	defer stop()
+
+	first, second := newCollector(true), newCollector(true)
+	stop = subscribe(broker.Subscriber("group"), "topic", first)
+	defer stop()
+	stop = subscribe(broker.Subscriber("group"), "topic", second)
+	defer stop()
+	// the partitions are moved while the members join
+	time.Sleep(50 * time.Millisecond)
+
+	keys := []string{"a", "b", "c", "d", "e", "f"}
+	partitions := make(map[string]int32, len(keys))
+	for _, key := range keys {
+		partitions[key] = publish(t, broker, "topic", key, key).Partition
+	}
+
+	consumed := make(map[string]int32, len(keys))
+	for range keys {
+		other.next(t)
+		select {
+		case msg := <-first.messages:
+			consumed[string(msg.Key)] = 1
+		case msg := <-second.messages:
+			consumed[string(msg.Key)] = 2
+		case <-time.After(timeout):
+			t.Fatal("no message consumed")
+		}
+	}
+	other.none(t)
+
+	// the partition is consumed by one member
+	members := make(map[int32]int32)
+	for key, member := range consumed {
+		if prev, ok := members[partitions[key]]; ok {
+			assert.Equal(t, prev, member)
+		}
+		members[partitions[key]] = member
+	}
+	assert.Len(t, consumed, len(keys))
+}
+
+func Test_Closed(t *testing.T) {
+	broker := New(1)
+	require.NoError(t, broker.Close())
+
+	err := broker.Publish(context.Background(), &brokerPkg.Message{Topic: "topic"})
+	assert.ErrorIs(t, err, brokerPkg.ErrClosed)
+
+	err = broker.Subscriber("group").Subscribe(context.Background(), []string{"topic"}, newCollector(true))
+	assert.ErrorIs(t, err, brokerPkg.ErrClosed)
+}
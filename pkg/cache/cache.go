@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	Redis  = "redis"
+	Memory = "memory"
+)
+
+var (
+	ErrNotFound = errors.New("cache: key not found")
+	ErrConflict = errors.New("cache: value changed concurrently")
+)
+
+// KeepTTL keeps the current expiration time of the key.
+const KeepTTL time.Duration = -1
+
+type Message struct {
+	Channel string
+	Payload []byte
+}
+
+// Subscription delivers published messages until it is closed.
+type Subscription interface {
+	Messages() <-chan Message
+	Close() error
+}
+
+// Interface is a key-value cache with sets and pub/sub.
+// Zero expiration means the key does not expire.
+type Interface interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	// Swap replaces the value only if it is equal to old, the expiration time is kept.
+	Swap(ctx context.Context, key string, old, value []byte) error
+	Del(ctx context.Context, keys ...string) error
+	Exists(ctx context.Context, key string) (bool, error)
+	Incr(ctx context.Context, key string) (int64, error)
+	Expire(ctx context.Context, key string, expiration time.Duration) error
+	SAdd(ctx context.Context, key string, members ...string) error
+	SRem(ctx context.Context, key string, members ...string) error
+	SMembers(ctx context.Context, key string) ([]string, error)
+	Publish(ctx context.Context, channel string, message []byte) error
+	Subscribe(ctx context.Context, channels ...string) (Subscription, error)
+	Close() error
+}
@@ -0,0 +1,28 @@
+package connect
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+	memoryPkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache/memory"
+	redisPkg "gitlab.ozon.dev/iTukaev/homework/pkg/redis"
+)
+
+// New returns the cache of the kind, redis if it is empty.
+// The memory cache is not shared between the processes.
+func New(ctx context.Context, kind string, cfg redisPkg.Config) (cachePkg.Interface, error) {
+	switch kind {
+	case cachePkg.Memory:
+		return memoryPkg.New(), nil
+	case "", cachePkg.Redis:
+		client, err := redisPkg.New(ctx, cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "new redis client")
+		}
+		return redisPkg.NewCache(client), nil
+	default:
+		return nil, errors.Errorf("unknown cache [%s]", kind)
+	}
+}
@@ -0,0 +1,323 @@
+package memory
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+)
+
+const (
+	cleanupInterval = time.Minute
+	subscriberQueue = 100
+)
+
+var errWrongType = errors.New("cache: operation against a key holding the wrong kind of value")
+
+// New returns the in-process cache, expired keys are evicted on access and by the periodic cleanup.
+// Messages are delivered to the subscribers of the same cache only.
+func New() cachePkg.Interface {
+	c := &memory{
+		items:       make(map[string]*item),
+		subscribers: make(map[string]map[*subscription]struct{}),
+		stop:        make(chan struct{}),
+	}
+	go c.cleanup()
+	return c
+}
+
+type item struct {
+	value     []byte
+	set       map[string]struct{}
+	expiresAt time.Time
+}
+
+func (i *item) expired(now time.Time) bool {
+	return !i.expiresAt.IsZero() && !now.Before(i.expiresAt)
+}
+
+type memory struct {
+	mu          sync.Mutex
+	items       map[string]*item
+	subscribers map[string]map[*subscription]struct{}
+	stop        chan struct{}
+	once        sync.Once
+}
+
+func (c *memory) Get(_ context.Context, key string) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it, err := c.value(key)
+	if err != nil {
+		return nil, err
+	}
+	return copyBytes(it.value), nil
+}
+
+func (c *memory) Set(_ context.Context, key string, value []byte, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it := &item{value: copyBytes(value)}
+	if expiration == cachePkg.KeepTTL {
+		if old := c.item(key); old != nil {
+			it.expiresAt = old.expiresAt
+		}
+	} else if expiration > 0 {
+		it.expiresAt = time.Now().Add(expiration)
+	}
+	c.items[key] = it
+	return nil
+}
+
+func (c *memory) Swap(_ context.Context, key string, old, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it, err := c.value(key)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(it.value, old) {
+		return cachePkg.ErrConflict
+	}
+	it.value = copyBytes(value)
+	return nil
+}
+
+func (c *memory) Del(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.items, key)
+	}
+	return nil
+}
+
+func (c *memory) Exists(_ context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.item(key) != nil, nil
+}
+
+func (c *memory) Incr(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it := c.item(key)
+	if it == nil {
+		it = &item{value: []byte("0")}
+		c.items[key] = it
+	}
+	if it.set != nil {
+		return 0, errWrongType
+	}
+	n, err := strconv.ParseInt(string(it.value), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "value is not an integer")
+	}
+	n++
+	it.value = []byte(strconv.FormatInt(n, 10))
+	return n, nil
+}
+
+func (c *memory) Expire(_ context.Context, key string, expiration time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it := c.item(key)
+	if it == nil {
+		return nil
+	}
+	if expiration <= 0 {
+		delete(c.items, key)
+		return nil
+	}
+	it.expiresAt = time.Now().Add(expiration)
+	return nil
+}
+
+func (c *memory) SAdd(_ context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it := c.item(key)
+	if it == nil {
+		it = &item{set: make(map[string]struct{}, len(members))}
+		c.items[key] = it
+	}
+	if it.set == nil {
+		return errWrongType
+	}
+	for _, member := range members {
+		it.set[member] = struct{}{}
+	}
+	return nil
+}
+
+func (c *memory) SRem(_ context.Context, key string, members ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it := c.item(key)
+	if it == nil {
+		return nil
+	}
+	if it.set == nil {
+		return errWrongType
+	}
+	for _, member := range members {
+		delete(it.set, member)
+	}
+	// like redis, empty sets do not exist
+	if len(it.set) == 0 {
+		delete(c.items, key)
+	}
+	return nil
+}
+
+func (c *memory) SMembers(_ context.Context, key string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	it := c.item(key)
+	if it == nil {
+		return []string{}, nil
+	}
+	if it.set == nil {
+		return nil, errWrongType
+	}
+	members := make([]string, 0, len(it.set))
+	for member := range it.set {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+// Publish does not wait for slow subscribers, the message is dropped if the subscriber queue is full.
+func (c *memory) Publish(_ context.Context, channel string, message []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for sub := range c.subscribers[channel] {
+		select {
+		case sub.messages <- cachePkg.Message{Channel: channel, Payload: copyBytes(message)}:
+		default:
+		}
+	}
+	return nil
+}
+
+func (c *memory) Subscribe(_ context.Context, channels ...string) (cachePkg.Subscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	sub := &subscription{
+		cache:    c,
+		channels: channels,
+		messages: make(chan cachePkg.Message, subscriberQueue),
+	}
+	for _, channel := range channels {
+		if c.subscribers[channel] == nil {
+			c.subscribers[channel] = make(map[*subscription]struct{})
+		}
+		c.subscribers[channel][sub] = struct{}{}
+	}
+	return sub, nil
+}
+
+func (c *memory) Close() error {
+	c.once.Do(func() {
+		close(c.stop)
+	})
+	return nil
+}
+
+// item returns not expired item, expired one is removed.
+func (c *memory) item(key string) *item {
+	it, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	if it.expired(time.Now()) {
+		delete(c.items, key)
+		return nil
+	}
+	return it
+}
+
+func (c *memory) value(key string) (*item, error) {
+	it := c.item(key)
+	if it == nil {
+		return nil, cachePkg.ErrNotFound
+	}
+	if it.set != nil {
+		return nil, errWrongType
+	}
+	return it, nil
+}
+
+func (c *memory) cleanup() {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stop:
+			return
+		case now := <-ticker.C:
+			c.mu.Lock()
+			for key, it := range c.items {
+				if it.expired(now) {
+					delete(c.items, key)
+				}
+			}
+			c.mu.Unlock()
+		}
+	}
+}
+
+func (c *memory) unsubscribe(sub *subscription) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, channel := range sub.channels {
+		delete(c.subscribers[channel], sub)
+		if len(c.subscribers[channel]) == 0 {
+			delete(c.subscribers, channel)
+		}
+	}
+	close(sub.messages)
+}
+
+type subscription struct {
+	cache    *memory
+	channels []string
+	messages chan cachePkg.Message
+	once     sync.Once
+}
+
+func (s *subscription) Messages() <-chan cachePkg.Message {
+	return s.messages
+}
+
+func (s *subscription) Close() error {
+	s.once.Do(func() {
+		s.cache.unsubscribe(s)
+	})
+	return nil
+}
+
+func copyBytes(data []byte) []byte {
+	res := make([]byte, len(data))
+	copy(res, data)
+	return res
+}
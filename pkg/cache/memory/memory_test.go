@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+)
+
+func Test_GetSet(t *testing.T) {
+	ctx := context.Background()
+	cache := New()
+	defer cache.Close()
+
+	_, err := cache.Get(ctx, "key")
+	assert.ErrorIs(t, err, cachePkg.ErrNotFound)
+
+	require.NoError(t, cache.Set(ctx, "key", []byte("value"), 0))
+	data, err := cache.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), data)
+
+	require.NoError(t, cache.Set(ctx, "short", []byte("value"), time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	_, err = cache.Get(ctx, "short")
+	assert.ErrorIs(t, err, cachePkg.ErrNotFound)
+
+	require.NoError(t, cache.Del(ctx, "key"))
+	exists, err := cache.Exists(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func Test_Swap(t *testing.T) {
+	ctx := context.Background()
+
+	testCases := []struct {
+		name   string
+		old    []byte
+		expErr error
+		exp    []byte
+	}{
+		{
+			name: "success",
+			old:  []byte("value"),
+			exp:  []byte("new"),
+		},
+		{
+			name:   "changed",
+			old:    []byte("other"),
+			expErr: cachePkg.ErrConflict,
+			exp:    []byte("value"),
+		},
+	}
+
+	for _, c := range testCases {
+		t.Run(c.name, func(t *testing.T) {
+			cache := New()
+			defer cache.Close()
+			require.NoError(t, cache.Set(ctx, "key", []byte("value"), time.Minute))
+
+			err := cache.Swap(ctx, "key", c.old, []byte("new"))
+			assert.ErrorIs(t, err, c.expErr)
+
+			data, err := cache.Get(ctx, "key")
+			require.NoError(t, err)
+			assert.Equal(t, c.exp, data)
+		})
+	}
+}
+
+func Test_IncrSets(t *testing.T) {
+	ctx := context.Background()
+	cache := New()
+	defer cache.Close()
+
+	n, err := cache.Incr(ctx, "counter")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	n, err = cache.Incr(ctx, "counter")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	require.NoError(t, cache.SAdd(ctx, "set", "a", "b"))
+	require.NoError(t, cache.SRem(ctx, "set", "a"))
+	members, err := cache.SMembers(ctx, "set")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, members)
+
+	_, err = cache.Incr(ctx, "set")
+	assert.Error(t, err)
+}
+
+func Test_PubSub(t *testing.T) {
+	ctx := context.Background()
+	cache := New()
+	defer cache.Close()
+
+	sub, err := cache.Subscribe(ctx, "channel")
+	require.NoError(t, err)
+
+	require.NoError(t, cache.Publish(ctx, "channel", []byte("message")))
+	require.NoError(t, cache.Publish(ctx, "other", []byte("message")))
+
+	msg := <-sub.Messages()
+	assert.Equal(t, cachePkg.Message{Channel: "channel", Payload: []byte("message")}, msg)
+
+	require.NoError(t, sub.Close())
+	_, ok := <-sub.Messages()
+	assert.False(t, ok)
+}
@@ -92,6 +92,14 @@ func genChain(allStructs []*StructInfo, imports map[string]string, packageName s
 				case *ast.Ident:
 					fType = fmt.Sprintf("*%s", val)
 				}
+			case *ast.ArrayType:
+				switch val := val.Elt.(type) {
+				case *ast.SelectorExpr:
+					fType = fmt.Sprintf("[]%s.%s", val.X, val.Sel)
+					toImport[fmt.Sprintf("%s", val.X)] = true
+				case *ast.Ident:
+					fType = fmt.Sprintf("[]%s", val)
+				}
 			default:
 				fType = fmt.Sprintf("%s", val)
 			}
@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	ifMatchKey = "if-match"
+	eTagKey    = "etag"
+
+	weakPrefix = "W/"
+	anyETag    = "*"
+)
+
+var ErrInvalidETag = errors.New("invalid If-Match: strong entity tag of the user version expected")
+
+// FormatETag returns the strong entity tag of the user version.
+func FormatETag(version uint64) string {
+	return strconv.Quote(strconv.FormatUint(version, 10))
+}
+
+// ParseETag returns the user version of the strong entity tag, the weak tags are rejected
+// since If-Match uses the strong comparison.
+func ParseETag(tag string) (uint64, bool) {
+	tag = strings.TrimSpace(tag)
+	if strings.HasPrefix(tag, weakPrefix) {
+		return 0, false
+	}
+	if len(tag) >= 2 && strings.HasPrefix(tag, `"`) && strings.HasSuffix(tag, `"`) {
+		tag = tag[1 : len(tag)-1]
+	}
+	version, err := strconv.ParseUint(tag, 10, 64)
+	if err != nil || version == 0 {
+		return 0, false
+	}
+	return version, true
+}
+
+// GetIfMatchFromContext returns the user version of the "if-match" metadata, 0 if it is absent or "*".
+// It returns ErrInvalidETag if the header is not the strong entity tag of the user version.
+func GetIfMatchFromContext(ctx context.Context) (uint64, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, nil
+	}
+	data := md.Get(ifMatchKey)
+	if len(data) == 0 {
+		return 0, nil
+	}
+	if len(data) > 1 {
+		return 0, errors.Wrap(ErrInvalidETag, "one entity tag expected")
+	}
+	if strings.TrimSpace(data[0]) == anyETag {
+		return 0, nil
+	}
+	version, ok := ParseETag(data[0])
+	if !ok {
+		return 0, errors.Wrapf(ErrInvalidETag, "[%s]", data[0])
+	}
+	return version, nil
+}
+
+// SetETag sends the user version in the "etag" response header.
+func SetETag(ctx context.Context, version uint64) error {
+	return grpc.SetHeader(ctx, metadata.Pairs(eTagKey, FormatETag(version)))
+}
+
+// IncomingHeaderMatcher passes If-Match header to gRPC metadata in addition to the default headers.
+func IncomingHeaderMatcher(key string) (string, bool) {
+	if http.CanonicalHeaderKey(key) == "If-Match" {
+		return ifMatchKey, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// OutgoingHeaderMatcher passes "etag" metadata to ETag response header.
+func OutgoingHeaderMatcher(key string) (string, bool) {
+	if key == eTagKey {
+		return "ETag", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
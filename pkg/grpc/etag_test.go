@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGetIfMatchFromContext(t *testing.T) {
+	cases := []struct {
+		name   string
+		md     metadata.MD
+		exp    uint64
+		expErr error
+	}{
+		{
+			name: "no header",
+			md:   metadata.MD{},
+		},
+		{
+			name: "strong tag",
+			md:   metadata.Pairs(ifMatchKey, `"3"`),
+			exp:  3,
+		},
+		{
+			name: "version without quotes",
+			md:   metadata.Pairs(ifMatchKey, "3"),
+			exp:  3,
+		},
+		{
+			name: "any version",
+			md:   metadata.Pairs(ifMatchKey, "*"),
+		},
+		{
+			name:   "weak tag",
+			md:     metadata.Pairs(ifMatchKey, `W/"3"`),
+			expErr: ErrInvalidETag,
+		},
+		{
+			name:   "malformed tag",
+			md:     metadata.Pairs(ifMatchKey, `"v3"`),
+			expErr: ErrInvalidETag,
+		},
+		{
+			name:   "zero version",
+			md:     metadata.Pairs(ifMatchKey, `"0"`),
+			expErr: ErrInvalidETag,
+		},
+		{
+			name:   "several tags",
+			md:     metadata.Pairs(ifMatchKey, `"3"`, ifMatchKey, `"4"`),
+			expErr: ErrInvalidETag,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			version, err := GetIfMatchFromContext(metadata.NewIncomingContext(context.Background(), c.md))
+			assert.ErrorIs(t, err, c.expErr)
+			assert.Equal(t, c.exp, version)
+		})
+	}
+}
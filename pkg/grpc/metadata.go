@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/metadata"
@@ -10,6 +11,9 @@ import (
 
 const (
 	undefinedMeta = "undefined"
+
+	authorizationKey = "authorization"
+	bearerPrefix     = "Bearer "
 )
 
 func GetMetaFromContext(ctx context.Context) string {
@@ -27,3 +31,28 @@ func GetMetaFromContext(ctx context.Context) string {
 
 	return meta
 }
+
+// GetBearerFromContext returns the token of the "authorization: Bearer <token>" metadata.
+func GetBearerFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	data := md.Get(authorizationKey)
+	if len(data) == 0 || !strings.HasPrefix(data[0], bearerPrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(data[0], bearerPrefix)
+}
+
+// ForwardAuthorization copies incoming authorization metadata to the outgoing context.
+func ForwardAuthorization(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	if data := md.Get(authorizationKey); len(data) > 0 {
+		return metadata.AppendToOutgoingContext(ctx, authorizationKey, data[0])
+	}
+	return ctx
+}
@@ -3,12 +3,13 @@ package helper
 import (
 	"context"
 
-	"github.com/Shopify/sarama"
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
 )
 
 const (
-	uidKey = "uid"
-	pubKey = "pub"
+	uidKey       = "uid"
+	pubKey       = "pub"
+	operationKey = "operation"
 )
 
 func InjectUidPubToCtx(ctx context.Context, uid, pub string) context.Context {
@@ -23,7 +24,7 @@ func ExtractUidPubFromCtx(ctx context.Context) (uid, pub string) {
 	return uid, pub
 }
 
-func ExtractUidPubFromMessage(msg *sarama.ConsumerMessage) (uid, pub string) {
+func ExtractUidPubFromMessage(msg *brokerPkg.Message) (uid, pub string) {
 	for _, header := range msg.Headers {
 		switch string(header.Key) {
 		case uidKey:
@@ -34,3 +35,28 @@ func ExtractUidPubFromMessage(msg *sarama.ConsumerMessage) (uid, pub string) {
 	}
 	return uid, pub
 }
+
+// NewMessage returns the message of the operation keyed by the user name,
+// so the messages of one user are in one partition and keep their order.
+func NewMessage(topic, operation, name string, value []byte) *brokerPkg.Message {
+	return &brokerPkg.Message{
+		Topic: topic,
+		Key:   []byte(name),
+		Value: value,
+		Headers: []brokerPkg.Header{{
+			Key:   []byte(operationKey),
+			Value: []byte(operation),
+		}},
+	}
+}
+
+// ExtractOperationFromMessage returns the operation of the message,
+// the messages produced before the operation header are keyed by the operation.
+func ExtractOperationFromMessage(msg *brokerPkg.Message) string {
+	for _, header := range msg.Headers {
+		if string(header.Key) == operationKey {
+			return string(header.Value)
+		}
+	}
+	return string(msg.Key)
+}
@@ -0,0 +1,37 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
+)
+
+func TestExtractOperationFromMessage(t *testing.T) {
+	produced := NewMessage("topic", "update", "Ivan", nil)
+	assert.Equal(t, "Ivan", string(produced.Key))
+
+	cases := []struct {
+		name string
+		msg  *brokerPkg.Message
+		exp  string
+	}{
+		{
+			name: "operation header",
+			msg:  produced,
+			exp:  "update",
+		},
+		{
+			name: "keyed by operation before the header",
+			msg:  &brokerPkg.Message{Key: []byte("create")},
+			exp:  "create",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.exp, ExtractOperationFromMessage(c.msg))
+		})
+	}
+}
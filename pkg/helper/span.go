@@ -3,9 +3,10 @@ package helper
 import (
 	"context"
 
-	"github.com/Shopify/sarama"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pkg/errors"
+
+	brokerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/broker"
 )
 
 func StartNewSpan(ctx context.Context, name string, stop chan struct{}) {
@@ -21,7 +22,7 @@ func StartNewSpan(ctx context.Context, name string, stop chan struct{}) {
 	<-stop
 }
 
-func InjectHeaders(ctx context.Context, msg *sarama.ProducerMessage) error {
+func InjectHeaders(ctx context.Context, msg *brokerPkg.Message) error {
 	span := opentracing.SpanFromContext(ctx)
 	uid, pub := ExtractUidPubFromCtx(ctx)
 	headers := map[string]string{
@@ -38,15 +39,15 @@ func InjectHeaders(ctx context.Context, msg *sarama.ProducerMessage) error {
 	}
 
 	for key, value := range headers {
-		msg.Headers = append(msg.Headers, sarama.RecordHeader{
-			Key:   sarama.ByteEncoder(key),
-			Value: sarama.ByteEncoder(value),
+		msg.Headers = append(msg.Headers, brokerPkg.Header{
+			Key:   []byte(key),
+			Value: []byte(value),
 		})
 	}
 	return nil
 }
 
-func GetSpanFromMessage(msg *sarama.ConsumerMessage, operationName string) opentracing.Span {
+func GetSpanFromMessage(msg *brokerPkg.Message, operationName string) opentracing.Span {
 	headers := make(map[string]string)
 	for _, header := range msg.Headers {
 		headers[string(header.Key)] = string(header.Value)
@@ -37,6 +37,86 @@ func (m *MockUserClient) EXPECT() *MockUserClientMockRecorder {
 	return m.recorder
 }
 
+// Data mocks base method.
+func (m *MockUserClient) Data(ctx context.Context, in *api.DataRequest, opts ...grpc.CallOption) (*api.DataResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Data", varargs...)
+	ret0, _ := ret[0].(*api.DataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Data indicates an expected call of Data.
+func (mr *MockUserClientMockRecorder) Data(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Data", reflect.TypeOf((*MockUserClient)(nil).Data), varargs...)
+}
+
+// SessionList mocks base method.
+func (m *MockUserClient) SessionList(ctx context.Context, in *api.SessionListRequest, opts ...grpc.CallOption) (*api.SessionListResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SessionList", varargs...)
+	ret0, _ := ret[0].(*api.SessionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SessionList indicates an expected call of SessionList.
+func (mr *MockUserClientMockRecorder) SessionList(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionList", reflect.TypeOf((*MockUserClient)(nil).SessionList), varargs...)
+}
+
+// SessionRevoke mocks base method.
+func (m *MockUserClient) SessionRevoke(ctx context.Context, in *api.SessionRevokeRequest, opts ...grpc.CallOption) (*api.SessionRevokeResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SessionRevoke", varargs...)
+	ret0, _ := ret[0].(*api.SessionRevokeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SessionRevoke indicates an expected call of SessionRevoke.
+func (mr *MockUserClientMockRecorder) SessionRevoke(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionRevoke", reflect.TypeOf((*MockUserClient)(nil).SessionRevoke), varargs...)
+}
+
+// TokenRefresh mocks base method.
+func (m *MockUserClient) TokenRefresh(ctx context.Context, in *api.TokenRefreshRequest, opts ...grpc.CallOption) (*api.TokenRefreshResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TokenRefresh", varargs...)
+	ret0, _ := ret[0].(*api.TokenRefreshResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokenRefresh indicates an expected call of TokenRefresh.
+func (mr *MockUserClientMockRecorder) TokenRefresh(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenRefresh", reflect.TypeOf((*MockUserClient)(nil).TokenRefresh), varargs...)
+}
+
 // UserAllList mocks base method.
 func (m *MockUserClient) UserAllList(ctx context.Context, in *api.UserAllListRequest, opts ...grpc.CallOption) (api.User_UserAllListClient, error) {
 	m.ctrl.T.Helper()
@@ -57,6 +137,26 @@ func (mr *MockUserClientMockRecorder) UserAllList(ctx, in interface{}, opts ...i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserAllList", reflect.TypeOf((*MockUserClient)(nil).UserAllList), varargs...)
 }
 
+// UserBatchCreate mocks base method.
+func (m *MockUserClient) UserBatchCreate(ctx context.Context, in *api.UserBatchCreateRequest, opts ...grpc.CallOption) (*api.UserBatchCreateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserBatchCreate", varargs...)
+	ret0, _ := ret[0].(*api.UserBatchCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserBatchCreate indicates an expected call of UserBatchCreate.
+func (mr *MockUserClientMockRecorder) UserBatchCreate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserBatchCreate", reflect.TypeOf((*MockUserClient)(nil).UserBatchCreate), varargs...)
+}
+
 // UserCreate mocks base method.
 func (m *MockUserClient) UserCreate(ctx context.Context, in *api.UserCreateRequest, opts ...grpc.CallOption) (*api.UserCreateResponse, error) {
 	m.ctrl.T.Helper()
@@ -117,6 +217,66 @@ func (mr *MockUserClientMockRecorder) UserGet(ctx, in interface{}, opts ...inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGet", reflect.TypeOf((*MockUserClient)(nil).UserGet), varargs...)
 }
 
+// UserGetAsOf mocks base method.
+func (m *MockUserClient) UserGetAsOf(ctx context.Context, in *api.UserGetAsOfRequest, opts ...grpc.CallOption) (*api.UserGetAsOfResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserGetAsOf", varargs...)
+	ret0, _ := ret[0].(*api.UserGetAsOfResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetAsOf indicates an expected call of UserGetAsOf.
+func (mr *MockUserClientMockRecorder) UserGetAsOf(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetAsOf", reflect.TypeOf((*MockUserClient)(nil).UserGetAsOf), varargs...)
+}
+
+// UserHistory mocks base method.
+func (m *MockUserClient) UserHistory(ctx context.Context, in *api.UserHistoryRequest, opts ...grpc.CallOption) (*api.UserHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserHistory", varargs...)
+	ret0, _ := ret[0].(*api.UserHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserHistory indicates an expected call of UserHistory.
+func (mr *MockUserClientMockRecorder) UserHistory(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserHistory", reflect.TypeOf((*MockUserClient)(nil).UserHistory), varargs...)
+}
+
+// UserImport mocks base method.
+func (m *MockUserClient) UserImport(ctx context.Context, opts ...grpc.CallOption) (api.User_UserImportClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserImport", varargs...)
+	ret0, _ := ret[0].(api.User_UserImportClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserImport indicates an expected call of UserImport.
+func (mr *MockUserClientMockRecorder) UserImport(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserImport", reflect.TypeOf((*MockUserClient)(nil).UserImport), varargs...)
+}
+
 // UserList mocks base method.
 func (m *MockUserClient) UserList(ctx context.Context, in *api.UserListRequest, opts ...grpc.CallOption) (*api.UserListResponse, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +297,66 @@ func (mr *MockUserClientMockRecorder) UserList(ctx, in interface{}, opts ...inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserList", reflect.TypeOf((*MockUserClient)(nil).UserList), varargs...)
 }
 
+// UserLogin mocks base method.
+func (m *MockUserClient) UserLogin(ctx context.Context, in *api.UserLoginRequest, opts ...grpc.CallOption) (*api.UserLoginResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserLogin", varargs...)
+	ret0, _ := ret[0].(*api.UserLoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserLogin indicates an expected call of UserLogin.
+func (mr *MockUserClientMockRecorder) UserLogin(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogin", reflect.TypeOf((*MockUserClient)(nil).UserLogin), varargs...)
+}
+
+// UserLogout mocks base method.
+func (m *MockUserClient) UserLogout(ctx context.Context, in *api.UserLogoutRequest, opts ...grpc.CallOption) (*api.UserLogoutResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserLogout", varargs...)
+	ret0, _ := ret[0].(*api.UserLogoutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserLogout indicates an expected call of UserLogout.
+func (mr *MockUserClientMockRecorder) UserLogout(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogout", reflect.TypeOf((*MockUserClient)(nil).UserLogout), varargs...)
+}
+
+// UserRestore mocks base method.
+func (m *MockUserClient) UserRestore(ctx context.Context, in *api.UserRestoreRequest, opts ...grpc.CallOption) (*api.UserRestoreResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UserRestore", varargs...)
+	ret0, _ := ret[0].(*api.UserRestoreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserRestore indicates an expected call of UserRestore.
+func (mr *MockUserClientMockRecorder) UserRestore(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRestore", reflect.TypeOf((*MockUserClient)(nil).UserRestore), varargs...)
+}
+
 // UserUpdate mocks base method.
 func (m *MockUserClient) UserUpdate(ctx context.Context, in *api.UserUpdateRequest, opts ...grpc.CallOption) (*api.UserUpdateResponse, error) {
 	m.ctrl.T.Helper()
@@ -157,6 +377,143 @@ func (mr *MockUserClientMockRecorder) UserUpdate(ctx, in interface{}, opts ...in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserUpdate", reflect.TypeOf((*MockUserClient)(nil).UserUpdate), varargs...)
 }
 
+// MockUser_UserImportClient is a mock of User_UserImportClient interface.
+type MockUser_UserImportClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUser_UserImportClientMockRecorder
+}
+
+// MockUser_UserImportClientMockRecorder is the mock recorder for MockUser_UserImportClient.
+type MockUser_UserImportClientMockRecorder struct {
+	mock *MockUser_UserImportClient
+}
+
+// NewMockUser_UserImportClient creates a new mock instance.
+func NewMockUser_UserImportClient(ctrl *gomock.Controller) *MockUser_UserImportClient {
+	mock := &MockUser_UserImportClient{ctrl: ctrl}
+	mock.recorder = &MockUser_UserImportClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser_UserImportClient) EXPECT() *MockUser_UserImportClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockUser_UserImportClient) CloseAndRecv() (*api.UserImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*api.UserImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockUser_UserImportClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockUser_UserImportClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockUser_UserImportClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockUser_UserImportClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockUser_UserImportClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockUser_UserImportClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockUser_UserImportClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockUser_UserImportClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockUser_UserImportClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockUser_UserImportClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockUser_UserImportClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockUser_UserImportClient) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockUser_UserImportClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockUser_UserImportClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockUser_UserImportClient) Send(arg0 *api.UserImportRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockUser_UserImportClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockUser_UserImportClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockUser_UserImportClient) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockUser_UserImportClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockUser_UserImportClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockUser_UserImportClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockUser_UserImportClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockUser_UserImportClient)(nil).Trailer))
+}
+
 // MockUser_UserAllListClient is a mock of User_UserAllListClient interface.
 type MockUser_UserAllListClient struct {
 	ctrl     *gomock.Controller
@@ -303,6 +660,66 @@ func (m *MockUserServer) EXPECT() *MockUserServerMockRecorder {
 	return m.recorder
 }
 
+// Data mocks base method.
+func (m *MockUserServer) Data(arg0 context.Context, arg1 *api.DataRequest) (*api.DataResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Data", arg0, arg1)
+	ret0, _ := ret[0].(*api.DataResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Data indicates an expected call of Data.
+func (mr *MockUserServerMockRecorder) Data(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Data", reflect.TypeOf((*MockUserServer)(nil).Data), arg0, arg1)
+}
+
+// SessionList mocks base method.
+func (m *MockUserServer) SessionList(arg0 context.Context, arg1 *api.SessionListRequest) (*api.SessionListResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SessionList", arg0, arg1)
+	ret0, _ := ret[0].(*api.SessionListResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SessionList indicates an expected call of SessionList.
+func (mr *MockUserServerMockRecorder) SessionList(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionList", reflect.TypeOf((*MockUserServer)(nil).SessionList), arg0, arg1)
+}
+
+// SessionRevoke mocks base method.
+func (m *MockUserServer) SessionRevoke(arg0 context.Context, arg1 *api.SessionRevokeRequest) (*api.SessionRevokeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SessionRevoke", arg0, arg1)
+	ret0, _ := ret[0].(*api.SessionRevokeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SessionRevoke indicates an expected call of SessionRevoke.
+func (mr *MockUserServerMockRecorder) SessionRevoke(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SessionRevoke", reflect.TypeOf((*MockUserServer)(nil).SessionRevoke), arg0, arg1)
+}
+
+// TokenRefresh mocks base method.
+func (m *MockUserServer) TokenRefresh(arg0 context.Context, arg1 *api.TokenRefreshRequest) (*api.TokenRefreshResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TokenRefresh", arg0, arg1)
+	ret0, _ := ret[0].(*api.TokenRefreshResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TokenRefresh indicates an expected call of TokenRefresh.
+func (mr *MockUserServerMockRecorder) TokenRefresh(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TokenRefresh", reflect.TypeOf((*MockUserServer)(nil).TokenRefresh), arg0, arg1)
+}
+
 // UserAllList mocks base method.
 func (m *MockUserServer) UserAllList(arg0 *api.UserAllListRequest, arg1 api.User_UserAllListServer) error {
 	m.ctrl.T.Helper()
@@ -317,6 +734,21 @@ func (mr *MockUserServerMockRecorder) UserAllList(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserAllList", reflect.TypeOf((*MockUserServer)(nil).UserAllList), arg0, arg1)
 }
 
+// UserBatchCreate mocks base method.
+func (m *MockUserServer) UserBatchCreate(arg0 context.Context, arg1 *api.UserBatchCreateRequest) (*api.UserBatchCreateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserBatchCreate", arg0, arg1)
+	ret0, _ := ret[0].(*api.UserBatchCreateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserBatchCreate indicates an expected call of UserBatchCreate.
+func (mr *MockUserServerMockRecorder) UserBatchCreate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserBatchCreate", reflect.TypeOf((*MockUserServer)(nil).UserBatchCreate), arg0, arg1)
+}
+
 // UserCreate mocks base method.
 func (m *MockUserServer) UserCreate(arg0 context.Context, arg1 *api.UserCreateRequest) (*api.UserCreateResponse, error) {
 	m.ctrl.T.Helper()
@@ -362,6 +794,50 @@ func (mr *MockUserServerMockRecorder) UserGet(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGet", reflect.TypeOf((*MockUserServer)(nil).UserGet), arg0, arg1)
 }
 
+// UserGetAsOf mocks base method.
+func (m *MockUserServer) UserGetAsOf(arg0 context.Context, arg1 *api.UserGetAsOfRequest) (*api.UserGetAsOfResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserGetAsOf", arg0, arg1)
+	ret0, _ := ret[0].(*api.UserGetAsOfResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserGetAsOf indicates an expected call of UserGetAsOf.
+func (mr *MockUserServerMockRecorder) UserGetAsOf(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserGetAsOf", reflect.TypeOf((*MockUserServer)(nil).UserGetAsOf), arg0, arg1)
+}
+
+// UserHistory mocks base method.
+func (m *MockUserServer) UserHistory(arg0 context.Context, arg1 *api.UserHistoryRequest) (*api.UserHistoryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserHistory", arg0, arg1)
+	ret0, _ := ret[0].(*api.UserHistoryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserHistory indicates an expected call of UserHistory.
+func (mr *MockUserServerMockRecorder) UserHistory(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserHistory", reflect.TypeOf((*MockUserServer)(nil).UserHistory), arg0, arg1)
+}
+
+// UserImport mocks base method.
+func (m *MockUserServer) UserImport(arg0 api.User_UserImportServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserImport", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserImport indicates an expected call of UserImport.
+func (mr *MockUserServerMockRecorder) UserImport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserImport", reflect.TypeOf((*MockUserServer)(nil).UserImport), arg0)
+}
+
 // UserList mocks base method.
 func (m *MockUserServer) UserList(arg0 context.Context, arg1 *api.UserListRequest) (*api.UserListResponse, error) {
 	m.ctrl.T.Helper()
@@ -377,6 +853,51 @@ func (mr *MockUserServerMockRecorder) UserList(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserList", reflect.TypeOf((*MockUserServer)(nil).UserList), arg0, arg1)
 }
 
+// UserLogin mocks base method.
+func (m *MockUserServer) UserLogin(arg0 context.Context, arg1 *api.UserLoginRequest) (*api.UserLoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserLogin", arg0, arg1)
+	ret0, _ := ret[0].(*api.UserLoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserLogin indicates an expected call of UserLogin.
+func (mr *MockUserServerMockRecorder) UserLogin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogin", reflect.TypeOf((*MockUserServer)(nil).UserLogin), arg0, arg1)
+}
+
+// UserLogout mocks base method.
+func (m *MockUserServer) UserLogout(arg0 context.Context, arg1 *api.UserLogoutRequest) (*api.UserLogoutResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserLogout", arg0, arg1)
+	ret0, _ := ret[0].(*api.UserLogoutResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserLogout indicates an expected call of UserLogout.
+func (mr *MockUserServerMockRecorder) UserLogout(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserLogout", reflect.TypeOf((*MockUserServer)(nil).UserLogout), arg0, arg1)
+}
+
+// UserRestore mocks base method.
+func (m *MockUserServer) UserRestore(arg0 context.Context, arg1 *api.UserRestoreRequest) (*api.UserRestoreResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserRestore", arg0, arg1)
+	ret0, _ := ret[0].(*api.UserRestoreResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UserRestore indicates an expected call of UserRestore.
+func (mr *MockUserServerMockRecorder) UserRestore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserRestore", reflect.TypeOf((*MockUserServer)(nil).UserRestore), arg0, arg1)
+}
+
 // UserUpdate mocks base method.
 func (m *MockUserServer) UserUpdate(arg0 context.Context, arg1 *api.UserUpdateRequest) (*api.UserUpdateResponse, error) {
 	m.ctrl.T.Helper()
@@ -439,6 +960,140 @@ func (mr *MockUnsafeUserServerMockRecorder) mustEmbedUnimplementedUserServer() *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "mustEmbedUnimplementedUserServer", reflect.TypeOf((*MockUnsafeUserServer)(nil).mustEmbedUnimplementedUserServer))
 }
 
+// MockUser_UserImportServer is a mock of User_UserImportServer interface.
+type MockUser_UserImportServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUser_UserImportServerMockRecorder
+}
+
+// MockUser_UserImportServerMockRecorder is the mock recorder for MockUser_UserImportServer.
+type MockUser_UserImportServerMockRecorder struct {
+	mock *MockUser_UserImportServer
+}
+
+// NewMockUser_UserImportServer creates a new mock instance.
+func NewMockUser_UserImportServer(ctrl *gomock.Controller) *MockUser_UserImportServer {
+	mock := &MockUser_UserImportServer{ctrl: ctrl}
+	mock.recorder = &MockUser_UserImportServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUser_UserImportServer) EXPECT() *MockUser_UserImportServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockUser_UserImportServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockUser_UserImportServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockUser_UserImportServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockUser_UserImportServer) Recv() (*api.UserImportRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*api.UserImportRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockUser_UserImportServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockUser_UserImportServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockUser_UserImportServer) RecvMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockUser_UserImportServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockUser_UserImportServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockUser_UserImportServer) SendAndClose(arg0 *api.UserImportResponse) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockUser_UserImportServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockUser_UserImportServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockUser_UserImportServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockUser_UserImportServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockUser_UserImportServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockUser_UserImportServer) SendMsg(m interface{}) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockUser_UserImportServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockUser_UserImportServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockUser_UserImportServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockUser_UserImportServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockUser_UserImportServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockUser_UserImportServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockUser_UserImportServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockUser_UserImportServer)(nil).SetTrailer), arg0)
+}
+
 // MockUser_UserAllListServer is a mock of User_UserAllListServer interface.
 type MockUser_UserAllListServer struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,155 @@
+package redis
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/pkg/errors"
+
+	cachePkg "gitlab.ozon.dev/iTukaev/homework/pkg/cache"
+)
+
+// NewCache wraps the client into the cache interface.
+func NewCache(client *redis.Client) cachePkg.Interface {
+	return &cache{
+		client: client,
+	}
+}
+
+type cache struct {
+	client *redis.Client
+}
+
+func (c *cache) Get(ctx context.Context, key string) ([]byte, error) {
+	data, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, cachePkg.ErrNotFound
+	}
+	return data, err
+}
+
+func (c *cache) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	return c.client.Set(ctx, key, value, expiration).Err()
+}
+
+func (c *cache) Swap(ctx context.Context, key string, old, value []byte) error {
+	err := c.client.Watch(ctx, func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if errors.Is(err, redis.Nil) {
+			return cachePkg.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(data, old) {
+			return cachePkg.ErrConflict
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, value, redis.KeepTTL)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		return cachePkg.ErrConflict
+	}
+	return err
+}
+
+func (c *cache) Del(ctx context.Context, keys ...string) error {
+	return c.client.Del(ctx, keys...).Err()
+}
+
+func (c *cache) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := c.client.Exists(ctx, key).Result()
+	return n > 0, err
+}
+
+func (c *cache) Incr(ctx context.Context, key string) (int64, error) {
+	return c.client.Incr(ctx, key).Result()
+}
+
+func (c *cache) Expire(ctx context.Context, key string, expiration time.Duration) error {
+	return c.client.Expire(ctx, key, expiration).Err()
+}
+
+func (c *cache) SAdd(ctx context.Context, key string, members ...string) error {
+	return c.client.SAdd(ctx, key, toInterfaces(members)...).Err()
+}
+
+func (c *cache) SRem(ctx context.Context, key string, members ...string) error {
+	return c.client.SRem(ctx, key, toInterfaces(members)...).Err()
+}
+
+func (c *cache) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.client.SMembers(ctx, key).Result()
+}
+
+func (c *cache) Publish(ctx context.Context, channel string, message []byte) error {
+	return c.client.Publish(ctx, channel, message).Err()
+}
+
+func (c *cache) Subscribe(ctx context.Context, channels ...string) (cachePkg.Subscription, error) {
+	pubSub := c.client.Subscribe(ctx, channels...)
+	// wait for the confirmation, so messages published after Subscribe are not lost
+	if _, err := pubSub.Receive(ctx); err != nil {
+		_ = pubSub.Close()
+		return nil, errors.Wrap(err, "subscribe")
+	}
+
+	sub := &subscription{
+		pubSub:   pubSub,
+		messages: make(chan cachePkg.Message),
+		done:     make(chan struct{}),
+	}
+	go sub.run()
+
+	return sub, nil
+}
+
+func (c *cache) Close() error {
+	return c.client.Close()
+}
+
+type subscription struct {
+	pubSub   *redis.PubSub
+	messages chan cachePkg.Message
+	done     chan struct{}
+	once     sync.Once
+}
+
+func (s *subscription) run() {
+	defer close(s.messages)
+	for msg := range s.pubSub.Channel() {
+		select {
+		case s.messages <- cachePkg.Message{
+			Channel: msg.Channel,
+			Payload: []byte(msg.Payload),
+		}:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *subscription) Messages() <-chan cachePkg.Message {
+	return s.messages
+}
+
+func (s *subscription) Close() error {
+	s.once.Do(func() {
+		close(s.done)
+	})
+	return s.pubSub.Close()
+}
+
+func toInterfaces(members []string) []interface{} {
+	res := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		res = append(res, member)
+	}
+	return res
+}
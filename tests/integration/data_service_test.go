@@ -215,6 +215,6 @@ func (s *repositorySuite) TestData_UserList() {
 func (s *repositorySuite) getUser(name string) (models.User, error) {
 	var user models.User
 	row := s.db.QueryRow(s.ctx, selectUser, name)
-	err := row.Scan(&user.Name, &user.Password, &user.Email, &user.FullName, &user.CreatedAt)
+	err := row.Scan(&user.Name, &user.Password, &user.Email, &user.FullName, &user.CreatedAt, &user.Version, &user.DeletedAt)
 	return user, err
 }
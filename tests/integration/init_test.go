@@ -12,9 +12,11 @@ import (
 	"github.com/ory/dockertest/v3"
 	"github.com/ory/dockertest/v3/docker"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
 
 	apiDataPkg "gitlab.ozon.dev/iTukaev/homework/internal/api/data"
 	userPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user"
+	passwordPkg "gitlab.ozon.dev/iTukaev/homework/internal/pkg/core/user/password"
 	postgresPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres"
 	pb "gitlab.ozon.dev/iTukaev/homework/pkg/api"
 	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
@@ -84,8 +86,12 @@ func (s *repositorySuite) SetupSuite() {
 	}
 	logger := loggerPkg.NewFatal()
 	data := postgresPkg.New(s.db, logger)
-	user := userPkg.New(data, logger)
-	s.user = apiDataPkg.New(user, logger)
+	hasher, err := passwordPkg.New(passwordPkg.Config{Algorithm: passwordPkg.Bcrypt, Cost: bcrypt.MinCost})
+	if err != nil {
+		log.Fatalf("Could not create hasher: %s", err)
+	}
+	user := userPkg.New(data, logger, nil, hasher)
+	s.user = apiDataPkg.New(user, nil, logger)
 }
 
 func (s *repositorySuite) TearDownSuite() {
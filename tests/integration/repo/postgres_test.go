@@ -0,0 +1,89 @@
+//go:build integration
+// +build integration
+
+package repo
+
+import (
+	"context"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+
+	repoPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo"
+	postgresPkg "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres/migrate"
+	pgModels "gitlab.ozon.dev/iTukaev/homework/internal/repo/postgres/models"
+	"gitlab.ozon.dev/iTukaev/homework/internal/repo/repotest"
+	loggerPkg "gitlab.ozon.dev/iTukaev/homework/pkg/logger"
+	"gitlab.ozon.dev/iTukaev/homework/tests/integration/tdb"
+)
+
+const truncateUsers = `TRUNCATE public.users;`
+
+var db *pgxpool.Pool
+
+// TestMain runs PostgreSQL in docker on a random port, so the package can run next to the other integration tests.
+func TestMain(m *testing.M) {
+	ctx := context.Background()
+	dockerPool, err := dockertest.NewPool("")
+	if err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+
+	resource, err := dockerPool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "14.4",
+		Env: []string{
+			"POSTGRES_USER=" + tdb.User,
+			"POSTGRES_PASSWORD=" + tdb.Password,
+			"POSTGRES_DB=" + tdb.DBName,
+		},
+	}, func(config *docker.HostConfig) {
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{
+			Name: "no",
+		}
+	})
+	if err != nil {
+		log.Fatalf("Could not start docker resource: %s", err)
+	}
+
+	if err = dockerPool.Retry(func() error {
+		db, err = postgresPkg.NewPostgres(ctx, tdb.Host, resource.GetPort("5432/tcp"),
+			tdb.User, tdb.Password, tdb.DBName, loggerPkg.NewFatal())
+		return err
+	}); err != nil {
+		log.Fatalf("Could not connect to docker: %s", err)
+	}
+	pg := pgModels.Config{
+		Host:     tdb.Host,
+		Port:     resource.GetPort("5432/tcp"),
+		User:     tdb.User,
+		Password: tdb.Password,
+		DBName:   tdb.DBName,
+	}
+	if err = migrate.Run(ctx, pg, migrate.Up, loggerPkg.NewFatal()); err != nil {
+		log.Fatalf("Could not migrate: %s", err)
+	}
+
+	code := m.Run()
+
+	db.Close()
+	if err = dockerPool.Purge(resource); err != nil {
+		log.Fatalf("Could not purge docker resource: %s", err)
+	}
+	os.Exit(code)
+}
+
+func TestPostgres_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repoPkg.Interface {
+		if _, err := db.Exec(context.Background(), truncateUsers); err != nil {
+			t.Fatalf("truncate users: %v", err)
+		}
+		return postgresPkg.New(db, loggerPkg.NewFatal())
+	})
+}
@@ -6,10 +6,12 @@ package integration
 const (
 	tableCreate = `CREATE TABLE IF NOT EXISTS public.users (
 name          varchar(30) NOT NULL CONSTRAINT name_right CHECK ( name ~ '^[A-Za-z0-9_\.]+$' ) PRIMARY KEY,
-password      varchar(30) NOT NULL,
+password      varchar(255) NOT NULL,
 email         varchar(50) NOT NULL UNIQUE CONSTRAINT email_right CHECK(email ~ '^.*@[A-Za-z0-9\-_\.]*$'),
 full_name     varchar(255) NOT NULL,
-created_at    integer
+created_at    integer,
+version       bigint NOT NULL DEFAULT 1,
+deleted_at    bigint
 );`
 
 	insertUsers = `INSERT INTO public.users (name, password, email, full_name, created_at)
@@ -20,5 +22,5 @@ VALUES ('Piter','123','piter@email.com','Piter Parker',1659447420),
 
 	deleteUsers = `DELETE FROM public.users;`
 
-	selectUser = `SELECT name, password, email, full_name, created_at FROM users WHERE name=$1`
+	selectUser = `SELECT name, password, email, full_name, created_at, version, COALESCE(deleted_at, 0) FROM users WHERE name=$1`
 )